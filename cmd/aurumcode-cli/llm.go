@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/internal/llm/cost"
+	litellmProvider "github.com/Mpaape/AurumCode/internal/llm/provider/litellm"
+	openaiProvider "github.com/Mpaape/AurumCode/internal/llm/provider/openai"
+)
+
+// newOrchestrator builds an LLM orchestrator from environment variables.
+// Requests are budgeted against the configured model, which must have a
+// price when a budget is set.
+func newOrchestrator(perRunBudget float64) (*llm.Orchestrator, error) {
+	llmAPIKey := os.Getenv("LLM_API_KEY")
+	llmBaseURL := os.Getenv("LLM_BASE_URL")
+	openaiAPIKey := os.Getenv("OPENAI_API_KEY")
+
+	var provider llm.Provider
+	model := os.Getenv("LLM_MODEL")
+
+	switch {
+	case llmAPIKey != "" && llmBaseURL != "":
+		if model == "" {
+			model = "gpt-4o-mini"
+		}
+		provider = litellmProvider.NewProvider(llmAPIKey, llmBaseURL, model).
+			WithUserAgent(os.Getenv("LLM_USER_AGENT"))
+	case openaiAPIKey != "":
+		if model == "" {
+			model = "gpt-4"
+		}
+		provider = openaiProvider.NewProvider(openaiAPIKey).
+			WithUserAgent(os.Getenv("LLM_USER_AGENT"))
+	default:
+		return nil, fmt.Errorf("no LLM provider configured: set LLM_API_KEY and LLM_BASE_URL, or OPENAI_API_KEY")
+	}
+
+	prices, err := modelPrices(model)
+	if err != nil {
+		return nil, err
+	}
+	if _, priced := prices[model]; !priced && perRunBudget > 0 {
+		return nil, fmt.Errorf("no price known for model %q: set LLM_PRICE_INPUT_PER_1K and LLM_PRICE_OUTPUT_PER_1K so --budget can be enforced", model)
+	}
+
+	tracker := cost.NewTracker(perRunBudget, 10000.0, prices)
+	return llm.NewOrchestrator(provider, nil, tracker).WithModelKey(model), nil
+}
+
+// modelPrices returns the built-in prices, with model's price overridden by
+// LLM_PRICE_INPUT_PER_1K and LLM_PRICE_OUTPUT_PER_1K when set
+func modelPrices(model string) (map[string]cost.PriceMap, error) {
+	prices := cost.DefaultPrices()
+
+	in, out := os.Getenv("LLM_PRICE_INPUT_PER_1K"), os.Getenv("LLM_PRICE_OUTPUT_PER_1K")
+	if in == "" && out == "" {
+		return prices, nil
+	}
+
+	price, err := cost.ParsePrice(in, out)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LLM_PRICE_INPUT_PER_1K/LLM_PRICE_OUTPUT_PER_1K: %w", err)
+	}
+	prices[model] = price
+	return prices, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: aurumcode-cli <command> [flags]
+
+Commands:
+  precommit  Review staged changes; exits non-zero on blocking issues
+  report     Export a review result (JSON) as a shareable report
+  review     Review a commit range (--range base..head)
+  scan       Review every source file in a directory and report the results
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "precommit":
+		err = runPrecommit(os.Args[2:])
+	case "report":
+		err = runReport(os.Args[2:])
+	case "review":
+		err = runReview(os.Args[2:])
+	case "scan":
+		err = runScan(os.Args[2:])
+	case "-h", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
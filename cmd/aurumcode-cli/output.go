@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/Mpaape/AurumCode/internal/plaintext"
+	"github.com/Mpaape/AurumCode/internal/review"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// stderr receives status messages; plain-text mode strips their emojis
+var stderr io.Writer = os.Stderr
+
+// setPlainText switches status messages and logs to emoji-free output
+func setPlainText(enabled bool) {
+	if !enabled {
+		return
+	}
+	stderr = plaintext.NewWriter(os.Stderr)
+	log.SetOutput(stderr)
+}
+
+// writeOutput writes data to path, or to stdout when path is empty
+func writeOutput(data []byte, path string) error {
+	if path == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// checkGate fails when any issue is at or above the failOn severity;
+// an empty failOn disables the gate
+func checkGate(issues []types.ReviewIssue, failOn string) error {
+	if failOn == "" {
+		return nil
+	}
+
+	gate, err := types.ParseSeverity(failOn)
+	if err != nil {
+		return err
+	}
+
+	if gated := review.GatedIssues(issues, gate); len(gated) > 0 {
+		return fmt.Errorf("%d issue(s) at or above %s severity", len(gated), gate)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+	"github.com/Mpaape/AurumCode/internal/report"
+	"github.com/Mpaape/AurumCode/internal/review"
+)
+
+// runPrecommit reviews the staged changes and fails on gated severities,
+// so it can run as a git pre-commit hook
+func runPrecommit(args []string) error {
+	fs := flag.NewFlagSet("precommit", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "repository directory")
+	failOn := fs.String("fail-on", "error", "exit non-zero on issues at or above this severity")
+	budget := fs.Float64("budget", 1.0, "per-run LLM budget in USD")
+	format := fs.String("format", report.FormatText, "output format: text, json or sarif")
+	output := fs.String("output", "", "output file (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return reviewLocal(review.NewStagedProvider(site.NewDefaultRunner(), *dir), localReviewOptions{
+		dir:    *dir,
+		failOn: *failOn,
+		budget: *budget,
+		format: *format,
+		output: *output,
+	})
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Mpaape/AurumCode/internal/report"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// runReport exports a review result read from --input (or stdin)
+func runReport(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	input := fs.String("input", "", "review result JSON file (default: stdin)")
+	export := fs.String("export", report.FormatHTML, "export format: html, pdf, text, json or sarif")
+	output := fs.String("output", "", "output file (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *input != "" {
+		f, err := os.Open(*input)
+		if err != nil {
+			return fmt.Errorf("failed to open input: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var result types.ReviewResult
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return fmt.Errorf("failed to parse review result: %w", err)
+	}
+
+	data, err := report.Export(result, *export)
+	if err != nil {
+		return err
+	}
+
+	if err := writeOutput(data, *output); err != nil {
+		return err
+	}
+
+	if *output != "" {
+		fmt.Fprintf(stderr, "✅ Report written to %s\n", *output)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/Mpaape/AurumCode/internal/config"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+	"github.com/Mpaape/AurumCode/internal/report"
+	"github.com/Mpaape/AurumCode/internal/review"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// runReview reviews the changes in a commit range, e.g. a feature branch
+// before opening a pull request
+func runReview(args []string) error {
+	fs := flag.NewFlagSet("review", flag.ContinueOnError)
+	commitRange := fs.String("range", "", "commit range to review, as base..head")
+	dir := fs.String("dir", ".", "repository directory")
+	failOn := fs.String("fail-on", "", "exit non-zero on issues at or above this severity (empty disables)")
+	budget := fs.Float64("budget", 1.0, "per-run LLM budget in USD")
+	format := fs.String("format", report.FormatText, "output format: text, json or sarif")
+	output := fs.String("output", "", "output file (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *commitRange == "" {
+		return fmt.Errorf("--range is required")
+	}
+	provider, err := review.NewRangeProvider(site.NewDefaultRunner(), *dir, *commitRange)
+	if err != nil {
+		return err
+	}
+
+	return reviewLocal(provider, localReviewOptions{
+		dir:    *dir,
+		failOn: *failOn,
+		budget: *budget,
+		format: *format,
+		output: *output,
+	})
+}
+
+// localReviewOptions are the flags shared by the local review commands
+type localReviewOptions struct {
+	dir    string // Repository whose config applies
+	failOn string
+	budget float64
+	format string
+	output string
+}
+
+// reviewLocal reviews the diff from provider, writes the result in the
+// requested format and applies the severity gate
+func reviewLocal(provider review.GitProvider, opts localReviewOptions) error {
+	// Reject a bad gate before spending anything on the review
+	if opts.failOn != "" {
+		if _, err := types.ParseSeverity(opts.failOn); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.LoadForRepo(types.NewDefaultConfig(), opts.dir)
+	if err != nil {
+		return err
+	}
+	warnings, err := config.Validate(cfg)
+	for _, w := range warnings {
+		fmt.Fprintf(stderr, "⚠️  %s\n", w)
+	}
+	if err != nil {
+		return err
+	}
+	setPlainText(cfg.PlainText)
+
+	orch, err := newOrchestrator(opts.budget)
+	if err != nil {
+		return err
+	}
+
+	reviewer := review.NewReviewer(orch).WithConfig(cfg.Review)
+	result, err := review.Run(context.Background(), provider, reviewer)
+	if err != nil {
+		return fmt.Errorf("review failed: %w", err)
+	}
+
+	data, err := report.ExportWithOptions(*result, opts.format, report.RenderOptions{PlainText: cfg.PlainText})
+	if err != nil {
+		return err
+	}
+	if err := writeOutput(data, opts.output); err != nil {
+		return err
+	}
+
+	if err := checkGate(result.Issues, opts.failOn); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stderr, "✅ No blocking issues")
+	return nil
+}
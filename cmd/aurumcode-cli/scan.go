@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/config"
+	"github.com/Mpaape/AurumCode/internal/report"
+	"github.com/Mpaape/AurumCode/internal/scan"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// runScan reviews a whole repository and writes an aggregated report
+func runScan(args []string) error {
+	fs := flag.NewFlagSet("scan", flag.ContinueOnError)
+	format := fs.String("format", report.FormatText, "report format: text, json, sarif or html")
+	output := fs.String("output", "", "output file (default: stdout)")
+	batchSize := fs.Int("batch-size", 5, "maximum files per LLM request")
+	budget := fs.Float64("budget", 5.0, "per-run LLM budget in USD")
+	statePath := fs.String("state", ".aurumcode/cache/scan.json", "incremental scan state file (empty disables)")
+	failOn := fs.String("fail-on", "", "exit non-zero on issues at or above this severity (empty disables)")
+
+	// Accept the directory before or after the flags
+	dir := "."
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		dir, args = args[0], args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		dir = fs.Arg(0)
+	}
+
+	*format = strings.ToLower(*format)
+
+	cfg, err := config.LoadForRepo(types.NewDefaultConfig(), dir)
+	if err != nil {
+		return err
+	}
+	warnings, err := config.Validate(cfg)
+	for _, w := range warnings {
+		fmt.Fprintf(stderr, "⚠️  %s\n", w)
+	}
+	if err != nil {
+		return err
+	}
+	setPlainText(cfg.PlainText)
+
+	// Keep scan state with the scanned repository
+	if *statePath != "" && !filepath.IsAbs(*statePath) {
+		*statePath = filepath.Join(dir, *statePath)
+	}
+
+	orch, err := newOrchestrator(*budget)
+	if err != nil {
+		return err
+	}
+
+	result, err := scan.NewScanner(orch).
+		WithBatchSize(*batchSize).
+		WithConfig(cfg.Review).
+		WithExcludedDirs(cfg.Documentation.ExcludedDirs...).
+		WithExtensionOverrides(cfg.Documentation.ExtensionOverrides).
+		WithStatePath(*statePath).
+		Scan(context.Background(), dir)
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	if result.BudgetExhausted {
+		fmt.Fprintf(stderr, "⚠️  Budget exhausted: %d files were not reviewed\n", len(result.FilesSkipped))
+	}
+
+	var data []byte
+	switch *format {
+	case report.FormatJSON:
+		// The full scan result, which embeds the review
+		data, err = json.MarshalIndent(result, "", "  ")
+	case report.FormatText, report.FormatSARIF, report.FormatHTML:
+		data, err = report.ExportWithOptions(result.Review, *format, report.RenderOptions{PlainText: cfg.PlainText})
+	default:
+		return fmt.Errorf("unsupported format %q", *format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := writeOutput(data, *output); err != nil {
+		return err
+	}
+
+	if *output != "" {
+		fmt.Fprintf(stderr, "✅ Reviewed %d files (%d unchanged), %d issues → %s\n",
+			len(result.FilesReviewed), len(result.FilesCached), len(result.Review.Issues), *output)
+	}
+	return checkGate(result.Review.Issues, *failOn)
+}
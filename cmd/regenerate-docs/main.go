@@ -2,47 +2,30 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"flag"
 	"log"
 	"os"
 
-	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
-	bashExtractor "github.com/Mpaape/AurumCode/internal/documentation/extractors/bash"
-	cppExtractor "github.com/Mpaape/AurumCode/internal/documentation/extractors/cpp"
-	csharpExtractor "github.com/Mpaape/AurumCode/internal/documentation/extractors/csharp"
-	goExtractor "github.com/Mpaape/AurumCode/internal/documentation/extractors/go"
-	javascriptExtractor "github.com/Mpaape/AurumCode/internal/documentation/extractors/javascript"
-	powershellExtractor "github.com/Mpaape/AurumCode/internal/documentation/extractors/powershell"
-	pythonExtractor "github.com/Mpaape/AurumCode/internal/documentation/extractors/python"
-	rustExtractor "github.com/Mpaape/AurumCode/internal/documentation/extractors/rust"
+	"github.com/Mpaape/AurumCode/internal/config"
+	"github.com/Mpaape/AurumCode/internal/documentation/architecture"
+	"github.com/Mpaape/AurumCode/internal/documentation/dependencies"
+	"github.com/Mpaape/AurumCode/internal/documentation/glossary"
 	"github.com/Mpaape/AurumCode/internal/documentation/site"
 	"github.com/Mpaape/AurumCode/internal/llm"
 	"github.com/Mpaape/AurumCode/internal/llm/cost"
 	litellmProvider "github.com/Mpaape/AurumCode/internal/llm/provider/litellm"
 	openaiProvider "github.com/Mpaape/AurumCode/internal/llm/provider/openai"
 	"github.com/Mpaape/AurumCode/internal/pipeline"
+	"github.com/Mpaape/AurumCode/pkg/types"
 )
 
-type extractorAlias struct {
-	base extractors.Extractor
-	lang extractors.Language
-}
-
-func (a *extractorAlias) Extract(ctx context.Context, req *extractors.ExtractRequest) (*extractors.ExtractResult, error) {
-	reqCopy := *req
-	reqCopy.Language = a.lang
-	return a.base.Extract(ctx, &reqCopy)
-}
-
-func (a *extractorAlias) Validate(ctx context.Context) error {
-	return a.base.Validate(ctx)
-}
-
-func (a *extractorAlias) Language() extractors.Language {
-	return a.lang
-}
-
 func main() {
+	resume := flag.Bool("resume", false, "skip languages completed by a previous interrupted run")
+	confirm := flag.Bool("confirm", false, "abort before running if the estimated LLM cost exceeds --max-cost or cannot be priced")
+	maxCost := flag.Float64("max-cost", 1.0, "maximum estimated LLM cost in USD allowed with --confirm")
+	failFast := flag.Bool("fail-fast", false, "abort on the first extraction or step error instead of continuing")
+	flag.Parse()
+
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 	log.Println("🚀 AurumCode - Regenerating Complete Documentation")
 	log.Println("================================================")
@@ -52,16 +35,17 @@ func main() {
 	openaiAPIKey := os.Getenv("OPENAI_API_KEY")
 
 	var llmOrch *llm.Orchestrator
+	model := os.Getenv("LLM_MODEL")
 
 	switch {
 	case llmAPIKey != "" && llmBaseURL != "":
-		model := os.Getenv("LLM_MODEL")
 		if model == "" {
 			model = "gpt-4o-mini"
 		}
-		provider := litellmProvider.NewProvider(llmAPIKey, llmBaseURL, model)
-		tracker := cost.NewTracker(1000.0, 10000.0, map[string]cost.PriceMap{})
-		llmOrch = llm.NewOrchestrator(provider, nil, tracker)
+		provider := litellmProvider.NewProvider(llmAPIKey, llmBaseURL, model).
+			WithUserAgent(os.Getenv("LLM_USER_AGENT"))
+		tracker := cost.NewTracker(1000.0, 10000.0, modelPrices(model))
+		llmOrch = llm.NewOrchestrator(provider, nil, tracker).WithModelKey(model)
 		log.Printf("✓ LiteLLM configured (%s)", llmBaseURL)
 	case llmAPIKey != "" && llmBaseURL == "":
 		log.Println("⚠️  LLM_BASE_URL not set - skipping LiteLLM provider")
@@ -70,14 +54,27 @@ func main() {
 	}
 
 	if llmOrch == nil && openaiAPIKey != "" {
+		if model == "" {
+			model = "gpt-4"
+		}
 		provider := openaiProvider.NewProvider(openaiAPIKey)
-		tracker := cost.NewTracker(1000.0, 10000.0, map[string]cost.PriceMap{
-			"gpt-4": {InputPer1K: 0.03, OutputPer1K: 0.06},
-		})
-		llmOrch = llm.NewOrchestrator(provider, nil, tracker)
+		if baseURL := os.Getenv("OPENAI_BASE_URL"); baseURL != "" {
+			provider = openaiProvider.NewProviderWithBaseURL(openaiAPIKey, baseURL)
+		}
+		provider.WithUserAgent(os.Getenv("LLM_USER_AGENT"))
+		tracker := cost.NewTracker(1000.0, 10000.0, modelPrices(model))
+		llmOrch = llm.NewOrchestrator(provider, nil, tracker).WithModelKey(model)
 		log.Println("✓ OpenAI provider configured")
 	}
 
+	// An unreachable endpoint would fail every LLM call; run without LLM features instead
+	if llmOrch != nil {
+		if err := llmOrch.Probe(context.Background()); err != nil {
+			log.Printf("⚠️  LLM endpoint unreachable - LLM features will be disabled: %v", err)
+			llmOrch = nil
+		}
+	}
+
 	if llmOrch != nil {
 		log.Printf("✓ LLM Orchestrator created (providers: %v)", llmOrch.GetProviderChain())
 	} else {
@@ -86,7 +83,25 @@ func main() {
 
 	runner := site.NewDefaultRunner()
 
-	config := &pipeline.ExtractorPipelineConfig{
+	repoConfig, err := config.LoadForRepo(types.NewDefaultConfig(), ".")
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	warnings, err := config.Validate(repoConfig)
+	for _, warning := range warnings {
+		log.Printf("⚠️  %s", warning)
+	}
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	// DOCS_TIMEOUT overrides the timeout from the repo config
+	docsConfig := repoConfig.Documentation
+	if timeout := os.Getenv("DOCS_TIMEOUT"); timeout != "" {
+		docsConfig.Timeout = timeout
+	}
+
+	pipelineConfig := &pipeline.ExtractorPipelineConfig{
 		SourceDir:       ".",
 		OutputDir:       ".aurumcode",
 		DocsDir:         ".aurumcode",
@@ -95,21 +110,70 @@ func main() {
 		GenerateWelcome: llmOrch != nil,
 		ValidateJekyll:  false,
 		DeployGHPages:   false,
+		Resume:          *resume,
+	}
+	if err := pipelineConfig.ApplyDocumentation(docsConfig); err != nil {
+		log.Fatalf("❌ %v", err)
 	}
+	if *failFast {
+		pipelineConfig.ErrorMode = pipeline.ErrorModeFailFast
+	}
+
+	extractorPipeline := pipeline.NewExtractorPipeline(pipelineConfig, runner, llmOrch)
 
-	extractorPipeline := pipeline.NewExtractorPipeline(config, runner, llmOrch)
-	if err := registerLanguageExtractors(extractorPipeline, runner); err != nil {
-		log.Fatalf("❌ Failed to register language extractors: %v", err)
+	estimate, err := extractorPipeline.EstimateLLMCost()
+	if err != nil {
+		log.Printf("⚠️  Could not estimate LLM cost: %v", err)
+	} else if estimate.Calls > 0 {
+		log.Printf("💰 Estimated LLM usage: %d calls, ~%d tokens in, up to %d tokens out, ~$%.4f",
+			estimate.Calls, estimate.TokensIn, estimate.TokensOut, estimate.USD)
+		if estimate.Unpriced > 0 {
+			log.Printf("   %d call(s) use a model without configured pricing and are not included (set LLM_PRICE_INPUT_PER_1K and LLM_PRICE_OUTPUT_PER_1K)", estimate.Unpriced)
+		}
+		if *confirm {
+			if err := pipeline.CheckCostLimit(estimate, *maxCost); err != nil {
+				log.Fatalf("❌ Aborting: %v (raise --max-cost to proceed)", err)
+			}
+		}
 	}
 
 	log.Println("\n📝 Running Documentation Extraction...")
 	log.Println("────────────────────────────────────────")
 
 	ctx := context.Background()
-	if err := extractorPipeline.Run(ctx); err != nil {
+	result, err := extractorPipeline.Run(ctx)
+	if err != nil {
+		for _, step := range result.Steps {
+			log.Printf("   %s: %s", step.Name, step.Status)
+		}
 		log.Fatalf("❌ Pipeline failed: %v", err)
 	}
 
+	if docsConfig.Categories.Architecture {
+		if path, err := architecture.Generate(pipelineConfig.SourceDir, pipelineConfig.DocsDir); err != nil {
+			log.Printf("⚠️  Architecture diagram skipped: %v", err)
+		} else {
+			log.Printf("✓ Architecture diagram written to %s", path)
+		}
+	}
+
+	// Dependency update checks hit the Go module proxy, so they are opt-in
+	var checker dependencies.UpdateChecker
+	if os.Getenv("DOCS_CHECK_UPDATES") == "true" {
+		checker = dependencies.NewGoProxyChecker("")
+	}
+	if path, err := dependencies.Generate(ctx, pipelineConfig.SourceDir, pipelineConfig.DocsDir, checker); err != nil {
+		log.Printf("⚠️  Dependency page skipped: %v", err)
+	} else {
+		log.Printf("✓ Dependency page written to %s", path)
+	}
+
+	if path, err := glossary.Generate(pipelineConfig.DocsDir, docsConfig.GlossaryTerms); err != nil {
+		log.Printf("⚠️  Glossary skipped: %v", err)
+	} else {
+		log.Printf("✓ Glossary written to %s", path)
+	}
+
 	log.Println("────────────────────────────────────────")
 	log.Println("✅ Documentation regeneration completed!")
 	log.Println("\n📊 Generated documentation in:")
@@ -129,50 +193,20 @@ func main() {
 	}
 }
 
-func registerLanguageExtractors(p *pipeline.ExtractorPipeline, runner site.CommandRunner) error {
-	register := func(ext extractors.Extractor) error {
-		if err := p.RegisterExtractor(ext); err != nil {
-			return fmt.Errorf("register %s extractor: %w", ext.Language(), err)
-		}
-		return nil
-	}
-
-	if err := register(goExtractor.NewGoExtractor(runner)); err != nil {
-		return err
-	}
+// modelPrices returns the built-in prices, with model's price overridden by
+// LLM_PRICE_INPUT_PER_1K and LLM_PRICE_OUTPUT_PER_1K when set
+func modelPrices(model string) map[string]cost.PriceMap {
+	prices := cost.DefaultPrices()
 
-	jsExtractor := javascriptExtractor.NewJSExtractor(runner)
-	if err := register(jsExtractor); err != nil {
-		return err
+	in, out := os.Getenv("LLM_PRICE_INPUT_PER_1K"), os.Getenv("LLM_PRICE_OUTPUT_PER_1K")
+	if in == "" && out == "" {
+		return prices
 	}
 
-	if err := register(&extractorAlias{base: jsExtractor, lang: extractors.LanguageTypeScript}); err != nil {
-		return err
+	price, err := cost.ParsePrice(in, out)
+	if err != nil {
+		log.Fatalf("❌ Invalid LLM_PRICE_INPUT_PER_1K/LLM_PRICE_OUTPUT_PER_1K: %v", err)
 	}
-
-	if err := register(pythonExtractor.NewPythonExtractor(runner)); err != nil {
-		return err
-	}
-
-	if err := register(csharpExtractor.NewCSharpExtractor(runner)); err != nil {
-		return err
-	}
-
-	if err := register(cppExtractor.NewCPPExtractor(runner)); err != nil {
-		return err
-	}
-
-	if err := register(rustExtractor.NewRustExtractor(runner)); err != nil {
-		return err
-	}
-
-	if err := register(bashExtractor.NewBashExtractor(runner)); err != nil {
-		return err
-	}
-
-	if err := register(powershellExtractor.NewPowerShellExtractor(runner)); err != nil {
-		return err
-	}
-
-	return nil
+	prices[model] = price
+	return prices
 }
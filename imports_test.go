@@ -0,0 +1,79 @@
+package aurumcode_test
+
+import (
+	"bufio"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestCanonicalImportPath fails when a file imports first-party packages
+// under a path other than the module path from go.mod (e.g. "aurumcode/internal/...").
+// Both forms would compile as distinct packages with incompatible types.
+func TestCanonicalImportPath(t *testing.T) {
+	module := readModulePath(t)
+	alias := strings.ToLower(path.Base(module)) + "/"
+
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(".", func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if p != "." && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(p, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, p, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if strings.HasPrefix(strings.ToLower(importPath), alias) {
+				t.Errorf("%s: import %q should use the module path %q", fset.Position(spec.Pos()), importPath, module)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to scan sources: %v", err)
+	}
+}
+
+func readModulePath(t *testing.T) string {
+	t.Helper()
+
+	f, err := os.Open("go.mod")
+	if err != nil {
+		t.Fatalf("failed to open go.mod: %v", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+
+	t.Fatal("no module directive in go.mod")
+	return ""
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUndefinedVariable indicates a ${VAR} reference with no value in strict mode
+var ErrUndefinedVariable = errors.New("undefined environment variable")
+
+// envPattern matches ${VAR} references
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Loader reads AurumCode configuration files
+type Loader struct {
+	strictEnv bool
+	lookupEnv func(string) (string, bool)
+}
+
+// NewLoader creates a loader that expands ${VAR} references from the environment
+func NewLoader() *Loader {
+	return &Loader{
+		lookupEnv: os.LookupEnv,
+	}
+}
+
+// WithStrictEnv makes loading fail when a referenced variable is not set.
+// Otherwise undefined variables expand to an empty string, so an unquoted
+// value made only of such a reference keeps its default.
+func (l *Loader) WithStrictEnv(strict bool) *Loader {
+	l.strictEnv = strict
+	return l
+}
+
+// LoadFromPath reads configuration from a YAML file
+func LoadFromPath(path string) (*types.Config, error) {
+	return NewLoader().LoadFromPath(path)
+}
+
+// LoadFromReader reads configuration YAML from r
+func LoadFromReader(r io.Reader) (*types.Config, error) {
+	return NewLoader().LoadFromReader(r)
+}
+
+// LoadFromPath reads configuration from a YAML file
+func (l *Loader) LoadFromPath(path string) (*types.Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config: %w", err)
+	}
+	defer f.Close()
+
+	cfg, err := l.LoadFromReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// LoadFromReader reads configuration YAML from r. Fields missing from the
+// document keep the values from types.NewDefaultConfig, and older schema
+// versions are migrated to CurrentVersion with a logged warning per change.
+// A document without a version is read as the current schema.
+func (l *Loader) LoadFromReader(r io.Reader) (*types.Config, error) {
+	cfg := types.NewDefaultConfig()
+
+	renamed, err := l.decode(r, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, migrated, err := Migrate(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range append(renamed, migrated...) {
+		log.Printf("⚠️  Config migrated: %s", change)
+	}
+
+	return cfg, nil
+}
+
+// decode expands and decodes the YAML in r into cfg, returning the legacy
+// keys that were renamed
+func (l *Loader) decode(r io.Reader, cfg *types.Config) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if doc.Kind == 0 {
+		// Empty document
+		return nil, nil
+	}
+
+	undefined := make(map[string]bool)
+	l.expandNode(&doc, undefined)
+	if l.strictEnv && len(undefined) > 0 {
+		names := make([]string, 0, len(undefined))
+		for name := range undefined {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%w: %s", ErrUndefinedVariable, strings.Join(names, ", "))
+	}
+
+	renamed := migrateDocument(&doc)
+
+	if err := doc.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	return renamed, nil
+}
+
+// expandNode replaces ${VAR} references in scalar values, recording
+// variables that are not set
+func (l *Loader) expandNode(node *yaml.Node, undefined map[string]bool) {
+	if node.Kind == yaml.ScalarNode {
+		if !strings.Contains(node.Value, "${") {
+			return
+		}
+
+		node.Value = envPattern.ReplaceAllStringFunc(node.Value, func(ref string) string {
+			name := envPattern.FindStringSubmatch(ref)[1]
+			value, ok := l.lookupEnv(name)
+			if !ok {
+				undefined[name] = true
+			}
+			return value
+		})
+
+		// Let unquoted values resolve again, so ${MAX_TOKENS} can fill an int
+		if node.Style == 0 {
+			node.Tag = ""
+		}
+		return
+	}
+
+	for _, child := range node.Content {
+		l.expandNode(child, undefined)
+	}
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFromReader(t *testing.T) {
+	cfg, err := LoadFromReader(strings.NewReader(`
+version: "2.0"
+llm:
+  provider: openai
+  model: gpt-4
+  max_tokens: 8000
+outputs:
+  deploy_site: false
+`))
+	if err != nil {
+		t.Fatalf("LoadFromReader failed: %v", err)
+	}
+
+	if cfg.LLM.Provider != "openai" || cfg.LLM.Model != "gpt-4" {
+		t.Errorf("LLM = %+v, want openai/gpt-4", cfg.LLM)
+	}
+	if cfg.LLM.MaxTokens != 8000 {
+		t.Errorf("MaxTokens = %d, want 8000", cfg.LLM.MaxTokens)
+	}
+	if cfg.Outputs.DeploySite {
+		t.Error("explicit deploy_site: false should override the default")
+	}
+
+	// Unset fields keep their defaults
+	if cfg.LLM.Temperature != 0.3 {
+		t.Errorf("Temperature = %v, want default 0.3", cfg.LLM.Temperature)
+	}
+	if cfg.Documentation.OutputDirectory != "docs" {
+		t.Errorf("OutputDirectory = %q, want default docs", cfg.Documentation.OutputDirectory)
+	}
+}
+
+func TestLoadFromReader_Empty(t *testing.T) {
+	cfg, err := LoadFromReader(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("LoadFromReader failed: %v", err)
+	}
+	if cfg.Version != "2.0" {
+		t.Errorf("Version = %q, want default 2.0", cfg.Version)
+	}
+}
+
+func TestLoadFromReader_InvalidYAML(t *testing.T) {
+	if _, err := LoadFromReader(strings.NewReader("llm: [unclosed")); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
+
+func TestLoadFromPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("llm:\n  model: local\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("LoadFromPath failed: %v", err)
+	}
+	if cfg.LLM.Model != "local" {
+		t.Errorf("Model = %q, want local", cfg.LLM.Model)
+	}
+
+	if _, err := LoadFromPath(filepath.Join(t.TempDir(), "missing.yml")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestLoadFromReader_EnvExpansion(t *testing.T) {
+	t.Setenv("AURUM_TEST_MODEL", "gpt-4o")
+	t.Setenv("AURUM_TEST_TOKENS", "2048")
+	t.Setenv("AURUM_TEST_HOST", "docs.example.com")
+
+	cfg, err := NewLoader().WithStrictEnv(true).LoadFromReader(strings.NewReader(`
+llm:
+  model: ${AURUM_TEST_MODEL}
+  max_tokens: ${AURUM_TEST_TOKENS}
+documentation:
+  deploy:
+    base_url: "https://${AURUM_TEST_HOST}/project"
+`))
+	if err != nil {
+		t.Fatalf("LoadFromReader failed: %v", err)
+	}
+
+	if cfg.LLM.Model != "gpt-4o" {
+		t.Errorf("Model = %q, want gpt-4o", cfg.LLM.Model)
+	}
+	if cfg.LLM.MaxTokens != 2048 {
+		t.Errorf("MaxTokens = %d, want 2048", cfg.LLM.MaxTokens)
+	}
+	if got := cfg.Documentation.Deploy.BaseURL; got != "https://docs.example.com/project" {
+		t.Errorf("BaseURL = %q, want expanded URL", got)
+	}
+}
+
+func TestLoadFromReader_UndefinedEnv(t *testing.T) {
+	const doc = `
+llm:
+  model: ${AURUM_TEST_UNSET_B}
+  provider: ${AURUM_TEST_UNSET_A}
+`
+	os.Unsetenv("AURUM_TEST_UNSET_A")
+	os.Unsetenv("AURUM_TEST_UNSET_B")
+
+	t.Run("lenient keeps defaults", func(t *testing.T) {
+		cfg, err := LoadFromReader(strings.NewReader(doc))
+		if err != nil {
+			t.Fatalf("LoadFromReader failed: %v", err)
+		}
+		if cfg.LLM.Model != "sonnet-like" {
+			t.Errorf("Model = %q, want default sonnet-like", cfg.LLM.Model)
+		}
+	})
+
+	t.Run("lenient quoted expands to empty", func(t *testing.T) {
+		cfg, err := LoadFromReader(strings.NewReader(`llm: {model: "${AURUM_TEST_UNSET_A}"}`))
+		if err != nil {
+			t.Fatalf("LoadFromReader failed: %v", err)
+		}
+		if cfg.LLM.Model != "" {
+			t.Errorf("Model = %q, want empty", cfg.LLM.Model)
+		}
+	})
+
+	t.Run("strict fails", func(t *testing.T) {
+		_, err := NewLoader().WithStrictEnv(true).LoadFromReader(strings.NewReader(doc))
+		if !errors.Is(err, ErrUndefinedVariable) {
+			t.Fatalf("expected ErrUndefinedVariable, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "AURUM_TEST_UNSET_A, AURUM_TEST_UNSET_B") {
+			t.Errorf("error should list undefined variables in order, got %v", err)
+		}
+	})
+}
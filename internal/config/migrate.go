@@ -0,0 +1,182 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentVersion is the configuration schema version written by NewDefaultConfig
+const CurrentVersion = "2.0"
+
+// ErrUnsupportedVersion indicates a config schema newer than this build understands
+var ErrUnsupportedVersion = errors.New("unsupported config version")
+
+// legacyRenames maps 1.x keys to their 2.0 location
+var legacyRenames = []struct {
+	from string
+	to   string
+}{
+	{"output.review", "outputs.comment_on_pr"},
+	{"output.documentation", "outputs.update_docs"},
+	{"output.tests", "outputs.generate_tests"},
+}
+
+// Migrate upgrades cfg to CurrentVersion, returning the migrated copy and a
+// description of each change applied. An empty version is treated as 1.0.
+func Migrate(cfg *types.Config) (*types.Config, []string, error) {
+	major, err := majorVersion(cfg.Version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := *cfg
+	var applied []string
+
+	switch {
+	case major > 2:
+		return nil, nil, fmt.Errorf("%w: %s (latest is %s)", ErrUnsupportedVersion, cfg.Version, CurrentVersion)
+	case major == 2:
+		return &out, nil, nil
+	}
+
+	// 1.x had no documentation section
+	if isZeroDocumentation(out.Documentation) {
+		out.Documentation = types.NewDefaultConfig().Documentation
+		applied = append(applied, "documentation: added 2.0 defaults")
+	}
+
+	from := cfg.Version
+	if from == "" {
+		from = "1.0"
+	}
+	out.Version = CurrentVersion
+	applied = append(applied, fmt.Sprintf("version: %s -> %s", from, CurrentVersion))
+
+	return &out, applied, nil
+}
+
+// majorVersion parses the major component of a version such as "1.2"
+func majorVersion(version string) (int, error) {
+	if version == "" {
+		return 1, nil
+	}
+
+	major, _, _ := strings.Cut(version, ".")
+	n, err := strconv.Atoi(major)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedVersion, version)
+	}
+	return n, nil
+}
+
+// isZeroDocumentation reports whether no documentation setting was provided
+func isZeroDocumentation(doc types.DocumentationConfig) bool {
+	return !doc.Enabled && doc.Mode == "" && doc.OutputDirectory == "" &&
+		len(doc.Languages) == 0 && doc.SiteGenerator == "" && doc.Theme == "" &&
+		doc.Deploy == (types.DeployConfig{}) &&
+		doc.Features == (types.DocFeaturesConfig{}) &&
+		doc.Categories == (types.DocCategoriesConfig{}) &&
+		doc.Cache == (types.CacheConfig{}) &&
+		doc.Timeout == "" && len(doc.GlossaryTerms) == 0 &&
+		doc.Welcome == (types.WelcomeConfig{}) &&
+		len(doc.ExcludedDirs) == 0 && len(doc.ExtensionOverrides) == 0
+}
+
+// migrateDocument moves renamed 1.x keys to their 2.0 location in a parsed
+// config document. Keys already present at the new location win.
+func migrateDocument(doc *yaml.Node) []string {
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var applied []string
+	for _, rename := range legacyRenames {
+		from := strings.Split(rename.from, ".")
+		to := strings.Split(rename.to, ".")
+
+		value := lookupKey(root, from)
+		if value == nil || lookupKey(root, to) != nil {
+			continue
+		}
+
+		setKey(root, to, value)
+		deleteKey(root, from)
+		applied = append(applied, fmt.Sprintf("%s: renamed to %s", rename.from, rename.to))
+	}
+
+	return applied
+}
+
+// lookupKey returns the value at path in a mapping node, or nil
+func lookupKey(node *yaml.Node, path []string) *yaml.Node {
+	for _, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
+
+// setKey stores value at path, creating intermediate mappings as needed
+func setKey(node *yaml.Node, path []string, value *yaml.Node) {
+	for i, key := range path {
+		child := lookupKey(node, path[i:i+1])
+		if i == len(path)-1 {
+			if child != nil {
+				*child = *value
+				return
+			}
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+			return
+		}
+
+		if child == nil || child.Kind != yaml.MappingNode {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+		}
+		node = child
+	}
+}
+
+// deleteKey removes the value at path, dropping parents left empty
+func deleteKey(node *yaml.Node, path []string) {
+	parent := lookupKey(node, path[:len(path)-1])
+	if parent == nil || parent.Kind != yaml.MappingNode {
+		return
+	}
+
+	key := path[len(path)-1]
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+			break
+		}
+	}
+
+	if len(parent.Content) == 0 && len(path) > 1 {
+		deleteKey(node, path[:len(path)-1])
+	}
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+func TestMigrate_FromV1(t *testing.T) {
+	cfg := &types.Config{
+		Version: "1.0",
+		LLM:     types.LLMConfig{Provider: "openai", Model: "gpt-4"},
+	}
+
+	migrated, applied, err := Migrate(cfg)
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	if migrated.Version != CurrentVersion {
+		t.Errorf("Version = %q, want %q", migrated.Version, CurrentVersion)
+	}
+	if migrated.LLM != cfg.LLM {
+		t.Errorf("LLM changed during migration: %+v", migrated.LLM)
+	}
+	if !reflect.DeepEqual(migrated.Documentation, types.NewDefaultConfig().Documentation) {
+		t.Error("expected documentation defaults to be added")
+	}
+
+	want := []string{"documentation: added 2.0 defaults", "version: 1.0 -> 2.0"}
+	if !reflect.DeepEqual(applied, want) {
+		t.Errorf("applied = %v, want %v", applied, want)
+	}
+
+	if cfg.Version != "1.0" {
+		t.Error("Migrate should not modify its input")
+	}
+}
+
+func TestMigrate_KeepsExistingDocumentation(t *testing.T) {
+	cfg := &types.Config{
+		Version:       "1.2",
+		Documentation: types.DocumentationConfig{OutputDirectory: "site"},
+	}
+
+	migrated, applied, err := Migrate(cfg)
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if migrated.Documentation.OutputDirectory != "site" {
+		t.Errorf("OutputDirectory = %q, want site", migrated.Documentation.OutputDirectory)
+	}
+	if len(applied) != 1 || applied[0] != "version: 1.2 -> 2.0" {
+		t.Errorf("applied = %v, want only the version bump", applied)
+	}
+}
+
+func TestMigrate_KeepsDetectorSettings(t *testing.T) {
+	cfg := &types.Config{
+		Version: "1.1",
+		Documentation: types.DocumentationConfig{
+			ExcludedDirs:       []string{"third_party"},
+			ExtensionOverrides: map[string]string{".dsl": "python"},
+		},
+	}
+
+	migrated, applied, err := Migrate(cfg)
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if !reflect.DeepEqual(migrated.Documentation, cfg.Documentation) {
+		t.Errorf("Documentation = %+v, want %+v", migrated.Documentation, cfg.Documentation)
+	}
+	if len(applied) != 1 || applied[0] != "version: 1.1 -> 2.0" {
+		t.Errorf("applied = %v, want only the version bump", applied)
+	}
+}
+
+func TestMigrate_Current(t *testing.T) {
+	migrated, applied, err := Migrate(types.NewDefaultConfig())
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("expected no migrations, got %v", applied)
+	}
+	if migrated.Version != CurrentVersion {
+		t.Errorf("Version = %q, want %q", migrated.Version, CurrentVersion)
+	}
+}
+
+func TestMigrate_Unsupported(t *testing.T) {
+	for _, version := range []string{"3.0", "latest", "0.9"} {
+		if _, _, err := Migrate(&types.Config{Version: version}); !errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("Migrate(%q): expected ErrUnsupportedVersion, got %v", version, err)
+		}
+	}
+}
+
+func TestLoadFromReader_MigratesV1(t *testing.T) {
+	cfg, err := LoadFromReader(strings.NewReader(`
+version: "1.0"
+llm:
+  provider: openai
+output:
+  review: false
+  documentation: true
+  tests: false
+`))
+	if err != nil {
+		t.Fatalf("LoadFromReader failed: %v", err)
+	}
+
+	if cfg.Version != CurrentVersion {
+		t.Errorf("Version = %q, want %q", cfg.Version, CurrentVersion)
+	}
+	if cfg.Outputs.CommentOnPR {
+		t.Error("output.review: false should migrate to outputs.comment_on_pr")
+	}
+	if !cfg.Outputs.UpdateDocs {
+		t.Error("output.documentation: true should migrate to outputs.update_docs")
+	}
+	if cfg.Outputs.GenerateTests {
+		t.Error("output.tests: false should migrate to outputs.generate_tests")
+	}
+}
+
+func TestLoadFromReader_NewKeyWinsOverLegacy(t *testing.T) {
+	cfg, err := LoadFromReader(strings.NewReader(`
+output:
+  review: true
+outputs:
+  comment_on_pr: false
+`))
+	if err != nil {
+		t.Fatalf("LoadFromReader failed: %v", err)
+	}
+	if cfg.Outputs.CommentOnPR {
+		t.Error("outputs.comment_on_pr should take precedence over output.review")
+	}
+}
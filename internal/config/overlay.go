@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// RepoConfigPath is where a repository keeps its config overrides
+const RepoConfigPath = ".aurumcode/config.yml"
+
+// Merge layers overlay onto base and returns the result; neither input is
+// modified. Non-zero overlay fields win, maps are merged key by key, and
+// non-empty overlay slices replace the base slice. Because zero values are
+// treated as unset, an overlay cannot switch a base flag back to false.
+func Merge(base, overlay *types.Config) *types.Config {
+	out := &types.Config{}
+	if base != nil {
+		mergeValue(reflect.ValueOf(out).Elem(), reflect.ValueOf(base).Elem())
+	}
+	if overlay != nil {
+		mergeValue(reflect.ValueOf(out).Elem(), reflect.ValueOf(overlay).Elem())
+	}
+	return out
+}
+
+// mergeValue copies the non-zero parts of src into dst
+func mergeValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				mergeValue(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		}
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(iter.Key(), iter.Value())
+		}
+	case reflect.Slice:
+		if src.Len() == 0 && (src.IsNil() || !dst.IsNil()) {
+			return
+		}
+		dst.Set(reflect.AppendSlice(reflect.MakeSlice(src.Type(), 0, src.Len()), src))
+	default:
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
+
+// LoadForRepo reads RepoConfigPath from repoDir and merges it onto base.
+// A repository without a config file gets a copy of base.
+func LoadForRepo(base *types.Config, repoDir string) (*types.Config, error) {
+	return NewLoader().LoadForRepo(base, repoDir)
+}
+
+// LoadForRepo reads RepoConfigPath from repoDir and merges it onto base.
+// A repository without a config file gets a copy of base.
+func (l *Loader) LoadForRepo(base *types.Config, repoDir string) (*types.Config, error) {
+	path := filepath.Join(repoDir, RepoConfigPath)
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Merge(base, nil), nil
+		}
+		return nil, fmt.Errorf("failed to open repo config: %w", err)
+	}
+	defer f.Close()
+
+	// Decode without defaults so only fields the repo sets override base
+	overlay := &types.Config{}
+	renamed, err := l.decode(f, overlay)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	major, err := majorVersion(overlay.Version)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if major > 2 {
+		return nil, fmt.Errorf("%s: %w: %s (latest is %s)", path, ErrUnsupportedVersion, overlay.Version, CurrentVersion)
+	}
+	// The base config decides the schema version
+	overlay.Version = ""
+
+	for _, change := range renamed {
+		log.Printf("⚠️  Repo config migrated: %s", change)
+	}
+
+	return Merge(base, overlay), nil
+}
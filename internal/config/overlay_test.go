@@ -0,0 +1,161 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+func TestMerge(t *testing.T) {
+	base := types.NewDefaultConfig()
+	overlay := &types.Config{
+		LLM: types.LLMConfig{Model: "gpt-4o", MaxTokens: 8000},
+		Prompts: map[string]string{
+			"documentation": "prompts/docs.md",
+		},
+		Documentation: types.DocumentationConfig{
+			Languages: []string{"go"},
+			Deploy:    types.DeployConfig{Branch: "pages"},
+		},
+	}
+
+	merged := Merge(base, overlay)
+
+	// Overlay fields win
+	if merged.LLM.Model != "gpt-4o" || merged.LLM.MaxTokens != 8000 {
+		t.Errorf("LLM = %+v, want overlay model and tokens", merged.LLM)
+	}
+	if !reflect.DeepEqual(merged.Documentation.Languages, []string{"go"}) {
+		t.Errorf("Languages = %v, want [go]", merged.Documentation.Languages)
+	}
+	if merged.Documentation.Deploy.Branch != "pages" {
+		t.Errorf("Deploy.Branch = %q, want pages", merged.Documentation.Deploy.Branch)
+	}
+
+	// Unset overlay fields inherit the base
+	if merged.LLM.Provider != base.LLM.Provider || merged.LLM.Temperature != base.LLM.Temperature {
+		t.Errorf("LLM = %+v, want base provider and temperature", merged.LLM)
+	}
+	if merged.Documentation.Deploy.Target != "github-pages" {
+		t.Errorf("Deploy.Target = %q, want base github-pages", merged.Documentation.Deploy.Target)
+	}
+	if !merged.Outputs.DeploySite || merged.Version != base.Version {
+		t.Error("expected outputs and version to be inherited from base")
+	}
+
+	// Maps merge key by key
+	if merged.Prompts["code_review"] != base.Prompts["code_review"] {
+		t.Error("base prompt should be kept")
+	}
+	if merged.Prompts["documentation"] != "prompts/docs.md" {
+		t.Error("overlay prompt should be added")
+	}
+}
+
+func TestMerge_DoesNotModifyInputs(t *testing.T) {
+	base := types.NewDefaultConfig()
+	overlay := &types.Config{Rules: map[string]string{"security": "custom.yml"}}
+
+	merged := Merge(base, overlay)
+	merged.Rules["extra"] = "extra.yml"
+	merged.Documentation.Languages = append(merged.Documentation.Languages, "go")
+
+	if base.Rules["security"] != "rules/security-rules.yml" {
+		t.Error("Merge should not modify base")
+	}
+	if _, ok := base.Rules["extra"]; ok {
+		t.Error("merged maps should not alias base maps")
+	}
+	if len(overlay.Rules) != 1 {
+		t.Error("merged maps should not alias overlay maps")
+	}
+}
+
+func TestMerge_Nil(t *testing.T) {
+	base := types.NewDefaultConfig()
+	if got := Merge(base, nil); !reflect.DeepEqual(got, base) {
+		t.Error("Merge(base, nil) should equal base")
+	}
+	if got := Merge(nil, nil); !reflect.DeepEqual(got, &types.Config{}) {
+		t.Errorf("Merge(nil, nil) = %+v, want zero config", got)
+	}
+}
+
+func TestLoadForRepo(t *testing.T) {
+	repoDir := t.TempDir()
+	writeRepoConfig(t, repoDir, `
+version: "1.0"
+llm:
+  model: repo-model
+output:
+  tests: true
+documentation:
+  output_directory: site
+`)
+
+	base := types.NewDefaultConfig()
+	base.Outputs.GenerateTests = false
+
+	cfg, err := LoadForRepo(base, repoDir)
+	if err != nil {
+		t.Fatalf("LoadForRepo failed: %v", err)
+	}
+
+	if cfg.LLM.Model != "repo-model" {
+		t.Errorf("Model = %q, want repo-model", cfg.LLM.Model)
+	}
+	if cfg.Documentation.OutputDirectory != "site" {
+		t.Errorf("OutputDirectory = %q, want site", cfg.Documentation.OutputDirectory)
+	}
+	if !cfg.Outputs.GenerateTests {
+		t.Error("legacy output.tests should be migrated and applied")
+	}
+
+	// Fields the repo does not set come from the base, not from defaults
+	if cfg.LLM.Provider != base.LLM.Provider || cfg.Documentation.Theme != base.Documentation.Theme {
+		t.Error("unset repo fields should inherit the base config")
+	}
+	if cfg.Version != base.Version {
+		t.Errorf("Version = %q, want base version %q", cfg.Version, base.Version)
+	}
+}
+
+func TestLoadForRepo_NoConfig(t *testing.T) {
+	base := types.NewDefaultConfig()
+
+	cfg, err := LoadForRepo(base, t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadForRepo failed: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, base) {
+		t.Error("expected a copy of base when the repo has no config")
+	}
+	if cfg == base {
+		t.Error("expected a copy, not base itself")
+	}
+}
+
+func TestLoadForRepo_UnsupportedVersion(t *testing.T) {
+	repoDir := t.TempDir()
+	writeRepoConfig(t, repoDir, "version: \"3.0\"\n")
+
+	if _, err := LoadForRepo(types.NewDefaultConfig(), repoDir); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+func writeRepoConfig(t *testing.T, repoDir, content string) {
+	t.Helper()
+
+	path := filepath.Join(repoDir, RepoConfigPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// ErrInvalidConfig indicates settings that contradict each other or are out of range
+var ErrInvalidConfig = errors.New("invalid config")
+
+// Validate checks that Outputs and Features agree with the Documentation
+// section and that review settings are valid. Problems that cannot be
+// honored are returned as an error wrapping ErrInvalidConfig; settings that
+// only have no effect are returned as warnings.
+func Validate(cfg *types.Config) ([]string, error) {
+	var warnings []string
+	var errs []error
+
+	docs := cfg.Documentation
+
+	if cfg.Outputs.UpdateDocs && !docs.Enabled {
+		errs = append(errs, fmt.Errorf("%w: outputs.update_docs requires documentation.enabled", ErrInvalidConfig))
+	}
+
+	if cfg.Outputs.DeploySite && !docs.Deploy.Enabled {
+		errs = append(errs, fmt.Errorf("%w: outputs.deploy_site requires documentation.deploy.enabled", ErrInvalidConfig))
+	}
+
+	if cfg.Outputs.DeploySite && !cfg.Outputs.UpdateDocs {
+		warnings = append(warnings, "outputs.deploy_site is set without outputs.update_docs; the existing docs will be deployed unchanged")
+	}
+
+	if cfg.Features.Documentation && !docs.Enabled {
+		warnings = append(warnings, "features.documentation has no effect while documentation.enabled is false")
+	}
+
+	if docs.Deploy.Enabled && !docs.Enabled {
+		warnings = append(warnings, "documentation.deploy.enabled has no effect while documentation.enabled is false")
+	}
+
+	if min := cfg.Review.MinConfidence; min < 0 || min > 1 {
+		errs = append(errs, fmt.Errorf("%w: review.min_confidence must be between 0 and 1, got %g", ErrInvalidConfig, min))
+	}
+
+	if max := cfg.Review.MaxComments; max < 0 {
+		errs = append(errs, fmt.Errorf("%w: review.max_comments must not be negative, got %d", ErrInvalidConfig, max))
+	}
+
+	for _, pattern := range cfg.Review.IgnorePaths {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("%w: review.ignore_paths has invalid pattern %q", ErrInvalidConfig, pattern))
+		}
+	}
+
+	return warnings, errors.Join(errs...)
+}
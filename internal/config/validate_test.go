@@ -0,0 +1,138 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+func TestValidate_Default(t *testing.T) {
+	warnings, err := Validate(types.NewDefaultConfig())
+	if err != nil {
+		t.Errorf("default config should be valid: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("default config should have no warnings, got %v", warnings)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *types.Config)
+		wantErr string
+		warning string
+	}{
+		{
+			name: "update docs with documentation disabled",
+			modify: func(cfg *types.Config) {
+				cfg.Outputs.DeploySite = false
+				cfg.Features.Documentation = false
+				cfg.Documentation.Deploy.Enabled = false
+				cfg.Documentation.Enabled = false
+			},
+			wantErr: "outputs.update_docs requires documentation.enabled",
+		},
+		{
+			name: "deploy site with deploy disabled",
+			modify: func(cfg *types.Config) {
+				cfg.Documentation.Deploy.Enabled = false
+			},
+			wantErr: "outputs.deploy_site requires documentation.deploy.enabled",
+		},
+		{
+			name: "deploy site without update docs",
+			modify: func(cfg *types.Config) {
+				cfg.Outputs.UpdateDocs = false
+			},
+			warning: "outputs.deploy_site is set without outputs.update_docs",
+		},
+		{
+			name: "documentation feature with documentation disabled",
+			modify: func(cfg *types.Config) {
+				cfg.Outputs.UpdateDocs = false
+				cfg.Outputs.DeploySite = false
+				cfg.Documentation.Deploy.Enabled = false
+				cfg.Documentation.Enabled = false
+			},
+			warning: "features.documentation has no effect",
+		},
+		{
+			name: "deploy enabled with documentation disabled",
+			modify: func(cfg *types.Config) {
+				cfg.Outputs.UpdateDocs = false
+				cfg.Outputs.DeploySite = false
+				cfg.Features.Documentation = false
+				cfg.Documentation.Enabled = false
+			},
+			warning: "documentation.deploy.enabled has no effect",
+		},
+		{
+			name: "min confidence above one",
+			modify: func(cfg *types.Config) {
+				cfg.Review.MinConfidence = 1.5
+			},
+			wantErr: "review.min_confidence must be between 0 and 1",
+		},
+		{
+			name: "negative max comments",
+			modify: func(cfg *types.Config) {
+				cfg.Review.MaxComments = -1
+			},
+			wantErr: "review.max_comments must not be negative",
+		},
+		{
+			name: "malformed review ignore pattern",
+			modify: func(cfg *types.Config) {
+				cfg.Review.IgnorePaths = []string{"*.lock", "gen/[a-"}
+			},
+			wantErr: `review.ignore_paths has invalid pattern "gen/[a-"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := types.NewDefaultConfig()
+			tt.modify(cfg)
+
+			warnings, err := Validate(cfg)
+
+			if tt.wantErr != "" {
+				if !errors.Is(err, ErrInvalidConfig) {
+					t.Fatalf("expected ErrInvalidConfig, got %v", err)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want it to mention %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if tt.warning != "" {
+				if len(warnings) != 1 || !strings.Contains(warnings[0], tt.warning) {
+					t.Errorf("warnings = %v, want one mentioning %q", warnings, tt.warning)
+				}
+			} else if len(warnings) != 0 {
+				t.Errorf("unexpected warnings: %v", warnings)
+			}
+		})
+	}
+}
+
+func TestValidate_ReportsAllErrors(t *testing.T) {
+	cfg := types.NewDefaultConfig()
+	cfg.Documentation.Enabled = false
+	cfg.Documentation.Deploy.Enabled = false
+
+	_, err := Validate(cfg)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{"outputs.update_docs", "outputs.deploy_site"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should mention %s, got %v", want, err)
+		}
+	}
+}
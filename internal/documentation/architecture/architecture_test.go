@@ -0,0 +1,154 @@
+package architecture
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// createModule writes a small module: cmd -> {a, d}, a -> b, b -> c, c -> a (cycle)
+func createModule(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":        "module example.com/app\n\ngo 1.21\n",
+		"cmd/main.go":   "package main\n\nimport (\n\t\"fmt\"\n\t\"example.com/app/a\"\n\t\"example.com/app/d\"\n)\n",
+		"a/a.go":        "package a\n\nimport \"example.com/app/b\"\n",
+		"b/b.go":        "package b\n\nimport \"example.com/app/c\"\n",
+		"c/c.go":        "package c\n\nimport (\n\t\"example.com/app/a\"\n\t\"github.com/other/lib\"\n)\n",
+		"d/d.go":        "package d\n\nimport \"strings\"\n",
+		"d/d_test.go":   "package d\n\nimport \"example.com/app/a\"\n",
+		"vendor/x/x.go": "package x\n\nimport \"example.com/app/a\"\n",
+	}
+
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func TestAnalyzeGoImports(t *testing.T) {
+	graph, err := AnalyzeGoImports(createModule(t))
+	if err != nil {
+		t.Fatalf("AnalyzeGoImports failed: %v", err)
+	}
+
+	if graph.Module != "example.com/app" {
+		t.Errorf("Expected module example.com/app, got %s", graph.Module)
+	}
+
+	wantPackages := []string{"a", "b", "c", "cmd", "d"}
+	if !reflect.DeepEqual(graph.Packages, wantPackages) {
+		t.Errorf("Packages = %v, want %v", graph.Packages, wantPackages)
+	}
+
+	wantEdges := map[string][]string{
+		"a":   {"b"},
+		"b":   {"c"},
+		"c":   {"a"},
+		"cmd": {"a", "d"},
+	}
+	for pkg, want := range wantEdges {
+		if got := graph.Edges[pkg]; !reflect.DeepEqual(got, want) {
+			t.Errorf("Edges[%s] = %v, want %v", pkg, got, want)
+		}
+	}
+
+	// Test-only imports and third-party imports are excluded
+	if len(graph.Edges["d"]) != 0 {
+		t.Errorf("d should have no first-party imports, got %v", graph.Edges["d"])
+	}
+}
+
+func TestGraph_Cycles(t *testing.T) {
+	graph, err := AnalyzeGoImports(createModule(t))
+	if err != nil {
+		t.Fatalf("AnalyzeGoImports failed: %v", err)
+	}
+
+	cycles := graph.Cycles()
+	want := [][]string{{"a", "b", "c"}}
+	if !reflect.DeepEqual(cycles, want) {
+		t.Errorf("Cycles() = %v, want %v", cycles, want)
+	}
+}
+
+func TestRenderMermaid(t *testing.T) {
+	graph, err := AnalyzeGoImports(createModule(t))
+	if err != nil {
+		t.Fatalf("AnalyzeGoImports failed: %v", err)
+	}
+
+	mermaid := RenderMermaid(graph)
+
+	// Node ids follow sorted package order: a=p0, b=p1, c=p2, cmd=p3, d=p4
+	expected := []string{
+		"graph LR",
+		`p0["a"]`,
+		`p3["cmd"]`,
+		"p0 --> p1",
+		"p1 --> p2",
+		"p2 --> p0",
+		"p3 --> p0",
+		"p3 --> p4",
+		"class p0 cycle",
+		"class p1 cycle",
+		"class p2 cycle",
+		"linkStyle 0,1,2 stroke:#c00",
+	}
+	for _, want := range expected {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("Mermaid graph missing %q\n%s", want, mermaid)
+		}
+	}
+
+	if strings.Contains(mermaid, "class p3 cycle") {
+		t.Error("cmd is not part of a cycle")
+	}
+
+	if got := strings.Count(mermaid, "-->"); got != 5 {
+		t.Errorf("Expected 5 edges, got %d", got)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	root := createModule(t)
+	docsDir := filepath.Join(root, "docs")
+
+	path, err := Generate(root, docsDir)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if path != filepath.Join(docsDir, "_architecture", "index.md") {
+		t.Errorf("Unexpected output path %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Output not written: %v", err)
+	}
+
+	content := string(data)
+	for _, want := range []string{"title: Architecture", "```mermaid", "## Import Cycles", "- `a`, `b`, `c`"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Generated page missing %q", want)
+		}
+	}
+}
+
+func TestGenerate_NoGoMod(t *testing.T) {
+	if _, err := Generate(t.TempDir(), t.TempDir()); err == nil {
+		t.Error("Expected error without go.mod")
+	}
+}
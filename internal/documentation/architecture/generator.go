@@ -0,0 +1,128 @@
+package architecture
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/normalizer"
+)
+
+// Generate analyzes the Go module at rootDir and writes the package
+// dependency graph to <docsDir>/_architecture/index.md, returning the path
+func Generate(rootDir, docsDir string) (string, error) {
+	graph, err := AnalyzeGoImports(rootDir)
+	if err != nil {
+		return "", err
+	}
+
+	fm := normalizer.GenerateFrontMatter(normalizer.FrontMatterOptions{
+		FilePath:    "index.md",
+		Section:     "_architecture",
+		IsIndex:     true,
+		CustomTitle: "Architecture",
+	})
+	header, err := fm.ToYAML()
+	if err != nil {
+		return "", err
+	}
+
+	outputPath := filepath.Join(docsDir, "_architecture", "index.md")
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create architecture directory: %w", err)
+	}
+
+	if err := os.WriteFile(outputPath, []byte(header+RenderMarkdown(graph)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	return outputPath, nil
+}
+
+// RenderMarkdown renders the graph as a markdown page with a Mermaid diagram
+// and a list of import cycles
+func RenderMarkdown(g *Graph) string {
+	var b strings.Builder
+
+	b.WriteString("# Architecture\n\n")
+	fmt.Fprintf(&b, "Package dependencies of `%s`. An arrow points from a package to a package it imports.\n\n", g.Module)
+
+	b.WriteString("```mermaid\n")
+	b.WriteString(RenderMermaid(g))
+	b.WriteString("```\n\n")
+
+	cycles := g.Cycles()
+	b.WriteString("## Import Cycles\n\n")
+	if len(cycles) == 0 {
+		b.WriteString("No import cycles detected.\n")
+		return b.String()
+	}
+
+	b.WriteString("The following packages depend on each other and are highlighted in red:\n\n")
+	for _, cycle := range cycles {
+		names := make([]string, len(cycle))
+		for i, pkg := range cycle {
+			names[i] = "`" + pkg + "`"
+		}
+		fmt.Fprintf(&b, "- %s\n", strings.Join(names, ", "))
+	}
+
+	return b.String()
+}
+
+// RenderMermaid renders the graph as a Mermaid flowchart. Packages and edges
+// that are part of an import cycle are styled with the "cycle" class.
+func RenderMermaid(g *Graph) string {
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+
+	ids := make(map[string]string, len(g.Packages))
+	for i, pkg := range g.Packages {
+		ids[pkg] = fmt.Sprintf("p%d", i)
+	}
+
+	cycleOf := make(map[string]int)
+	for i, cycle := range g.Cycles() {
+		for _, pkg := range cycle {
+			cycleOf[pkg] = i + 1
+		}
+	}
+
+	for _, pkg := range g.Packages {
+		fmt.Fprintf(&b, "    %s[\"%s\"]\n", ids[pkg], pkg)
+	}
+
+	var cycleEdges []int
+	edge := 0
+	for _, pkg := range g.Packages {
+		for _, target := range g.Edges[pkg] {
+			fmt.Fprintf(&b, "    %s --> %s\n", ids[pkg], ids[target])
+			if cycleOf[pkg] != 0 && cycleOf[pkg] == cycleOf[target] {
+				cycleEdges = append(cycleEdges, edge)
+			}
+			edge++
+		}
+	}
+
+	if len(cycleOf) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("    classDef cycle fill:#fdd,stroke:#c00,stroke-width:2px\n")
+	for _, pkg := range g.Packages {
+		if cycleOf[pkg] != 0 {
+			fmt.Fprintf(&b, "    class %s cycle\n", ids[pkg])
+		}
+	}
+
+	if len(cycleEdges) > 0 {
+		indexes := make([]string, len(cycleEdges))
+		for i, e := range cycleEdges {
+			indexes[i] = fmt.Sprint(e)
+		}
+		fmt.Fprintf(&b, "    linkStyle %s stroke:#c00,stroke-width:2px\n", strings.Join(indexes, ","))
+	}
+
+	return b.String()
+}
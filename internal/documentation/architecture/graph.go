@@ -0,0 +1,200 @@
+package architecture
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Graph is the first-party package import graph of a Go module
+type Graph struct {
+	// Module is the module path from go.mod
+	Module string
+
+	// Packages lists package paths relative to the module root ("." for the root package)
+	Packages []string
+
+	// Edges maps a package to the first-party packages it imports
+	Edges map[string][]string
+}
+
+// AnalyzeGoImports builds the import graph for the Go module rooted at rootDir.
+// Only imports under the module path are kept; test files are ignored.
+func AnalyzeGoImports(rootDir string) (*Graph, error) {
+	module, err := readModulePath(filepath.Join(rootDir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &Graph{
+		Module: module,
+		Edges:  make(map[string][]string),
+	}
+
+	imports := make(map[string]map[string]bool)
+	fset := token.NewFileSet()
+
+	err = filepath.WalkDir(rootDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if path != rootDir && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") ||
+				name == "vendor" || name == "testdata" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		rel, err := filepath.Rel(rootDir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		pkg := filepath.ToSlash(rel)
+
+		if imports[pkg] == nil {
+			imports[pkg] = make(map[string]bool)
+		}
+
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if target, ok := graph.relative(importPath); ok && target != pkg {
+				imports[pkg][target] = true
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Packages that are imported but were not walked (e.g. skipped directories) still get nodes
+	for _, targets := range imports {
+		for target := range targets {
+			if imports[target] == nil {
+				imports[target] = make(map[string]bool)
+			}
+		}
+	}
+
+	for pkg, targets := range imports {
+		graph.Packages = append(graph.Packages, pkg)
+		for target := range targets {
+			graph.Edges[pkg] = append(graph.Edges[pkg], target)
+		}
+		sort.Strings(graph.Edges[pkg])
+	}
+	sort.Strings(graph.Packages)
+
+	return graph, nil
+}
+
+// relative converts a first-party import path to a module-relative package path
+func (g *Graph) relative(importPath string) (string, bool) {
+	if importPath == g.Module {
+		return ".", true
+	}
+	if rest, ok := strings.CutPrefix(importPath, g.Module+"/"); ok {
+		return rest, true
+	}
+	return "", false
+}
+
+// Cycles returns each group of packages that import each other, directly or
+// transitively. Groups and their members are sorted.
+func (g *Graph) Cycles() [][]string {
+	// Tarjan's strongly connected components
+	index := 0
+	indices := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var connect func(pkg string)
+	connect = func(pkg string) {
+		indices[pkg] = index
+		lowlink[pkg] = index
+		index++
+		stack = append(stack, pkg)
+		onStack[pkg] = true
+
+		for _, next := range g.Edges[pkg] {
+			if _, visited := indices[next]; !visited {
+				connect(next)
+				lowlink[pkg] = min(lowlink[pkg], lowlink[next])
+			} else if onStack[next] {
+				lowlink[pkg] = min(lowlink[pkg], indices[next])
+			}
+		}
+
+		if lowlink[pkg] != indices[pkg] {
+			return
+		}
+
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == pkg {
+				break
+			}
+		}
+
+		if len(component) > 1 {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, pkg := range g.Packages {
+		if _, visited := indices[pkg]; !visited {
+			connect(pkg)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// readModulePath extracts the module path from a go.mod file
+func readModulePath(goModPath string) (string, error) {
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(line, "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`), nil
+		}
+	}
+
+	return "", fmt.Errorf("no module directive in %s", goModPath)
+}
@@ -0,0 +1,307 @@
+package asyncapi
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OutputFile is the markdown file written under <dir>/docs
+const OutputFile = "ASYNCAPI.md"
+
+// ErrNoSpecs is returned when no AsyncAPI document is found
+var ErrNoSpecs = errors.New("no AsyncAPI specs found")
+
+// GenerateAsyncAPI discovers AsyncAPI 2.x specs under dir and renders their
+// channels, operations, and message payloads to docs/ASYNCAPI.md
+func GenerateAsyncAPI(dir string) error {
+	paths, err := Discover(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(paths) == 0 {
+		return fmt.Errorf("%w in %s", ErrNoSpecs, dir)
+	}
+
+	var sections []string
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		spec, err := Parse(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		sections = append(sections, Render(spec))
+	}
+
+	outputPath := filepath.Join(dir, "docs", OutputFile)
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	content := strings.Join(sections, "\n---\n\n")
+	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	return nil
+}
+
+// Discover returns YAML/JSON files under dir that declare an AsyncAPI 2.x version
+func Discover(dir string) ([]string, error) {
+	var paths []string
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if path != dir && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil || !strings.Contains(string(data), "asyncapi") {
+			return nil
+		}
+
+		if _, err := Parse(data); err == nil {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for AsyncAPI specs: %w", err)
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Render converts a parsed spec to markdown
+func Render(spec *Spec) string {
+	var b strings.Builder
+
+	title := spec.Info.Title
+	if title == "" {
+		title = "AsyncAPI"
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+
+	if spec.Info.Version != "" {
+		fmt.Fprintf(&b, "**Version:** %s  \n", spec.Info.Version)
+	}
+	fmt.Fprintf(&b, "**AsyncAPI:** %s\n\n", spec.AsyncAPI)
+
+	if spec.Info.Description != "" {
+		b.WriteString(strings.TrimSpace(spec.Info.Description) + "\n\n")
+	}
+
+	if len(spec.Servers) > 0 {
+		b.WriteString("## Servers\n\n")
+		b.WriteString("| Name | URL | Protocol | Description |\n")
+		b.WriteString("|------|-----|----------|-------------|\n")
+		for _, name := range sortedKeys(spec.Servers) {
+			server := spec.Servers[name]
+			fmt.Fprintf(&b, "| %s | `%s` | %s | %s |\n",
+				name, server.URL, server.Protocol, tableCell(server.Description))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Channels\n\n")
+	if len(spec.Channels) == 0 {
+		b.WriteString("No channels defined.\n\n")
+		return b.String()
+	}
+
+	for _, name := range sortedKeys(spec.Channels) {
+		renderChannel(&b, spec, name, spec.Channels[name])
+	}
+
+	return b.String()
+}
+
+// renderChannel writes a channel with its parameters and operations
+func renderChannel(b *strings.Builder, spec *Spec, name string, channel *Channel) {
+	fmt.Fprintf(b, "### `%s`\n\n", name)
+	if channel == nil {
+		return
+	}
+
+	if channel.Description != "" {
+		b.WriteString(strings.TrimSpace(channel.Description) + "\n\n")
+	}
+
+	if len(channel.Parameters) > 0 {
+		b.WriteString("**Parameters:**\n\n")
+		b.WriteString("| Name | Type | Description |\n")
+		b.WriteString("|------|------|-------------|\n")
+		for _, param := range sortedKeys(channel.Parameters) {
+			p := channel.Parameters[param]
+			fmt.Fprintf(b, "| `%s` | %s | %s |\n", param, schemaType(spec.resolveSchema(p.Schema)), tableCell(p.Description))
+		}
+		b.WriteString("\n")
+	}
+
+	renderOperation(b, spec, "PUBLISH", channel.Publish)
+	renderOperation(b, spec, "SUBSCRIBE", channel.Subscribe)
+}
+
+// renderOperation writes a publish or subscribe operation and its messages
+func renderOperation(b *strings.Builder, spec *Spec, kind string, op *Operation) {
+	if op == nil {
+		return
+	}
+
+	heading := kind
+	if op.OperationID != "" {
+		heading += " `" + op.OperationID + "`"
+	}
+	fmt.Fprintf(b, "#### %s\n\n", heading)
+
+	if op.Summary != "" {
+		b.WriteString(op.Summary + "\n\n")
+	}
+	if op.Description != "" {
+		b.WriteString(strings.TrimSpace(op.Description) + "\n\n")
+	}
+
+	message := spec.resolveMessage(op.Message)
+	if message == nil {
+		return
+	}
+
+	messages := []*Message{message}
+	if len(message.OneOf) > 0 {
+		messages = message.OneOf
+	}
+
+	for _, m := range messages {
+		renderMessage(b, spec, spec.resolveMessage(m))
+	}
+}
+
+// renderMessage writes a message header and its payload fields
+func renderMessage(b *strings.Builder, spec *Spec, m *Message) {
+	if m == nil {
+		return
+	}
+
+	name := m.Name
+	if name == "" {
+		name = m.Title
+	}
+	if name == "" {
+		name = "message"
+	}
+
+	fmt.Fprintf(b, "**Message:** `%s`", name)
+	if m.ContentType != "" {
+		fmt.Fprintf(b, " (%s)", m.ContentType)
+	}
+	b.WriteString("\n\n")
+
+	if m.Summary != "" {
+		b.WriteString(m.Summary + "\n\n")
+	}
+
+	payload := spec.resolveSchema(m.Payload)
+	if payload == nil {
+		return
+	}
+
+	if len(payload.Properties) == 0 {
+		fmt.Fprintf(b, "**Payload:** %s\n\n", schemaType(payload))
+		return
+	}
+
+	required := make(map[string]bool)
+	for _, field := range payload.Required {
+		required[field] = true
+	}
+
+	b.WriteString("| Field | Type | Required | Description |\n")
+	b.WriteString("|-------|------|----------|-------------|\n")
+	for _, field := range sortedKeys(payload.Properties) {
+		prop := spec.resolveSchema(payload.Properties[field])
+		if prop == nil {
+			prop = &Schema{}
+		}
+		req := ""
+		if required[field] {
+			req = "yes"
+		}
+		fmt.Fprintf(b, "| `%s` | %s | %s | %s |\n", field, schemaType(prop), req, tableCell(prop.Description))
+	}
+	b.WriteString("\n")
+}
+
+// schemaType renders a short type description such as "string (date-time)" or "array of integer"
+func schemaType(schema *Schema) string {
+	if schema == nil {
+		return ""
+	}
+
+	if schema.Ref != "" {
+		return schema.Ref[strings.LastIndex(schema.Ref, "/")+1:]
+	}
+
+	t := schema.Type
+	if t == "" {
+		t = "object"
+	}
+
+	if t == "array" && schema.Items != nil {
+		t = "array of " + schemaType(schema.Items)
+	}
+
+	if schema.Format != "" {
+		t += " (" + schema.Format + ")"
+	}
+
+	if len(schema.Enum) > 0 {
+		values := make([]string, len(schema.Enum))
+		for i, v := range schema.Enum {
+			values[i] = fmt.Sprint(v)
+		}
+		t += ": " + strings.Join(values, ", ")
+	}
+
+	return t
+}
+
+// tableCell flattens text so it fits in a markdown table cell
+func tableCell(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+// sortedKeys returns map keys in a stable order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
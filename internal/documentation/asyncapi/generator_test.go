@@ -0,0 +1,172 @@
+package asyncapi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleSpec = `asyncapi: '2.6.0'
+info:
+  title: Orders Service
+  version: 1.2.0
+  description: Publishes order lifecycle events.
+servers:
+  production:
+    url: broker.example.com:9092
+    protocol: kafka
+channels:
+  orders/{orderId}/created:
+    description: Emitted when an order is placed.
+    parameters:
+      orderId:
+        description: Order identifier
+        schema:
+          type: string
+    publish:
+      operationId: publishOrderCreated
+      summary: Announce a new order
+      message:
+        $ref: '#/components/messages/OrderCreated'
+  payments/status:
+    subscribe:
+      operationId: onPaymentStatus
+      message:
+        oneOf:
+          - $ref: '#/components/messages/PaymentSucceeded'
+          - $ref: '#/components/messages/PaymentFailed'
+components:
+  messages:
+    OrderCreated:
+      contentType: application/json
+      payload:
+        $ref: '#/components/schemas/Order'
+    PaymentSucceeded:
+      payload:
+        type: object
+        properties:
+          amount:
+            type: number
+    PaymentFailed:
+      payload:
+        type: object
+        properties:
+          reason:
+            type: string
+            enum: [declined, expired]
+  schemas:
+    Order:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          description: Order id
+        items:
+          type: array
+          items:
+            type: string
+        createdAt:
+          type: string
+          format: date-time
+`
+
+func TestParse(t *testing.T) {
+	spec, err := Parse([]byte(sampleSpec))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if spec.Info.Title != "Orders Service" {
+		t.Errorf("Expected title 'Orders Service', got %q", spec.Info.Title)
+	}
+
+	if len(spec.Channels) != 2 {
+		t.Errorf("Expected 2 channels, got %d", len(spec.Channels))
+	}
+}
+
+func TestParse_RejectsNonAsyncAPI(t *testing.T) {
+	tests := map[string]string{
+		"openapi":   "openapi: 3.0.0\ninfo:\n  title: REST\n",
+		"version 3": "asyncapi: 3.0.0\ninfo:\n  title: Next\n",
+		"invalid":   "asyncapi: [",
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(data)); err == nil {
+				t.Error("Expected parse error")
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	spec, err := Parse([]byte(sampleSpec))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	doc := Render(spec)
+
+	expected := []string{
+		"# Orders Service",
+		"**Version:** 1.2.0",
+		"| production | `broker.example.com:9092` | kafka |",
+		"### `orders/{orderId}/created`",
+		"| `orderId` | string | Order identifier |",
+		"#### PUBLISH `publishOrderCreated`",
+		"**Message:** `OrderCreated` (application/json)",
+		"| `id` | string | yes | Order id |",
+		"| `items` | array of string |",
+		"| `createdAt` | string (date-time) |",
+		"### `payments/status`",
+		"#### SUBSCRIBE `onPaymentStatus`",
+		"**Message:** `PaymentSucceeded`",
+		"**Message:** `PaymentFailed`",
+		"| `reason` | string: declined, expired |",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Rendered doc missing %q\n%s", want, doc)
+		}
+	}
+
+	// Channels are rendered in a stable order
+	if strings.Index(doc, "orders/{orderId}/created") > strings.Index(doc, "payments/status") {
+		t.Error("Channels should be sorted by name")
+	}
+}
+
+func TestGenerateAsyncAPI(t *testing.T) {
+	tmpDir := t.TempDir()
+	specDir := filepath.Join(tmpDir, "api")
+	os.MkdirAll(specDir, 0755)
+	os.WriteFile(filepath.Join(specDir, "asyncapi.yaml"), []byte(sampleSpec), 0644)
+	os.WriteFile(filepath.Join(specDir, "openapi.yaml"), []byte("openapi: 3.0.0\n"), 0644)
+
+	if err := GenerateAsyncAPI(tmpDir); err != nil {
+		t.Fatalf("GenerateAsyncAPI failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "docs", OutputFile))
+	if err != nil {
+		t.Fatalf("Output not written: %v", err)
+	}
+
+	doc := string(data)
+	if !strings.Contains(doc, "orders/{orderId}/created") || !strings.Contains(doc, "OrderCreated") {
+		t.Error("Generated doc should contain channels and messages")
+	}
+}
+
+func TestGenerateAsyncAPI_NoSpecs(t *testing.T) {
+	err := GenerateAsyncAPI(t.TempDir())
+	if !errors.Is(err, ErrNoSpecs) {
+		t.Errorf("Expected ErrNoSpecs, got %v", err)
+	}
+}
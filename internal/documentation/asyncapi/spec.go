@@ -0,0 +1,138 @@
+package asyncapi
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the subset of an AsyncAPI 2.x document rendered to markdown
+type Spec struct {
+	AsyncAPI   string              `yaml:"asyncapi"`
+	Info       Info                `yaml:"info"`
+	Servers    map[string]Server   `yaml:"servers"`
+	Channels   map[string]*Channel `yaml:"channels"`
+	Components Components          `yaml:"components"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `yaml:"title"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description"`
+}
+
+// Server is a message broker the API connects to
+type Server struct {
+	URL         string `yaml:"url"`
+	Protocol    string `yaml:"protocol"`
+	Description string `yaml:"description"`
+}
+
+// Channel is an addressable topic or queue
+type Channel struct {
+	Description string               `yaml:"description"`
+	Parameters  map[string]Parameter `yaml:"parameters"`
+	Subscribe   *Operation           `yaml:"subscribe"`
+	Publish     *Operation           `yaml:"publish"`
+}
+
+// Parameter is a channel name parameter
+type Parameter struct {
+	Description string  `yaml:"description"`
+	Schema      *Schema `yaml:"schema"`
+}
+
+// Operation is a publish or subscribe action on a channel
+type Operation struct {
+	OperationID string   `yaml:"operationId"`
+	Summary     string   `yaml:"summary"`
+	Description string   `yaml:"description"`
+	Message     *Message `yaml:"message"`
+}
+
+// Message is the payload exchanged on a channel
+type Message struct {
+	Ref         string     `yaml:"$ref"`
+	Name        string     `yaml:"name"`
+	Title       string     `yaml:"title"`
+	Summary     string     `yaml:"summary"`
+	Description string     `yaml:"description"`
+	ContentType string     `yaml:"contentType"`
+	Payload     *Schema    `yaml:"payload"`
+	OneOf       []*Message `yaml:"oneOf"`
+}
+
+// Schema is a JSON Schema fragment describing a payload
+type Schema struct {
+	Ref         string             `yaml:"$ref"`
+	Type        string             `yaml:"type"`
+	Format      string             `yaml:"format"`
+	Description string             `yaml:"description"`
+	Properties  map[string]*Schema `yaml:"properties"`
+	Required    []string           `yaml:"required"`
+	Items       *Schema            `yaml:"items"`
+	Enum        []interface{}      `yaml:"enum"`
+}
+
+// Components holds reusable messages and schemas
+type Components struct {
+	Messages map[string]*Message `yaml:"messages"`
+	Schemas  map[string]*Schema  `yaml:"schemas"`
+}
+
+// Parse decodes an AsyncAPI 2.x document from YAML or JSON
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse AsyncAPI spec: %w", err)
+	}
+
+	if spec.AsyncAPI == "" {
+		return nil, fmt.Errorf("not an AsyncAPI document: missing 'asyncapi' version field")
+	}
+
+	if !strings.HasPrefix(spec.AsyncAPI, "2.") {
+		return nil, fmt.Errorf("unsupported AsyncAPI version %s: only 2.x is supported", spec.AsyncAPI)
+	}
+
+	return &spec, nil
+}
+
+// resolveMessage follows a local "#/components/messages/..." reference
+func (s *Spec) resolveMessage(m *Message) *Message {
+	for depth := 0; m != nil && m.Ref != "" && depth < 10; depth++ {
+		name, ok := strings.CutPrefix(m.Ref, "#/components/messages/")
+		if !ok {
+			return m
+		}
+		resolved, ok := s.Components.Messages[name]
+		if !ok {
+			return m
+		}
+		if resolved.Name == "" {
+			copied := *resolved
+			copied.Name = name
+			resolved = &copied
+		}
+		m = resolved
+	}
+	return m
+}
+
+// resolveSchema follows a local "#/components/schemas/..." reference
+func (s *Spec) resolveSchema(schema *Schema) *Schema {
+	for depth := 0; schema != nil && schema.Ref != "" && depth < 10; depth++ {
+		name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/")
+		if !ok {
+			return schema
+		}
+		resolved, ok := s.Components.Schemas[name]
+		if !ok {
+			return schema
+		}
+		schema = resolved
+	}
+	return schema
+}
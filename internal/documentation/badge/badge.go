@@ -0,0 +1,121 @@
+package badge
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const shieldsBaseURL = "https://img.shields.io/badge/"
+
+// Badge colors understood by shields.io
+const (
+	ColorBrightGreen = "brightgreen"
+	ColorGreen       = "green"
+	ColorYellowGreen = "yellowgreen"
+	ColorYellow      = "yellow"
+	ColorOrange      = "orange"
+	ColorRed         = "red"
+	ColorBlue        = "blue"
+	ColorLightGrey   = "lightgrey"
+)
+
+// Build statuses accepted by BuildBadge
+const (
+	StatusPassing = "passing"
+	StatusFailing = "failing"
+)
+
+// URL builds a shields.io static badge URL for label, message, and color
+func URL(label, message, color string) string {
+	return shieldsBaseURL + escape(label) + "-" + escape(message) + "-" + escape(color)
+}
+
+// Markdown renders a badge image with alt text
+func Markdown(alt, badgeURL string) string {
+	return fmt.Sprintf("![%s](%s)", alt, badgeURL)
+}
+
+// CoverageBadge returns markdown for a doc coverage badge; pct is 0-100
+func CoverageBadge(pct float64) string {
+	return Markdown("doc coverage", CoverageURL(pct))
+}
+
+// CoverageURL returns the badge URL for a doc coverage percentage
+func CoverageURL(pct float64) string {
+	if pct < 0 {
+		pct = 0
+	}
+	if pct > 100 {
+		pct = 100
+	}
+	return URL("doc coverage", fmt.Sprintf("%.0f%%", pct), CoverageColor(pct))
+}
+
+// CoverageColor maps a coverage percentage to a badge color
+func CoverageColor(pct float64) string {
+	switch {
+	case pct >= 90:
+		return ColorBrightGreen
+	case pct >= 75:
+		return ColorGreen
+	case pct >= 60:
+		return ColorYellowGreen
+	case pct >= 40:
+		return ColorYellow
+	case pct >= 20:
+		return ColorOrange
+	default:
+		return ColorRed
+	}
+}
+
+// BuildBadge returns markdown for a build status badge
+func BuildBadge(status string) string {
+	return Markdown("build", BuildURL(status))
+}
+
+// BuildURL returns the badge URL for a build status; unknown statuses are grey
+func BuildURL(status string) string {
+	status = strings.ToLower(strings.TrimSpace(status))
+
+	color := ColorLightGrey
+	switch status {
+	case StatusPassing:
+		color = ColorBrightGreen
+	case StatusFailing:
+		color = ColorRed
+	case "":
+		status = "unknown"
+	}
+
+	return URL("build", status, color)
+}
+
+// VersionBadge returns markdown for a version badge
+func VersionBadge(version string) string {
+	return Markdown("version", VersionURL(version))
+}
+
+// VersionURL returns the badge URL for a version, adding a "v" prefix to numeric versions
+func VersionURL(version string) string {
+	version = strings.TrimSpace(version)
+	if version != "" && version[0] >= '0' && version[0] <= '9' {
+		version = "v" + version
+	}
+	return URL("version", version, ColorBlue)
+}
+
+// Section joins badges into a single README line
+func Section(badges ...string) string {
+	return strings.Join(badges, " ")
+}
+
+// escape encodes a badge path segment: shields.io treats "-" and "_" as
+// separators, so they are doubled, and spaces become "_"
+func escape(s string) string {
+	s = strings.ReplaceAll(s, "-", "--")
+	s = strings.ReplaceAll(s, "_", "__")
+	s = strings.ReplaceAll(s, " ", "_")
+	return url.PathEscape(s)
+}
@@ -0,0 +1,82 @@
+package badge
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCoverageURL(t *testing.T) {
+	tests := []struct {
+		pct  float64
+		want string
+	}{
+		{95, "https://img.shields.io/badge/doc_coverage-95%25-brightgreen"},
+		{90, "https://img.shields.io/badge/doc_coverage-90%25-brightgreen"},
+		{80, "https://img.shields.io/badge/doc_coverage-80%25-green"},
+		{62.5, "https://img.shields.io/badge/doc_coverage-62%25-yellowgreen"},
+		{45, "https://img.shields.io/badge/doc_coverage-45%25-yellow"},
+		{20, "https://img.shields.io/badge/doc_coverage-20%25-orange"},
+		{5, "https://img.shields.io/badge/doc_coverage-5%25-red"},
+		{-3, "https://img.shields.io/badge/doc_coverage-0%25-red"},
+		{120, "https://img.shields.io/badge/doc_coverage-100%25-brightgreen"},
+	}
+
+	for _, tt := range tests {
+		if got := CoverageURL(tt.pct); got != tt.want {
+			t.Errorf("CoverageURL(%v) = %s, want %s", tt.pct, got, tt.want)
+		}
+	}
+}
+
+func TestCoverageBadge(t *testing.T) {
+	got := CoverageBadge(75)
+	want := "![doc coverage](https://img.shields.io/badge/doc_coverage-75%25-green)"
+	if got != want {
+		t.Errorf("CoverageBadge(75) = %s, want %s", got, want)
+	}
+}
+
+func TestBuildURL(t *testing.T) {
+	tests := []struct {
+		status string
+		want   string
+	}{
+		{"passing", "https://img.shields.io/badge/build-passing-brightgreen"},
+		{"FAILING", "https://img.shields.io/badge/build-failing-red"},
+		{"pending", "https://img.shields.io/badge/build-pending-lightgrey"},
+		{"", "https://img.shields.io/badge/build-unknown-lightgrey"},
+	}
+
+	for _, tt := range tests {
+		if got := BuildURL(tt.status); got != tt.want {
+			t.Errorf("BuildURL(%q) = %s, want %s", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestVersionURL(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"1.2.3", "https://img.shields.io/badge/version-v1.2.3-blue"},
+		{"v2.0.0", "https://img.shields.io/badge/version-v2.0.0-blue"},
+		{"2.0.0-beta_1", "https://img.shields.io/badge/version-v2.0.0--beta__1-blue"},
+	}
+
+	for _, tt := range tests {
+		if got := VersionURL(tt.version); got != tt.want {
+			t.Errorf("VersionURL(%q) = %s, want %s", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestSection(t *testing.T) {
+	section := Section(BuildBadge("passing"), CoverageBadge(50), VersionBadge("1.0.0"))
+
+	for _, want := range []string{"![build]", "![doc coverage]", "![version]"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("Section missing %s: %s", want, section)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package dependencies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultGoProxyURL is the module proxy queried by GoProxyChecker
+const DefaultGoProxyURL = "https://proxy.golang.org"
+
+// UpdateChecker looks up the latest published version of a dependency.
+// A nil checker keeps generation fully offline.
+type UpdateChecker interface {
+	Latest(ctx context.Context, dep Dependency) (string, error)
+}
+
+// GoProxyChecker queries a Go module proxy for the latest version of Go dependencies
+type GoProxyChecker struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewGoProxyChecker creates a checker against the given proxy; an empty
+// baseURL uses DefaultGoProxyURL
+func NewGoProxyChecker(baseURL string) *GoProxyChecker {
+	if baseURL == "" {
+		baseURL = DefaultGoProxyURL
+	}
+
+	return &GoProxyChecker{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Latest returns the proxy's @latest version for a Go module
+func (c *GoProxyChecker) Latest(ctx context.Context, dep Dependency) (string, error) {
+	if dep.Ecosystem != EcosystemGo {
+		return "", fmt.Errorf("unsupported ecosystem %q", dep.Ecosystem)
+	}
+
+	endpoint := c.baseURL + "/" + escapeModulePath(dep.Name) + "/@latest"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query %s: %w", dep.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("module proxy returned %d for %s", resp.StatusCode, dep.Name)
+	}
+
+	var info struct {
+		Version string `json:"Version"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return "", fmt.Errorf("failed to decode proxy response: %w", err)
+	}
+
+	return info.Version, nil
+}
+
+// escapeModulePath applies the module proxy case encoding: each upper-case
+// letter becomes '!' followed by its lower-case form
+func escapeModulePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('!')
+			b.WriteRune(r + ('a' - 'A'))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
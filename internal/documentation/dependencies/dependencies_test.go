@@ -0,0 +1,186 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleGoMod = `module example.com/app
+
+go 1.21
+
+require github.com/gorilla/mux v1.8.0
+
+require (
+	gopkg.in/yaml.v3 v3.0.1
+	github.com/BurntSushi/toml v1.2.0 // pinned for compat
+	golang.org/x/sys v0.10.0 // indirect
+)
+
+replace example.com/old => ../old
+`
+
+func TestParseGoMod(t *testing.T) {
+	deps, err := ParseGoMod([]byte(sampleGoMod))
+	if err != nil {
+		t.Fatalf("ParseGoMod failed: %v", err)
+	}
+
+	want := []Dependency{
+		{Name: "github.com/gorilla/mux", Version: "v1.8.0", Ecosystem: EcosystemGo},
+		{Name: "gopkg.in/yaml.v3", Version: "v3.0.1", Ecosystem: EcosystemGo},
+		{Name: "github.com/BurntSushi/toml", Version: "v1.2.0", Ecosystem: EcosystemGo},
+	}
+	if !reflect.DeepEqual(deps, want) {
+		t.Errorf("deps = %+v, want %+v", deps, want)
+	}
+}
+
+func TestParseGoModMalformed(t *testing.T) {
+	if _, err := ParseGoMod([]byte("require (\n\tgithub.com/broken\n)\n")); err == nil {
+		t.Error("expected error for requirement without version")
+	}
+}
+
+func TestParsePackageJSON(t *testing.T) {
+	data := `{"dependencies": {"react": "^18.2.0", "axios": "1.4.0"}, "devDependencies": {"jest": "~29.0.0"}}`
+
+	deps, err := ParsePackageJSON([]byte(data))
+	if err != nil {
+		t.Fatalf("ParsePackageJSON failed: %v", err)
+	}
+
+	want := []Dependency{
+		{Name: "axios", Version: "1.4.0", Ecosystem: EcosystemNPM},
+		{Name: "react", Version: "^18.2.0", Ecosystem: EcosystemNPM},
+		{Name: "jest", Version: "~29.0.0", Ecosystem: EcosystemNPM, Dev: true},
+	}
+	if !reflect.DeepEqual(deps, want) {
+		t.Errorf("deps = %+v, want %+v", deps, want)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.8.0", "v1.8.1", -1},
+		{"v1.10.0", "v1.9.0", 1},
+		{"v3.0.1", "v3.0.1", 0},
+		{"v2.0.0-beta.1", "v2.0.0", -1},
+		{"^18.2.0", "18.2.0", 0},
+		{"v0.0.0-20230101000000-abcdef", "v0.1.0", -1},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateOffline(t *testing.T) {
+	root := t.TempDir()
+	docs := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(sampleGoMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := Generate(context.Background(), root, docs, nil)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if path != filepath.Join(docs, "_reference", "dependencies.md") {
+		t.Errorf("unexpected output path %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+
+	for _, want := range []string{
+		"title: Dependencies",
+		"## Go Modules",
+		"| Name | Version |\n|------|---------|\n",
+		"| `github.com/gorilla/mux` | v1.8.0 |",
+		"| `gopkg.in/yaml.v3` | v3.0.1 |",
+		"| `github.com/BurntSushi/toml` | v1.2.0 |",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("output missing %q:\n%s", want, content)
+		}
+	}
+
+	if strings.Contains(content, "golang.org/x/sys") {
+		t.Error("indirect dependency should not be listed")
+	}
+	if strings.Contains(content, "Latest") {
+		t.Error("offline output should not include update columns")
+	}
+}
+
+func TestGenerateNoManifests(t *testing.T) {
+	_, err := Generate(context.Background(), t.TempDir(), t.TempDir(), nil)
+	if !errors.Is(err, ErrNoManifests) {
+		t.Errorf("expected ErrNoManifests, got %v", err)
+	}
+}
+
+func TestGenerateWithProxyChecker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/github.com/gorilla/mux/@latest":
+			w.Write([]byte(`{"Version":"v1.8.1"}`))
+		case "/gopkg.in/yaml.v3/@latest":
+			w.Write([]byte(`{"Version":"v3.0.1"}`))
+		default:
+			// Includes the case-escaped /github.com/!burnt!sushi/toml path
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	docs := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(sampleGoMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := Generate(context.Background(), root, docs, NewGoProxyChecker(server.URL))
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+
+	for _, want := range []string{
+		"| `github.com/gorilla/mux` | v1.8.0 | v1.8.1 | ⚠️ update available |",
+		"| `gopkg.in/yaml.v3` | v3.0.1 | v3.0.1 | up to date |",
+		"| `github.com/BurntSushi/toml` | v1.2.0 |  | unknown |",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("output missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestEscapeModulePath(t *testing.T) {
+	if got := escapeModulePath("github.com/BurntSushi/toml"); got != "github.com/!burnt!sushi/toml" {
+		t.Errorf("escapeModulePath = %q", got)
+	}
+}
@@ -0,0 +1,181 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/normalizer"
+)
+
+// Status of a dependency relative to the latest published version
+const (
+	StatusCurrent  = "up to date"
+	StatusOutdated = "update available"
+	StatusUnknown  = "unknown"
+)
+
+// ErrNoManifests is returned when neither go.mod nor package.json exists
+var ErrNoManifests = errors.New("no go.mod or package.json found")
+
+// Entry is a dependency with the result of its update check
+type Entry struct {
+	Dependency
+	Latest string
+	Status string
+}
+
+// Collect reads go.mod and package.json from rootDir. Missing manifests are skipped.
+func Collect(rootDir string) ([]Dependency, error) {
+	var deps []Dependency
+	found := false
+
+	manifests := []struct {
+		name  string
+		parse func([]byte) ([]Dependency, error)
+	}{
+		{"go.mod", ParseGoMod},
+		{"package.json", ParsePackageJSON},
+	}
+
+	for _, m := range manifests {
+		data, err := os.ReadFile(filepath.Join(rootDir, m.name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", m.name, err)
+		}
+		found = true
+
+		parsed, err := m.parse(data)
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, parsed...)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w in %s", ErrNoManifests, rootDir)
+	}
+
+	return deps, nil
+}
+
+// Check resolves the latest version of each dependency. Lookup failures are
+// recorded as StatusUnknown rather than returned; a nil checker skips the lookup.
+func Check(ctx context.Context, deps []Dependency, checker UpdateChecker) []Entry {
+	entries := make([]Entry, len(deps))
+	for i, dep := range deps {
+		entries[i] = Entry{Dependency: dep}
+		if checker == nil || dep.Ecosystem != EcosystemGo {
+			continue
+		}
+
+		latest, err := checker.Latest(ctx, dep)
+		if err != nil || latest == "" {
+			entries[i].Status = StatusUnknown
+			continue
+		}
+
+		entries[i].Latest = latest
+		if compareVersions(dep.Version, latest) < 0 {
+			entries[i].Status = StatusOutdated
+		} else {
+			entries[i].Status = StatusCurrent
+		}
+	}
+	return entries
+}
+
+// Generate writes the direct dependencies of the project at rootDir to
+// <docsDir>/_reference/dependencies.md, returning the path. Pass a nil
+// checker to stay offline.
+func Generate(ctx context.Context, rootDir, docsDir string, checker UpdateChecker) (string, error) {
+	deps, err := Collect(rootDir)
+	if err != nil {
+		return "", err
+	}
+
+	fm := normalizer.GenerateFrontMatter(normalizer.FrontMatterOptions{
+		FilePath:    "dependencies.md",
+		Section:     "_reference",
+		CustomTitle: "Dependencies",
+	})
+	header, err := fm.ToYAML()
+	if err != nil {
+		return "", err
+	}
+
+	outputPath := filepath.Join(docsDir, "_reference", "dependencies.md")
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create reference directory: %w", err)
+	}
+
+	content := header + Render(Check(ctx, deps, checker), checker != nil)
+	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	return outputPath, nil
+}
+
+// Render produces the dependencies page. The Latest and Status columns are
+// only included when checked is true.
+func Render(entries []Entry, checked bool) string {
+	var b strings.Builder
+	b.WriteString("# Dependencies\n\n")
+
+	sections := []struct {
+		title     string
+		ecosystem Ecosystem
+		dev       bool
+	}{
+		{"Go Modules", EcosystemGo, false},
+		{"npm Packages", EcosystemNPM, false},
+		{"npm Dev Packages", EcosystemNPM, true},
+	}
+
+	for _, s := range sections {
+		var rows []Entry
+		for _, e := range entries {
+			if e.Ecosystem == s.ecosystem && e.Dev == s.dev {
+				rows = append(rows, e)
+			}
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "## %s\n\n", s.title)
+		if checked {
+			b.WriteString("| Name | Version | Latest | Status |\n")
+			b.WriteString("|------|---------|--------|--------|\n")
+		} else {
+			b.WriteString("| Name | Version |\n")
+			b.WriteString("|------|---------|\n")
+		}
+
+		for _, e := range rows {
+			if !checked {
+				fmt.Fprintf(&b, "| `%s` | %s |\n", e.Name, e.Version)
+				continue
+			}
+			status := e.Status
+			if status == StatusOutdated {
+				status = "⚠️ " + status
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", e.Name, e.Version, e.Latest, status)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(entries) == 0 {
+		b.WriteString("No direct dependencies declared.\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,161 @@
+package dependencies
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Ecosystem identifies where a dependency is declared
+type Ecosystem string
+
+const (
+	EcosystemGo  Ecosystem = "go"
+	EcosystemNPM Ecosystem = "npm"
+)
+
+// Dependency is a direct dependency declared by the project
+type Dependency struct {
+	Name      string
+	Version   string
+	Ecosystem Ecosystem
+	Dev       bool // npm devDependency
+}
+
+// ParseGoMod returns the direct requirements of a go.mod file.
+// Requirements marked "// indirect" are skipped.
+func ParseGoMod(data []byte) ([]Dependency, error) {
+	var deps []Dependency
+	inBlock := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "//"):
+			continue
+		case line == "require (":
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require "))
+		case !inBlock:
+			continue
+		}
+
+		if strings.Contains(line, "// indirect") {
+			continue
+		}
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("go.mod line %d: malformed requirement %q", lineNum, line)
+		}
+
+		deps = append(deps, Dependency{
+			Name:      strings.Trim(fields[0], `"`),
+			Version:   fields[1],
+			Ecosystem: EcosystemGo,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	return deps, nil
+}
+
+// ParsePackageJSON returns dependencies and devDependencies from package.json
+func ParsePackageJSON(data []byte) ([]Dependency, error) {
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	var deps []Dependency
+	add := func(m map[string]string, dev bool) {
+		names := make([]string, 0, len(m))
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			deps = append(deps, Dependency{
+				Name:      name,
+				Version:   m[name],
+				Ecosystem: EcosystemNPM,
+				Dev:       dev,
+			})
+		}
+	}
+
+	add(pkg.Dependencies, false)
+	add(pkg.DevDependencies, true)
+
+	return deps, nil
+}
+
+// compareVersions compares two semantic versions ("v1.2.3", "1.2.3-beta"),
+// returning -1, 0, or 1. Range prefixes such as "^" and "~" are ignored.
+func compareVersions(a, b string) int {
+	pa, preA := splitVersion(a)
+	pb, preB := splitVersion(b)
+
+	for i := 0; i < 3; i++ {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A release sorts after its prereleases
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	case preA < preB:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// splitVersion parses major.minor.patch and the prerelease suffix
+func splitVersion(v string) ([3]int, string) {
+	v = strings.TrimLeft(strings.TrimSpace(v), "^~>=v")
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, _ := strings.Cut(v, "-")
+
+	var parts [3]int
+	for i, p := range strings.SplitN(v, ".", 3) {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			break
+		}
+		parts[i] = n
+	}
+
+	return parts, pre
+}
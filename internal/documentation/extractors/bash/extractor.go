@@ -29,6 +29,11 @@ func (b *BashExtractor) Extract(ctx context.Context, req *extractors.ExtractRequ
 		return nil, fmt.Errorf("invalid language: expected %s, got %s", extractors.LanguageBash, req.Language)
 	}
 
+	// Only markdown output is supported
+	if err := req.CheckFormat(extractors.OutputFormatMarkdown); err != nil {
+		return nil, err
+	}
+
 	if _, err := os.Stat(req.SourceDir); err != nil {
 		return nil, fmt.Errorf("invalid source directory: %w", err)
 	}
@@ -38,7 +43,7 @@ func (b *BashExtractor) Extract(ctx context.Context, req *extractors.ExtractRequ
 	}
 
 	// Find Bash scripts
-	scripts, err := b.findBashScripts(req.SourceDir)
+	scripts, err := b.findBashScripts(req.SourceDir, req.Excluded())
 	if err != nil {
 		return nil, fmt.Errorf("failed to find Bash scripts: %w", err)
 	}
@@ -86,10 +91,16 @@ func (b *BashExtractor) Language() extractors.Language {
 	return extractors.LanguageBash
 }
 
-func (b *BashExtractor) findBashScripts(rootDir string) ([]string, error) {
+func (b *BashExtractor) findBashScripts(rootDir string, excluded extractors.ExcludedDirs) ([]string, error) {
 	scripts := []string{}
 	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if path != rootDir && excluded.Contains(info.Name()) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 		ext := filepath.Ext(path)
@@ -72,7 +72,7 @@ func TestBashExtractor_findBashScripts(t *testing.T) {
 	runner := site.NewMockRunner()
 	extractor := NewBashExtractor(runner)
 
-	found, err := extractor.findBashScripts(tmpDir)
+	found, err := extractor.findBashScripts(tmpDir, extractors.NewExcludedDirs())
 	if err != nil {
 		t.Fatalf("findBashScripts failed: %v", err)
 	}
@@ -0,0 +1,12 @@
+package bash
+
+import (
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+func init() {
+	extractors.RegisterFactory(extractors.LanguageBash, func(runner site.CommandRunner) extractors.Extractor {
+		return NewBashExtractor(runner)
+	})
+}
@@ -0,0 +1,95 @@
+package extractors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+// Factory creates an extractor that runs its tools through runner
+type Factory func(runner site.CommandRunner) Extractor
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[Language]Factory)
+)
+
+// RegisterFactory makes a built-in extractor available to RegisterAll.
+// Extractor packages call it from init; registering a language twice panics.
+func RegisterFactory(lang Language, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if factory == nil {
+		panic(fmt.Sprintf("extractors: nil factory for %s", lang))
+	}
+	if _, exists := factories[lang]; exists {
+		panic(fmt.Sprintf("extractors: factory for %s registered twice", lang))
+	}
+
+	factories[lang] = factory
+}
+
+// BuiltinLanguages returns the languages with a registered factory, sorted
+func BuiltinLanguages() []Language {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	langs := make([]Language, 0, len(factories))
+	for lang := range factories {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i] < langs[j] })
+
+	return langs
+}
+
+// RegisterAll registers an extractor for every built-in language whose
+// package has been linked in. Languages already present in registry are
+// left untouched.
+func RegisterAll(registry *Registry, runner site.CommandRunner) error {
+	for _, lang := range BuiltinLanguages() {
+		if registry.Has(lang) {
+			continue
+		}
+
+		factoriesMu.RLock()
+		factory := factories[lang]
+		factoriesMu.RUnlock()
+
+		if err := registry.Register(factory(runner)); err != nil {
+			return fmt.Errorf("register %s extractor: %w", lang, err)
+		}
+	}
+
+	return nil
+}
+
+// Alias serves base under a different language, e.g. a JavaScript extractor
+// that also handles TypeScript
+func Alias(base Extractor, lang Language) Extractor {
+	return &aliasExtractor{base: base, lang: lang}
+}
+
+// aliasExtractor forwards requests to base with the language rewritten
+type aliasExtractor struct {
+	base Extractor
+	lang Language
+}
+
+func (a *aliasExtractor) Extract(ctx context.Context, req *ExtractRequest) (*ExtractResult, error) {
+	reqCopy := *req
+	reqCopy.Language = a.lang
+	return a.base.Extract(ctx, &reqCopy)
+}
+
+func (a *aliasExtractor) Validate(ctx context.Context) error {
+	return a.base.Validate(ctx)
+}
+
+func (a *aliasExtractor) Language() Language {
+	return a.lang
+}
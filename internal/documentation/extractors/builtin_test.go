@@ -0,0 +1,102 @@
+package extractors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/bash"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/cpp"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/csharp"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/go"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/javascript"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/powershell"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/python"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/rust"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+func TestRegisterAll(t *testing.T) {
+	registry := extractors.NewRegistry()
+	if err := extractors.RegisterAll(registry, site.NewMockRunner()); err != nil {
+		t.Fatalf("RegisterAll failed: %v", err)
+	}
+
+	builtin := []extractors.Language{
+		extractors.LanguageGo,
+		extractors.LanguageJavaScript,
+		extractors.LanguageTypeScript,
+		extractors.LanguagePython,
+		extractors.LanguageCSharp,
+		extractors.LanguageCPP,
+		extractors.LanguageRust,
+		extractors.LanguageBash,
+		extractors.LanguagePowerShell,
+	}
+
+	if registry.Count() != len(builtin) {
+		t.Errorf("Count() = %d, want %d", registry.Count(), len(builtin))
+	}
+
+	for _, lang := range builtin {
+		ext, err := registry.Get(lang)
+		if err != nil {
+			t.Errorf("Get(%s) failed: %v", lang, err)
+			continue
+		}
+		if ext.Language() != lang {
+			t.Errorf("extractor for %s reports language %s", lang, ext.Language())
+		}
+	}
+}
+
+func TestRegisterAllKeepsExistingExtractors(t *testing.T) {
+	registry := extractors.NewRegistry()
+	custom := extractors.Alias(nil, extractors.LanguageGo)
+	if err := registry.Register(custom); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := extractors.RegisterAll(registry, site.NewMockRunner()); err != nil {
+		t.Fatalf("RegisterAll failed: %v", err)
+	}
+
+	if got, _ := registry.Get(extractors.LanguageGo); got != custom {
+		t.Error("RegisterAll should not replace an extractor registered by the caller")
+	}
+}
+
+// recordingExtractor captures the request language it receives
+type recordingExtractor struct {
+	got extractors.Language
+}
+
+func (r *recordingExtractor) Extract(ctx context.Context, req *extractors.ExtractRequest) (*extractors.ExtractResult, error) {
+	r.got = req.Language
+	return &extractors.ExtractResult{Language: req.Language}, nil
+}
+
+func (r *recordingExtractor) Validate(ctx context.Context) error { return nil }
+
+func (r *recordingExtractor) Language() extractors.Language { return extractors.LanguageJavaScript }
+
+func TestAliasRewritesLanguage(t *testing.T) {
+	base := &recordingExtractor{}
+	alias := extractors.Alias(base, extractors.LanguageTypeScript)
+
+	if alias.Language() != extractors.LanguageTypeScript {
+		t.Errorf("Language() = %s, want typescript", alias.Language())
+	}
+
+	req := &extractors.ExtractRequest{Language: extractors.LanguageJavaScript}
+	if _, err := alias.Extract(context.Background(), req); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	if base.got != extractors.LanguageTypeScript {
+		t.Errorf("base received %s, want typescript", base.got)
+	}
+	if req.Language != extractors.LanguageJavaScript {
+		t.Error("caller's request should not be modified")
+	}
+}
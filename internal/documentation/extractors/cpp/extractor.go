@@ -29,6 +29,11 @@ func (c *CPPExtractor) Extract(ctx context.Context, req *extractors.ExtractReque
 		return nil, fmt.Errorf("invalid language: expected %s, got %s", extractors.LanguageCPP, req.Language)
 	}
 
+	// Only markdown output is supported
+	if err := req.CheckFormat(extractors.OutputFormatMarkdown); err != nil {
+		return nil, err
+	}
+
 	if _, err := os.Stat(req.SourceDir); err != nil {
 		return nil, fmt.Errorf("invalid source directory: %w", err)
 	}
@@ -38,7 +43,7 @@ func (c *CPPExtractor) Extract(ctx context.Context, req *extractors.ExtractReque
 	}
 
 	// Find C/C++ files
-	files, err := c.findCPPFiles(req.SourceDir)
+	files, err := c.findCPPFiles(req.SourceDir, req.Excluded())
 	if err != nil {
 		return nil, fmt.Errorf("failed to find C/C++ files: %w", err)
 	}
@@ -51,13 +56,20 @@ func (c *CPPExtractor) Extract(ctx context.Context, req *extractors.ExtractReque
 		}, nil
 	}
 
-	// Create Doxyfile configuration
-	doxyfilePath := filepath.Join(os.TempDir(), "Doxyfile")
-	if err := c.createDoxyfile(doxyfilePath, req.SourceDir, req.OutputDir); err != nil {
+	// Create Doxyfile configuration; the name is unique so concurrent runs
+	// sharing a work directory cannot clobber each other
+	doxyfile, err := os.CreateTemp(req.WorkDir(), "Doxyfile-*")
+	if err != nil {
 		return nil, fmt.Errorf("failed to create Doxyfile: %w", err)
 	}
+	doxyfilePath := doxyfile.Name()
+	doxyfile.Close()
 	defer os.Remove(doxyfilePath)
 
+	if err := c.createDoxyfile(doxyfilePath, req.SourceDir, req.OutputDir); err != nil {
+		return nil, fmt.Errorf("failed to create Doxyfile: %w", err)
+	}
+
 	// Run Doxygen
 	_, err = c.runner.Run(ctx, "doxygen", []string{doxyfilePath}, ".", nil)
 	if err != nil {
@@ -98,10 +110,16 @@ func (c *CPPExtractor) Language() extractors.Language {
 	return extractors.LanguageCPP
 }
 
-func (c *CPPExtractor) findCPPFiles(rootDir string) ([]string, error) {
+func (c *CPPExtractor) findCPPFiles(rootDir string, excluded extractors.ExcludedDirs) ([]string, error) {
 	files := []string{}
 	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if path != rootDir && excluded.Contains(info.Name()) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 		ext := strings.ToLower(filepath.Ext(path))
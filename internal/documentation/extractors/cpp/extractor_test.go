@@ -88,7 +88,7 @@ func TestCPPExtractor_findCPPFiles(t *testing.T) {
 	runner := site.NewMockRunner()
 	extractor := NewCPPExtractor(runner)
 
-	files, err := extractor.findCPPFiles(tmpDir)
+	files, err := extractor.findCPPFiles(tmpDir, extractors.NewExcludedDirs())
 	if err != nil {
 		t.Fatalf("findCPPFiles failed: %v", err)
 	}
@@ -0,0 +1,12 @@
+package cpp
+
+import (
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+func init() {
+	extractors.RegisterFactory(extractors.LanguageCPP, func(runner site.CommandRunner) extractors.Extractor {
+		return NewCPPExtractor(runner)
+	})
+}
@@ -30,6 +30,11 @@ func (c *CSharpExtractor) Extract(ctx context.Context, req *extractors.ExtractRe
 		return nil, fmt.Errorf("invalid language: expected %s, got %s", extractors.LanguageCSharp, req.Language)
 	}
 
+	// xmldocmd only produces markdown
+	if err := req.CheckFormat(extractors.OutputFormatMarkdown); err != nil {
+		return nil, err
+	}
+
 	// Validate source directory
 	if _, err := os.Stat(req.SourceDir); err != nil {
 		return nil, fmt.Errorf("invalid source directory: %w", err)
@@ -41,7 +46,7 @@ func (c *CSharpExtractor) Extract(ctx context.Context, req *extractors.ExtractRe
 	}
 
 	// Find all C# projects
-	projects, err := c.findCSharpProjects(req.SourceDir)
+	projects, err := c.findCSharpProjects(req.SourceDir, req.Excluded())
 	if err != nil {
 		return nil, fmt.Errorf("failed to find C# projects: %w", err)
 	}
@@ -137,7 +142,7 @@ func (c *CSharpExtractor) Language() extractors.Language {
 }
 
 // findCSharpProjects finds all .csproj files in the source directory
-func (c *CSharpExtractor) findCSharpProjects(rootDir string) ([]string, error) {
+func (c *CSharpExtractor) findCSharpProjects(rootDir string, excluded extractors.ExcludedDirs) ([]string, error) {
 	projects := []string{}
 
 	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
@@ -149,8 +154,7 @@ func (c *CSharpExtractor) findCSharpProjects(rootDir string) ([]string, error) {
 		if info.IsDir() {
 			// Skip common excluded directories
 			dirName := filepath.Base(path)
-			if dirName == "bin" || dirName == "obj" || dirName == "node_modules" ||
-				dirName == ".git" || dirName == ".taskmaster" || strings.HasPrefix(dirName, ".") {
+			if excluded.Contains(dirName) || strings.HasPrefix(dirName, ".") {
 				return filepath.SkipDir
 			}
 			return nil
@@ -196,7 +196,7 @@ func TestCSharpExtractor_findCSharpProjects(t *testing.T) {
 	runner := site.NewMockRunner()
 	extractor := NewCSharpExtractor(runner)
 
-	projects, err := extractor.findCSharpProjects(tmpDir)
+	projects, err := extractor.findCSharpProjects(tmpDir, extractors.NewExcludedDirs())
 	if err != nil {
 		t.Fatalf("findCSharpProjects failed: %v", err)
 	}
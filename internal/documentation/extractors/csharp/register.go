@@ -0,0 +1,12 @@
+package csharp
+
+import (
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+func init() {
+	extractors.RegisterFactory(extractors.LanguageCSharp, func(runner site.CommandRunner) extractors.Extractor {
+		return NewCSharpExtractor(runner)
+	})
+}
@@ -10,7 +10,7 @@ import (
 
 // Detector scans projects to detect programming languages
 type Detector struct {
-	excludedDirs map[string]bool
+	excludedDirs ExcludedDirs
 	extensions   map[string]Language
 }
 
@@ -44,24 +44,10 @@ type LanguageStats struct {
 // NewDetector creates a new language detector
 func NewDetector() *Detector {
 	d := &Detector{
-		excludedDirs: make(map[string]bool),
+		excludedDirs: NewExcludedDirs(),
 		extensions:   make(map[string]Language),
 	}
 
-	// Set default excluded directories
-	d.excludedDirs["vendor"] = true
-	d.excludedDirs["node_modules"] = true
-	d.excludedDirs[".git"] = true
-	d.excludedDirs["bin"] = true
-	d.excludedDirs["obj"] = true
-	d.excludedDirs["_site"] = true
-	d.excludedDirs[".taskmaster"] = true
-	d.excludedDirs["dist"] = true
-	d.excludedDirs["build"] = true
-	d.excludedDirs["target"] = true
-	d.excludedDirs[".next"] = true
-	d.excludedDirs["__pycache__"] = true
-
 	// Map file extensions to languages
 	d.extensions[".go"] = LanguageGo
 	d.extensions[".js"] = LanguageJavaScript
@@ -91,9 +77,7 @@ func NewDetector() *Detector {
 
 // WithExcludedDirs adds additional directories to exclude
 func (d *Detector) WithExcludedDirs(dirs ...string) *Detector {
-	for _, dir := range dirs {
-		d.excludedDirs[dir] = true
-	}
+	d.excludedDirs.Add(dirs...)
 	return d
 }
 
@@ -105,6 +89,23 @@ func (d *Detector) WithExtensions(extMap map[string]Language) *Detector {
 	return d
 }
 
+// ExtensionOverrides normalizes configured extensions to the lowercase,
+// dot-prefixed form returned by filepath.Ext
+func ExtensionOverrides(overrides map[string]string) map[string]Language {
+	extensions := make(map[string]Language, len(overrides))
+	for ext, lang := range overrides {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		extensions[ext] = Language(strings.ToLower(strings.TrimSpace(lang)))
+	}
+	return extensions
+}
+
 // Detect scans the root directory and detects all languages in use
 func (d *Detector) Detect(ctx context.Context, rootDir string) (*DetectionResult, error) {
 	// Validate root directory
@@ -137,16 +138,15 @@ func (d *Detector) Detect(ctx context.Context, rootDir string) (*DetectionResult
 		// Skip directories in exclusion list
 		if info.IsDir() {
 			dirName := filepath.Base(path)
-			if d.excludedDirs[dirName] {
+			if d.excludedDirs.Contains(dirName) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
 
 		// Check file extension
-		ext := strings.ToLower(filepath.Ext(path))
-		lang, ok := d.extensions[ext]
-		if !ok {
+		lang := d.LanguageOf(path)
+		if lang == "" {
 			// Unknown extension, skip
 			return nil
 		}
@@ -185,6 +185,11 @@ func (d *Detector) Detect(ctx context.Context, rootDir string) (*DetectionResult
 	return result, nil
 }
 
+// LanguageOf returns the language of path by its extension, or "" if unknown
+func (d *Detector) LanguageOf(path string) Language {
+	return d.extensions[strings.ToLower(filepath.Ext(path))]
+}
+
 // countLines counts the number of lines in a file
 func (d *Detector) countLines(path string) (int, error) {
 	data, err := os.ReadFile(path)
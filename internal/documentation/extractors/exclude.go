@@ -0,0 +1,81 @@
+package extractors
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// defaultExcludedDirs are directory names never scanned for source files
+var defaultExcludedDirs = []string{
+	".aurumcode",
+	".git",
+	".github",
+	".next",
+	".taskmaster",
+	"__pycache__",
+	"_site",
+	"bin",
+	"build",
+	"dist",
+	"node_modules",
+	"obj",
+	"target",
+	"vendor",
+}
+
+// DefaultExcludedDirs returns the directory names skipped by default by file
+// discovery, language detection and extraction
+func DefaultExcludedDirs() []string {
+	return append([]string(nil), defaultExcludedDirs...)
+}
+
+// ExcludedDirs is a set of directory names skipped while walking a source tree
+type ExcludedDirs map[string]bool
+
+// NewExcludedDirs returns the default excluded directories plus extra
+func NewExcludedDirs(extra ...string) ExcludedDirs {
+	excluded := make(ExcludedDirs, len(defaultExcludedDirs)+len(extra))
+	excluded.Add(defaultExcludedDirs...)
+	excluded.Add(extra...)
+	return excluded
+}
+
+// Add excludes additional directory names
+func (e ExcludedDirs) Add(dirs ...string) {
+	for _, dir := range dirs {
+		if dir != "" {
+			e[dir] = true
+		}
+	}
+}
+
+// Contains reports whether a directory with the given base name is excluded
+func (e ExcludedDirs) Contains(name string) bool {
+	return e[name]
+}
+
+// ContainsPath reports whether any directory component of path is excluded
+func (e ExcludedDirs) ContainsPath(path string) bool {
+	clean := filepath.Clean(path)
+	for {
+		if e[filepath.Base(clean)] {
+			return true
+		}
+
+		parent := filepath.Dir(clean)
+		if parent == clean || parent == "." || parent == string(filepath.Separator) {
+			return false
+		}
+		clean = parent
+	}
+}
+
+// List returns the excluded directory names in sorted order
+func (e ExcludedDirs) List() []string {
+	dirs := make([]string, 0, len(e))
+	for dir := range e {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
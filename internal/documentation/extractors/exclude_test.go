@@ -0,0 +1,63 @@
+package extractors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExcludedDirsContainsPath(t *testing.T) {
+	excluded := NewExcludedDirs("generated")
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"src/main.go", false},
+		{"node_modules/pkg/index.js", true},
+		{".git/config", true},
+		{"vendor/lib/code.go", true},
+		{"target/release/app", true},
+		{"dist/bundle.js", true},
+		{".taskmaster/tasks.json", true},
+		{"internal/app/main.go", false},
+		{"internal/generated/models.go", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := excluded.ContainsPath(tt.path); got != tt.want {
+				t.Errorf("ContainsPath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewExcludedDirsKeepsDefaults(t *testing.T) {
+	excluded := NewExcludedDirs("generated", "")
+
+	for _, dir := range DefaultExcludedDirs() {
+		if !excluded.Contains(dir) {
+			t.Errorf("default %q should be excluded", dir)
+		}
+	}
+	if !excluded.Contains("generated") {
+		t.Error("extra directory should be excluded")
+	}
+	if excluded.Contains("") {
+		t.Error("empty names should be ignored")
+	}
+
+	want := append(DefaultExcludedDirs(), "generated")
+	if got := excluded.List(); len(got) != len(want) {
+		t.Errorf("List() = %v, want %d entries", got, len(want))
+	}
+}
+
+func TestDefaultExcludedDirsReturnsCopy(t *testing.T) {
+	dirs := DefaultExcludedDirs()
+	dirs[0] = "changed"
+
+	if reflect.DeepEqual(dirs, DefaultExcludedDirs()) {
+		t.Error("DefaultExcludedDirs should return a copy")
+	}
+}
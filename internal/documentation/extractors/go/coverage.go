@@ -0,0 +1,104 @@
+package goextractor
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+)
+
+// computeDocCoverage counts exported declarations in a package directory and
+// how many of them carry a doc comment. Test files are ignored.
+func computeDocCoverage(pkgDir string) (extractors.DocCoverage, error) {
+	coverage := extractors.DocCoverage{}
+
+	entries, err := os.ReadDir(pkgDir)
+	if err != nil {
+		return coverage, err
+	}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(pkgDir, name), nil, parser.ParseComments)
+		if err != nil {
+			return coverage, err
+		}
+
+		coverage.Add(fileDocCoverage(file))
+	}
+
+	return coverage, nil
+}
+
+// fileDocCoverage counts exported top-level declarations in a parsed file
+func fileDocCoverage(file *ast.File) extractors.DocCoverage {
+	coverage := extractors.DocCoverage{}
+
+	count := func(documented bool) {
+		coverage.Total++
+		if documented {
+			coverage.Documented++
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if !d.Name.IsExported() || !exportedReceiver(d) {
+				continue
+			}
+			count(d.Doc != nil)
+
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if s.Name.IsExported() {
+						count(s.Doc != nil || d.Doc != nil)
+					}
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						if name.IsExported() {
+							count(s.Doc != nil || d.Doc != nil)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return coverage
+}
+
+// exportedReceiver reports whether a method's receiver type is exported;
+// plain functions always qualify
+func exportedReceiver(fn *ast.FuncDecl) bool {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return true
+	}
+
+	expr := fn.Recv.List[0].Type
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.Ident:
+			return t.IsExported()
+		default:
+			return false
+		}
+	}
+}
@@ -38,6 +38,11 @@ func (g *GoExtractor) Extract(ctx context.Context, req *extractors.ExtractReques
 		return nil, fmt.Errorf("invalid language: expected %s, got %s", extractors.LanguageGo, req.Language)
 	}
 
+	// gomarkdoc only produces markdown
+	if err := req.CheckFormat(extractors.OutputFormatMarkdown); err != nil {
+		return nil, fmt.Errorf("gomarkdoc only generates markdown: %w", err)
+	}
+
 	// Validate source directory
 	if _, err := os.Stat(req.SourceDir); err != nil {
 		return nil, fmt.Errorf("invalid source directory: %w", err)
@@ -49,7 +54,7 @@ func (g *GoExtractor) Extract(ctx context.Context, req *extractors.ExtractReques
 	}
 
 	// Find all Go packages
-	packages, err := g.findGoPackages(req.SourceDir)
+	packages, err := g.findGoPackages(req.SourceDir, req.Excluded())
 	if err != nil {
 		return nil, fmt.Errorf("failed to find Go packages: %w", err)
 	}
@@ -94,6 +99,13 @@ func (g *GoExtractor) Extract(ctx context.Context, req *extractors.ExtractReques
 		}
 		outputPath := filepath.Join(req.OutputDir, outputName+".md")
 
+		// Measure documented exports, even for packages skipped below
+		coverage, err := computeDocCoverage(pkg)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("package %s: doc coverage: %w", pkg, err))
+		}
+		result.Stats.DocCoverage.Add(coverage)
+
 		// Check if we should skip this package (incremental mode)
 		if g.incrementalMode && g.shouldSkipPackage(pkg, outputPath) {
 			result.Stats.FilesProcessed++
@@ -135,7 +147,7 @@ func (g *GoExtractor) Language() extractors.Language {
 }
 
 // findGoPackages finds all Go packages in the source directory
-func (g *GoExtractor) findGoPackages(rootDir string) ([]string, error) {
+func (g *GoExtractor) findGoPackages(rootDir string, excluded extractors.ExcludedDirs) ([]string, error) {
 	packages := []string{}
 	visited := make(map[string]bool)
 
@@ -151,8 +163,7 @@ func (g *GoExtractor) findGoPackages(rootDir string) ([]string, error) {
 
 		// Skip common excluded directories
 		dirName := filepath.Base(path)
-		if dirName == "vendor" || dirName == "node_modules" || dirName == ".git" ||
-			dirName == "testdata" || dirName == ".taskmaster" || strings.HasPrefix(dirName, ".") {
+		if excluded.Contains(dirName) || dirName == "testdata" || strings.HasPrefix(dirName, ".") {
 			return filepath.SkipDir
 		}
 
@@ -168,6 +168,34 @@ func TestGoExtractor_Extract_InvalidLanguage(t *testing.T) {
 	}
 }
 
+func TestGoExtractor_Extract_HTMLUnsupported(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main\n"), 0644)
+
+	runner := site.NewMockRunner()
+	extractor := NewGoExtractor(runner)
+
+	req := &extractors.ExtractRequest{
+		Language:     extractors.LanguageGo,
+		SourceDir:    tmpDir,
+		OutputDir:    filepath.Join(tmpDir, "docs"),
+		OutputFormat: extractors.OutputFormatHTML,
+	}
+
+	_, err := extractor.Extract(context.Background(), req)
+	if !errors.Is(err, extractors.ErrUnsupportedFormat) {
+		t.Fatalf("expected ErrUnsupportedFormat, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "gomarkdoc") || !strings.Contains(err.Error(), "html") {
+		t.Errorf("error should name the tool and format, got: %v", err)
+	}
+
+	if len(runner.GetCalls()) != 0 {
+		t.Error("gomarkdoc should not be invoked for an unsupported format")
+	}
+}
+
 func TestGoExtractor_Extract_InvalidSourceDir(t *testing.T) {
 	runner := site.NewMockRunner()
 	extractor := NewGoExtractor(runner)
@@ -282,7 +310,7 @@ func TestGoExtractor_findGoPackages(t *testing.T) {
 	runner := site.NewMockRunner()
 	extractor := NewGoExtractor(runner)
 
-	packages, err := extractor.findGoPackages(tmpDir)
+	packages, err := extractor.findGoPackages(tmpDir, extractors.NewExcludedDirs())
 	if err != nil {
 		t.Fatalf("findGoPackages failed: %v", err)
 	}
@@ -306,6 +334,24 @@ func TestGoExtractor_findGoPackages(t *testing.T) {
 	}
 }
 
+func TestGoExtractor_findGoPackages_ConfiguredExcludedDirs(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, dir := range []string{"pkg", "generated/models"} {
+		os.MkdirAll(filepath.Join(tmpDir, dir), 0755)
+		os.WriteFile(filepath.Join(tmpDir, dir, "code.go"), []byte("package x\n"), 0644)
+	}
+
+	extractor := NewGoExtractor(site.NewMockRunner())
+	packages, err := extractor.findGoPackages(tmpDir, extractors.NewExcludedDirs("generated"))
+	if err != nil {
+		t.Fatalf("findGoPackages failed: %v", err)
+	}
+
+	if len(packages) != 1 || packages[0] != filepath.Join(tmpDir, "pkg") {
+		t.Errorf("expected only pkg, got %v", packages)
+	}
+}
+
 func TestGoExtractor_hasGoFiles(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -463,3 +509,80 @@ func TestGoExtractor_Language(t *testing.T) {
 		t.Errorf("expected language %s, got %s", extractors.LanguageGo, extractor.Language())
 	}
 }
+
+func TestComputeDocCoverage(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	source := `package sample
+
+// Documented is documented
+func Documented() {}
+
+func Undocumented() {}
+
+func unexported() {}
+
+// Server is documented
+type Server struct{}
+
+// Start is documented
+func (s *Server) Start() {}
+
+func (s *Server) Stop() {}
+
+type hidden struct{}
+
+func (h hidden) Exported() {}
+
+// Limits are documented as a group
+const (
+	MaxA = 1
+	MaxB = 2
+)
+
+var Loose = 3
+`
+	os.WriteFile(filepath.Join(tmpDir, "sample.go"), []byte(source), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "sample_test.go"), []byte("package sample\n\nfunc TestX() {}\n"), 0644)
+
+	coverage, err := computeDocCoverage(tmpDir)
+	if err != nil {
+		t.Fatalf("computeDocCoverage failed: %v", err)
+	}
+
+	// Exported: Documented, Undocumented, Server, Start, Stop, MaxA, MaxB, Loose
+	if coverage.Total != 8 {
+		t.Errorf("expected 8 exported symbols, got %d", coverage.Total)
+	}
+
+	// Documented: Documented, Server, Start, MaxA, MaxB
+	if coverage.Documented != 5 {
+		t.Errorf("expected 5 documented symbols, got %d", coverage.Documented)
+	}
+
+	if got := coverage.Ratio(); got != 5.0/8.0 {
+		t.Errorf("expected ratio 0.625, got %v", got)
+	}
+}
+
+func TestGoExtractor_Extract_ReportsDocCoverage(t *testing.T) {
+	tmpDir := t.TempDir()
+	source := "package sample\n\n// A is documented\nfunc A() {}\n\nfunc B() {}\n"
+	os.WriteFile(filepath.Join(tmpDir, "sample.go"), []byte(source), 0644)
+
+	runner := site.NewMockRunner()
+	extractor := NewGoExtractor(runner).WithIncrementalMode(false)
+
+	result, err := extractor.Extract(context.Background(), &extractors.ExtractRequest{
+		Language:  extractors.LanguageGo,
+		SourceDir: tmpDir,
+		OutputDir: filepath.Join(tmpDir, "docs"),
+	})
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	if result.Stats.DocCoverage.Documented != 1 || result.Stats.DocCoverage.Total != 2 {
+		t.Errorf("expected coverage 1/2, got %+v", result.Stats.DocCoverage)
+	}
+}
@@ -0,0 +1,12 @@
+package goextractor
+
+import (
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+func init() {
+	extractors.RegisterFactory(extractors.LanguageGo, func(runner site.CommandRunner) extractors.Extractor {
+		return NewGoExtractor(runner)
+	})
+}
@@ -38,6 +38,12 @@ func (j *JSExtractor) Extract(ctx context.Context, req *extractors.ExtractReques
 		return nil, fmt.Errorf("invalid language: expected JavaScript or TypeScript, got %s", req.Language)
 	}
 
+	// TypeDoc renders html natively and markdown via typedoc-plugin-markdown
+	if err := req.CheckFormat(extractors.OutputFormatMarkdown, extractors.OutputFormatHTML); err != nil {
+		return nil, err
+	}
+	format := req.Format()
+
 	// Validate source directory
 	if _, err := os.Stat(req.SourceDir); err != nil {
 		return nil, fmt.Errorf("invalid source directory: %w", err)
@@ -67,14 +73,14 @@ func (j *JSExtractor) Extract(ctx context.Context, req *extractors.ExtractReques
 		Errors:   []error{},
 	}
 
-	err = j.extractDocs(ctx, entryPoint, req.OutputDir, projectType)
+	err = j.extractDocs(ctx, entryPoint, req.OutputDir, projectType, format)
 	if err != nil {
 		result.Errors = append(result.Errors, err)
 		return result, fmt.Errorf("TypeDoc extraction failed: %w", err)
 	}
 
 	// Count generated files
-	files, err := j.countGeneratedFiles(req.OutputDir)
+	files, err := j.countGeneratedFiles(req.OutputDir, format)
 	if err == nil {
 		result.Files = files
 		result.Stats.DocsGenerated = len(files)
@@ -216,11 +222,12 @@ func (j *JSExtractor) findEntryPoint(rootDir string, projectType ProjectType) (s
 }
 
 // extractDocs runs TypeDoc to extract documentation
-func (j *JSExtractor) extractDocs(ctx context.Context, entryPoint, outputDir string, projectType ProjectType) error {
-	args := []string{
-		"--plugin", "typedoc-plugin-markdown",
-		"--out", outputDir,
+func (j *JSExtractor) extractDocs(ctx context.Context, entryPoint, outputDir string, projectType ProjectType, format extractors.OutputFormat) error {
+	var args []string
+	if format == extractors.OutputFormatMarkdown {
+		args = append(args, "--plugin", "typedoc-plugin-markdown")
 	}
+	args = append(args, "--out", outputDir)
 
 	// Add entry point
 	if filepath.Ext(entryPoint) == ".json" {
@@ -243,16 +250,20 @@ func (j *JSExtractor) extractDocs(ctx context.Context, entryPoint, outputDir str
 	return nil
 }
 
-// countGeneratedFiles counts markdown files in output directory
-func (j *JSExtractor) countGeneratedFiles(outputDir string) ([]string, error) {
+// countGeneratedFiles counts generated markdown or html files in output directory
+func (j *JSExtractor) countGeneratedFiles(outputDir string, format extractors.OutputFormat) ([]string, error) {
 	files := []string{}
+	ext := ".md"
+	if format == extractors.OutputFormatHTML {
+		ext = ".html"
+	}
 
 	err := filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return nil // Skip errors
 		}
 
-		if !info.IsDir() && strings.HasSuffix(path, ".md") {
+		if !info.IsDir() && strings.HasSuffix(path, ext) {
 			files = append(files, path)
 		}
 
@@ -529,3 +529,77 @@ func TestJSExtractor_Language(t *testing.T) {
 		t.Errorf("expected language %s, got %s", extractors.LanguageJavaScript, extractor.Language())
 	}
 }
+
+func TestJSExtractor_Extract_OutputFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     extractors.OutputFormat
+		wantPlugin bool
+		outputFile string
+	}{
+		{"default is markdown", "", true, "index.md"},
+		{"markdown", extractors.OutputFormatMarkdown, true, "index.md"},
+		{"html", extractors.OutputFormatHTML, false, "index.html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "index.ts"), []byte("export const a = 1"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			// Simulate generated output
+			outputDir := filepath.Join(tmpDir, "docs")
+			os.MkdirAll(outputDir, 0755)
+			os.WriteFile(filepath.Join(outputDir, tt.outputFile), []byte("doc\n"), 0644)
+
+			runner := site.NewMockRunner()
+			extractor := NewJSExtractor(runner)
+
+			result, err := extractor.Extract(context.Background(), &extractors.ExtractRequest{
+				Language:     extractors.LanguageTypeScript,
+				SourceDir:    tmpDir,
+				OutputDir:    outputDir,
+				OutputFormat: tt.format,
+			})
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+
+			if result.Stats.DocsGenerated != 1 {
+				t.Errorf("expected 1 %s doc counted, got %d", tt.outputFile, result.Stats.DocsGenerated)
+			}
+
+			calls := runner.GetCalls()
+			if len(calls) != 1 || calls[0].Cmd != "typedoc" {
+				t.Fatalf("expected a single typedoc call, got %+v", calls)
+			}
+
+			hasPlugin := false
+			for _, arg := range calls[0].Args {
+				if arg == "typedoc-plugin-markdown" {
+					hasPlugin = true
+				}
+			}
+			if hasPlugin != tt.wantPlugin {
+				t.Errorf("markdown plugin passed = %v, want %v (args: %v)", hasPlugin, tt.wantPlugin, calls[0].Args)
+			}
+		})
+	}
+}
+
+func TestJSExtractor_Extract_UnknownOutputFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	extractor := NewJSExtractor(site.NewMockRunner())
+
+	_, err := extractor.Extract(context.Background(), &extractors.ExtractRequest{
+		Language:     extractors.LanguageJavaScript,
+		SourceDir:    tmpDir,
+		OutputDir:    filepath.Join(tmpDir, "docs"),
+		OutputFormat: "pdf",
+	})
+	if !errors.Is(err, extractors.ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+package javascript
+
+import (
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+func init() {
+	extractors.RegisterFactory(extractors.LanguageJavaScript, func(runner site.CommandRunner) extractors.Extractor {
+		return NewJSExtractor(runner)
+	})
+
+	// TypeDoc handles TypeScript projects with the same extractor
+	extractors.RegisterFactory(extractors.LanguageTypeScript, func(runner site.CommandRunner) extractors.Extractor {
+		return extractors.Alias(NewJSExtractor(runner), extractors.LanguageTypeScript)
+	})
+}
@@ -0,0 +1,76 @@
+package extractors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// outputExtensions lists, per output format, the file extensions tools use
+// for that format; each is normalized to the requested extension
+var outputExtensions = map[OutputFormat][]string{
+	OutputFormatMarkdown: {".md", ".markdown", ".mdown", ".mkd", ".mkdn"},
+	OutputFormatHTML:     {".html", ".htm", ".xhtml"},
+}
+
+// defaultExtensions is the output file extension used for each format
+var defaultExtensions = map[OutputFormat]string{
+	OutputFormatMarkdown: ".md",
+	OutputFormatHTML:     ".html",
+}
+
+// Extension returns the file extension generated files should use,
+// defaulting to .md for markdown and .html for HTML output
+func (r *ExtractRequest) Extension() string {
+	ext := strings.ToLower(strings.TrimSpace(r.OutputExtension))
+	if ext == "" {
+		return defaultExtensions[r.Format()]
+	}
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+// NormalizeOutputFiles renames every file under req.OutputDir written with
+// another extension of the requested format (e.g. .markdown) to
+// req.Extension(), returning files with renamed paths updated
+func NormalizeOutputFiles(req *ExtractRequest, files []string) ([]string, error) {
+	target := req.Extension()
+	aliases := make(map[string]bool)
+	for _, ext := range outputExtensions[req.Format()] {
+		aliases[ext] = true
+	}
+
+	renamed := make(map[string]string)
+	err := filepath.Walk(req.OutputDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := filepath.Ext(path)
+		if info.IsDir() || !aliases[strings.ToLower(ext)] || ext == target {
+			return nil
+		}
+
+		newPath := strings.TrimSuffix(path, ext) + target
+		if err := os.Rename(path, newPath); err != nil {
+			return err
+		}
+		renamed[filepath.Clean(path)] = newPath
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize output extensions: %w", err)
+	}
+
+	normalized := make([]string, len(files))
+	for i, file := range files {
+		if newPath, ok := renamed[filepath.Clean(file)]; ok {
+			normalized[i] = newPath
+		} else {
+			normalized[i] = file
+		}
+	}
+	return normalized, nil
+}
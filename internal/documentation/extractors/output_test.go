@@ -0,0 +1,91 @@
+package extractors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractRequest_Extension(t *testing.T) {
+	tests := []struct {
+		name string
+		req  ExtractRequest
+		want string
+	}{
+		{name: "markdown default", req: ExtractRequest{}, want: ".md"},
+		{name: "html default", req: ExtractRequest{OutputFormat: OutputFormatHTML}, want: ".html"},
+		{name: "configured", req: ExtractRequest{OutputExtension: ".markdown"}, want: ".markdown"},
+		{name: "without dot", req: ExtractRequest{OutputExtension: "MD"}, want: ".md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.Extension(); got != tt.want {
+				t.Errorf("Extension() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeOutputFiles_RenamesMarkdownAliases(t *testing.T) {
+	outputDir := t.TempDir()
+	files := []string{
+		filepath.Join(outputDir, "api.markdown"),
+		filepath.Join(outputDir, "pkg", "types.mkd"),
+		filepath.Join(outputDir, "index.md"),
+		filepath.Join(outputDir, "diagram.svg"),
+	}
+	for _, file := range files {
+		os.MkdirAll(filepath.Dir(file), 0755)
+		os.WriteFile(file, []byte("content"), 0644)
+	}
+
+	req := &ExtractRequest{OutputDir: outputDir}
+	got, err := NormalizeOutputFiles(req, files)
+	if err != nil {
+		t.Fatalf("NormalizeOutputFiles failed: %v", err)
+	}
+
+	want := []string{
+		filepath.Join(outputDir, "api.md"),
+		filepath.Join(outputDir, "pkg", "types.md"),
+		filepath.Join(outputDir, "index.md"),
+		filepath.Join(outputDir, "diagram.svg"),
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("files[%d] = %s, want %s", i, got[i], want[i])
+		}
+		if _, err := os.Stat(want[i]); err != nil {
+			t.Errorf("Expected %s on disk: %v", want[i], err)
+		}
+	}
+
+	if _, err := os.Stat(files[0]); !os.IsNotExist(err) {
+		t.Errorf("%s should have been renamed", files[0])
+	}
+}
+
+func TestNormalizeOutputFiles_LeavesOtherFormatsAlone(t *testing.T) {
+	outputDir := t.TempDir()
+	page := filepath.Join(outputDir, "index.htm")
+	os.WriteFile(page, []byte("<html></html>"), 0644)
+
+	req := &ExtractRequest{OutputDir: outputDir}
+	got, err := NormalizeOutputFiles(req, []string{page})
+	if err != nil {
+		t.Fatalf("NormalizeOutputFiles failed: %v", err)
+	}
+	if got[0] != page {
+		t.Errorf("HTML output should be untouched for markdown requests, got %s", got[0])
+	}
+
+	req.OutputFormat = OutputFormatHTML
+	got, err = NormalizeOutputFiles(req, []string{page})
+	if err != nil {
+		t.Fatalf("NormalizeOutputFiles failed: %v", err)
+	}
+	if want := filepath.Join(outputDir, "index.html"); got[0] != want {
+		t.Errorf("files[0] = %s, want %s", got[0], want)
+	}
+}
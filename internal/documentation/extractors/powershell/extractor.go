@@ -29,6 +29,11 @@ func (p *PowerShellExtractor) Extract(ctx context.Context, req *extractors.Extra
 		return nil, fmt.Errorf("invalid language: expected %s, got %s", extractors.LanguagePowerShell, req.Language)
 	}
 
+	// Only markdown output is supported
+	if err := req.CheckFormat(extractors.OutputFormatMarkdown); err != nil {
+		return nil, err
+	}
+
 	if _, err := os.Stat(req.SourceDir); err != nil {
 		return nil, fmt.Errorf("invalid source directory: %w", err)
 	}
@@ -38,7 +43,7 @@ func (p *PowerShellExtractor) Extract(ctx context.Context, req *extractors.Extra
 	}
 
 	// Find PowerShell scripts
-	scripts, err := p.findPowerShellScripts(req.SourceDir)
+	scripts, err := p.findPowerShellScripts(req.SourceDir, req.Excluded())
 	if err != nil {
 		return nil, fmt.Errorf("failed to find PowerShell scripts: %w", err)
 	}
@@ -86,10 +91,16 @@ func (p *PowerShellExtractor) Language() extractors.Language {
 	return extractors.LanguagePowerShell
 }
 
-func (p *PowerShellExtractor) findPowerShellScripts(rootDir string) ([]string, error) {
+func (p *PowerShellExtractor) findPowerShellScripts(rootDir string, excluded extractors.ExcludedDirs) ([]string, error) {
 	scripts := []string{}
 	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if path != rootDir && excluded.Contains(info.Name()) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 		ext := strings.ToLower(filepath.Ext(path))
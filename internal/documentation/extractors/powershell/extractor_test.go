@@ -72,7 +72,7 @@ func TestPowerShellExtractor_findPowerShellScripts(t *testing.T) {
 	runner := site.NewMockRunner()
 	extractor := NewPowerShellExtractor(runner)
 
-	found, err := extractor.findPowerShellScripts(tmpDir)
+	found, err := extractor.findPowerShellScripts(tmpDir, extractors.NewExcludedDirs())
 	if err != nil {
 		t.Fatalf("findPowerShellScripts failed: %v", err)
 	}
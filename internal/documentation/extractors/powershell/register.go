@@ -0,0 +1,12 @@
+package powershell
+
+import (
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+func init() {
+	extractors.RegisterFactory(extractors.LanguagePowerShell, func(runner site.CommandRunner) extractors.Extractor {
+		return NewPowerShellExtractor(runner)
+	})
+}
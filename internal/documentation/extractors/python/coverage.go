@@ -0,0 +1,130 @@
+package python
+
+import (
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+)
+
+// computeDocCoverage counts public module-level functions and classes, plus
+// public methods of public classes, and how many of them have a docstring.
+// Names starting with an underscore are treated as private.
+func computeDocCoverage(code string) extractors.DocCoverage {
+	coverage := extractors.DocCoverage{}
+	lines := strings.Split(code, "\n")
+
+	inPublicClass := false
+	methodIndent := -1
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent == 0 {
+			inPublicClass = false
+			methodIndent = -1
+		}
+
+		name, isClass, ok := parseDefinition(trimmed)
+		if !ok {
+			continue
+		}
+
+		public := !strings.HasPrefix(name, "_")
+
+		switch {
+		case indent == 0:
+			if isClass {
+				inPublicClass = public
+			}
+		case inPublicClass && !isClass:
+			// Only direct methods of the class, not nested functions
+			if methodIndent == -1 {
+				methodIndent = indent
+			}
+			if indent != methodIndent {
+				continue
+			}
+		default:
+			continue
+		}
+
+		if !public {
+			continue
+		}
+
+		coverage.Total++
+
+		// A body on the same line as the signature cannot hold a docstring
+		end, inline := signatureEnd(lines, i)
+		if !inline && hasDocstring(lines, end+1) {
+			coverage.Documented++
+		}
+	}
+
+	return coverage
+}
+
+// parseDefinition extracts the name from a "def", "async def", or "class" line
+func parseDefinition(trimmed string) (name string, isClass bool, ok bool) {
+	var rest string
+	switch {
+	case strings.HasPrefix(trimmed, "class "):
+		rest, isClass = strings.TrimPrefix(trimmed, "class "), true
+	case strings.HasPrefix(trimmed, "def "):
+		rest = strings.TrimPrefix(trimmed, "def ")
+	case strings.HasPrefix(trimmed, "async def "):
+		rest = strings.TrimPrefix(trimmed, "async def ")
+	default:
+		return "", false, false
+	}
+
+	end := strings.IndexAny(rest, "(:")
+	if end <= 0 {
+		return "", false, false
+	}
+
+	return strings.TrimSpace(rest[:end]), isClass, true
+}
+
+// signatureEnd finds the line where the definition starting at start ends
+// with its closing colon, and whether a body follows on that same line
+func signatureEnd(lines []string, start int) (int, bool) {
+	depth := 0
+	for i := start; i < len(lines); i++ {
+		line := lines[i]
+		for j, r := range line {
+			switch r {
+			case '(', '[', '{':
+				depth++
+			case ')', ']', '}':
+				depth--
+			case ':':
+				if depth == 0 {
+					rest := strings.TrimSpace(line[j+1:])
+					return i, rest != "" && !strings.HasPrefix(rest, "#")
+				}
+			}
+		}
+	}
+	return len(lines) - 1, false
+}
+
+// hasDocstring reports whether the first statement at or after start is a string literal
+func hasDocstring(lines []string, start int) bool {
+	for i := start; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		trimmed = strings.TrimLeft(trimmed, "rRuUbB")
+		return strings.HasPrefix(trimmed, `"""`) || strings.HasPrefix(trimmed, "'''") ||
+			strings.HasPrefix(trimmed, `"`) || strings.HasPrefix(trimmed, "'")
+	}
+	return false
+}
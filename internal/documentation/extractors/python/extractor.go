@@ -30,6 +30,11 @@ func (p *PythonExtractor) Extract(ctx context.Context, req *extractors.ExtractRe
 		return nil, fmt.Errorf("invalid language: expected %s, got %s", extractors.LanguagePython, req.Language)
 	}
 
+	// pydoc-markdown only produces markdown
+	if err := req.CheckFormat(extractors.OutputFormatMarkdown); err != nil {
+		return nil, err
+	}
+
 	// Validate source directory
 	if _, err := os.Stat(req.SourceDir); err != nil {
 		return nil, fmt.Errorf("invalid source directory: %w", err)
@@ -41,7 +46,7 @@ func (p *PythonExtractor) Extract(ctx context.Context, req *extractors.ExtractRe
 	}
 
 	// Find all Python modules
-	modules, err := p.findPythonModules(req.SourceDir)
+	modules, err := p.findPythonModules(req.SourceDir, req.Excluded())
 	if err != nil {
 		return nil, fmt.Errorf("failed to find Python modules: %w", err)
 	}
@@ -81,6 +86,11 @@ func (p *PythonExtractor) Extract(ctx context.Context, req *extractors.ExtractRe
 		}
 		outputPath := filepath.Join(req.OutputDir, outputName+".md")
 
+		// Measure documented public symbols
+		if content, err := os.ReadFile(module); err == nil {
+			result.Stats.DocCoverage.Add(computeDocCoverage(string(content)))
+		}
+
 		// Extract documentation for this module
 		err = p.extractModule(ctx, module, outputPath)
 		if err != nil {
@@ -116,7 +126,7 @@ func (p *PythonExtractor) Language() extractors.Language {
 }
 
 // findPythonModules finds all Python modules in the source directory
-func (p *PythonExtractor) findPythonModules(rootDir string) ([]string, error) {
+func (p *PythonExtractor) findPythonModules(rootDir string, excluded extractors.ExcludedDirs) ([]string, error) {
 	modules := []string{}
 	visited := make(map[string]bool)
 
@@ -129,9 +139,7 @@ func (p *PythonExtractor) findPythonModules(rootDir string) ([]string, error) {
 		if info.IsDir() {
 			// Skip common excluded directories
 			dirName := filepath.Base(path)
-			if dirName == "venv" || dirName == ".venv" || dirName == "env" ||
-				dirName == "__pycache__" || dirName == ".git" ||
-				dirName == "node_modules" || dirName == ".taskmaster" ||
+			if excluded.Contains(dirName) || dirName == "venv" || dirName == "env" ||
 				strings.HasPrefix(dirName, ".") {
 				return filepath.SkipDir
 			}
@@ -252,7 +252,7 @@ func TestPythonExtractor_findPythonModules(t *testing.T) {
 	runner := site.NewMockRunner()
 	extractor := NewPythonExtractor(runner)
 
-	modules, err := extractor.findPythonModules(tmpDir)
+	modules, err := extractor.findPythonModules(tmpDir, extractors.NewExcludedDirs())
 	if err != nil {
 		t.Fatalf("findPythonModules failed: %v", err)
 	}
@@ -472,3 +472,84 @@ func TestPythonExtractor_Extract_ContextCancellation(t *testing.T) {
 		t.Error("expected error due to context cancellation")
 	}
 }
+
+func TestComputeDocCoverage(t *testing.T) {
+	code := `"""Module docstring."""
+
+import os
+
+
+def documented(a, b):
+    """Add two numbers."""
+    return a + b
+
+
+def undocumented():
+    return 1
+
+
+def _private():
+    """Not counted."""
+
+
+def one_liner(): return 2
+
+
+@decorator
+def multi_line(
+    first,
+    second,
+) -> dict:
+    """Spans several lines."""
+
+    def nested():
+        return None
+
+    return {}
+
+
+class Service:
+    """A public class."""
+
+    def start(self):
+        """Start the service."""
+
+    def stop(self):
+        pass
+
+    def _reset(self):
+        pass
+
+
+class _Internal:
+    def method(self):
+        pass
+
+
+async def fetch():
+    '''Fetch data.'''
+`
+
+	coverage := computeDocCoverage(code)
+
+	// Public: documented, undocumented, one_liner, multi_line, Service, start, stop, fetch
+	if coverage.Total != 8 {
+		t.Errorf("expected 8 public symbols, got %d", coverage.Total)
+	}
+
+	// Documented: documented, multi_line, Service, start, fetch
+	if coverage.Documented != 5 {
+		t.Errorf("expected 5 documented symbols, got %d", coverage.Documented)
+	}
+
+	if got := coverage.Ratio(); got != 5.0/8.0 {
+		t.Errorf("expected ratio 0.625, got %v", got)
+	}
+}
+
+func TestComputeDocCoverage_Empty(t *testing.T) {
+	coverage := computeDocCoverage("import os\n")
+	if coverage.Total != 0 || coverage.Ratio() != 0 {
+		t.Errorf("expected no symbols, got %+v", coverage)
+	}
+}
@@ -0,0 +1,12 @@
+package python
+
+import (
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+func init() {
+	extractors.RegisterFactory(extractors.LanguagePython, func(runner site.CommandRunner) extractors.Extractor {
+		return NewPythonExtractor(runner)
+	})
+}
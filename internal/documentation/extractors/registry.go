@@ -1,10 +1,21 @@
 package extractors
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 )
 
+var (
+	// ErrNotRegistered is returned when no extractor is registered for a language
+	ErrNotRegistered = errors.New("no extractor registered")
+
+	// ErrAlreadyRegistered is returned by Register when the language already
+	// has an extractor; use Replace to overwrite it deliberately
+	ErrAlreadyRegistered = errors.New("extractor already registered")
+)
+
 // Registry manages registered documentation extractors
 type Registry struct {
 	mu         sync.RWMutex
@@ -18,7 +29,8 @@ func NewRegistry() *Registry {
 	}
 }
 
-// Register adds an extractor to the registry
+// Register adds an extractor to the registry. It fails with
+// ErrAlreadyRegistered rather than overwrite an existing extractor.
 func (r *Registry) Register(extractor Extractor) error {
 	if extractor == nil {
 		return fmt.Errorf("extractor cannot be nil")
@@ -33,13 +45,32 @@ func (r *Registry) Register(extractor Extractor) error {
 	defer r.mu.Unlock()
 
 	if _, exists := r.extractors[lang]; exists {
-		return fmt.Errorf("extractor for %s already registered", lang)
+		return fmt.Errorf("%w for %s", ErrAlreadyRegistered, lang)
 	}
 
 	r.extractors[lang] = extractor
 	return nil
 }
 
+// Replace adds an extractor, overwriting any extractor already registered
+// for the same language
+func (r *Registry) Replace(extractor Extractor) error {
+	if extractor == nil {
+		return fmt.Errorf("extractor cannot be nil")
+	}
+
+	lang := extractor.Language()
+	if !lang.IsValid() {
+		return fmt.Errorf("invalid language: %s", lang)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.extractors[lang] = extractor
+	return nil
+}
+
 // Get retrieves an extractor for a specific language
 func (r *Registry) Get(lang Language) (Extractor, error) {
 	r.mu.RLock()
@@ -47,12 +78,25 @@ func (r *Registry) Get(lang Language) (Extractor, error) {
 
 	extractor, ok := r.extractors[lang]
 	if !ok {
-		return nil, fmt.Errorf("no extractor registered for %s", lang)
+		return nil, fmt.Errorf("%w for %s", ErrNotRegistered, lang)
 	}
 
 	return extractor, nil
 }
 
+// Unregister removes the extractor for a language
+func (r *Registry) Unregister(lang Language) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.extractors[lang]; !ok {
+		return fmt.Errorf("%w for %s", ErrNotRegistered, lang)
+	}
+
+	delete(r.extractors, lang)
+	return nil
+}
+
 // Has checks if an extractor is registered for a language
 func (r *Registry) Has(lang Language) bool {
 	r.mu.RLock()
@@ -75,7 +119,7 @@ func (r *Registry) List() []Extractor {
 	return extractors
 }
 
-// Languages returns all languages with registered extractors
+// Languages returns all languages with registered extractors, sorted
 func (r *Registry) Languages() []Language {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -84,6 +128,7 @@ func (r *Registry) Languages() []Language {
 	for lang := range r.extractors {
 		langs = append(langs, lang)
 	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i] < langs[j] })
 
 	return langs
 }
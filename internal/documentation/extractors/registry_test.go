@@ -2,6 +2,8 @@ package extractors
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"sync"
 	"testing"
 )
@@ -57,9 +59,53 @@ func TestRegistry_Register(t *testing.T) {
 		if err == nil {
 			t.Error("expected error for duplicate registration")
 		}
+
+		if !errors.Is(err, ErrAlreadyRegistered) {
+			t.Errorf("expected ErrAlreadyRegistered, got %v", err)
+		}
+
+		if got, _ := registry.Get(LanguageGo); got != extractor1 {
+			t.Error("duplicate registration should not overwrite the first extractor")
+		}
+	})
+
+	t.Run("alias under a different language", func(t *testing.T) {
+		registry := NewRegistry()
+		js := &MockExtractor{lang: LanguageJavaScript}
+
+		if err := registry.Register(js); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := registry.Register(Alias(js, LanguageTypeScript)); err != nil {
+			t.Fatalf("alias for a different language should register, got %v", err)
+		}
+
+		if registry.Count() != 2 {
+			t.Errorf("expected 2 extractors, got %d", registry.Count())
+		}
 	})
 }
 
+func TestRegistry_Replace(t *testing.T) {
+	registry := NewRegistry()
+	original := &MockExtractor{lang: LanguageGo}
+	replacement := &MockExtractor{lang: LanguageGo}
+
+	registry.Register(original)
+
+	if err := registry.Replace(replacement); err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+
+	if got, _ := registry.Get(LanguageGo); got != replacement {
+		t.Error("Replace should overwrite the existing extractor")
+	}
+
+	if err := registry.Replace(nil); err == nil {
+		t.Error("expected error for nil extractor")
+	}
+}
+
 func TestRegistry_Get(t *testing.T) {
 	t.Run("get existing extractor", func(t *testing.T) {
 		registry := NewRegistry()
@@ -143,6 +189,41 @@ func TestRegistry_Languages(t *testing.T) {
 	}
 }
 
+func TestRegistry_Unregister(t *testing.T) {
+	registry := NewRegistry()
+
+	registry.Register(&MockExtractor{lang: LanguageRust})
+	registry.Register(&MockExtractor{lang: LanguageGo})
+	registry.Register(&MockExtractor{lang: LanguagePython})
+
+	want := []Language{LanguageGo, LanguagePython, LanguageRust}
+	if got := registry.Languages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Languages() = %v, want %v", got, want)
+	}
+
+	if err := registry.Unregister(LanguagePython); err != nil {
+		t.Fatalf("Unregister failed: %v", err)
+	}
+
+	want = []Language{LanguageGo, LanguageRust}
+	if got := registry.Languages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Languages() after unregister = %v, want %v", got, want)
+	}
+
+	if _, err := registry.Get(LanguagePython); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Get after unregister: expected ErrNotRegistered, got %v", err)
+	}
+
+	if err := registry.Unregister(LanguagePython); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("second Unregister: expected ErrNotRegistered, got %v", err)
+	}
+
+	// The language can be registered again
+	if err := registry.Register(&MockExtractor{lang: LanguagePython}); err != nil {
+		t.Errorf("re-register after unregister failed: %v", err)
+	}
+}
+
 func TestRegistry_Clear(t *testing.T) {
 	registry := NewRegistry()
 
@@ -185,6 +266,11 @@ func TestRegistry_ThreadSafety(t *testing.T) {
 
 			// Check has
 			registry.Has(lang)
+
+			// Remove occasionally (may fail if already removed, that's ok)
+			if idx%10 == 0 {
+				registry.Unregister(lang)
+			}
 		}(i)
 	}
 
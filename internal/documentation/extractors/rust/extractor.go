@@ -29,6 +29,11 @@ func (r *RustExtractor) Extract(ctx context.Context, req *extractors.ExtractRequ
 		return nil, fmt.Errorf("invalid language: expected %s, got %s", extractors.LanguageRust, req.Language)
 	}
 
+	// Only markdown output is supported
+	if err := req.CheckFormat(extractors.OutputFormatMarkdown); err != nil {
+		return nil, err
+	}
+
 	if _, err := os.Stat(req.SourceDir); err != nil {
 		return nil, fmt.Errorf("invalid source directory: %w", err)
 	}
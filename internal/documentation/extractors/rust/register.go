@@ -0,0 +1,12 @@
+package rust
+
+import (
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+func init() {
+	extractors.RegisterFactory(extractors.LanguageRust, func(runner site.CommandRunner) extractors.Extractor {
+		return NewRustExtractor(runner)
+	})
+}
@@ -1,6 +1,11 @@
 package extractors
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
 
 // Language represents a programming language
 type Language string
@@ -50,6 +55,18 @@ func (l Language) String() string {
 	return string(l)
 }
 
+// OutputFormat is the documentation format produced by an extractor
+type OutputFormat string
+
+// Supported output formats
+const (
+	OutputFormatMarkdown OutputFormat = "markdown"
+	OutputFormatHTML     OutputFormat = "html"
+)
+
+// ErrUnsupportedFormat is returned when an extractor's tools cannot produce the requested format
+var ErrUnsupportedFormat = errors.New("unsupported output format")
+
 // ExtractRequest defines parameters for documentation extraction
 type ExtractRequest struct {
 	// Language to extract documentation for
@@ -61,10 +78,59 @@ type ExtractRequest struct {
 	// OutputDir is where extracted markdown will be written
 	OutputDir string
 
+	// ScratchDir is a private working directory for intermediate tool files,
+	// removed after extraction (empty = the system temp directory)
+	ScratchDir string
+
+	// OutputFormat selects markdown (default) or html output
+	OutputFormat OutputFormat
+
+	// OutputExtension is the extension generated files are renamed to
+	// (empty = the default for OutputFormat)
+	OutputExtension string
+
+	// ExcludedDirs lists directory names to skip while scanning SourceDir,
+	// in addition to DefaultExcludedDirs
+	ExcludedDirs []string
+
 	// Options for extractor-specific configuration
 	Options map[string]interface{}
 }
 
+// Format returns the requested output format, defaulting to markdown
+func (r *ExtractRequest) Format() OutputFormat {
+	if r.OutputFormat == "" {
+		return OutputFormatMarkdown
+	}
+	return r.OutputFormat
+}
+
+// WorkDir returns the directory for intermediate tool files
+func (r *ExtractRequest) WorkDir() string {
+	if r.ScratchDir == "" {
+		return os.TempDir()
+	}
+	return r.ScratchDir
+}
+
+// Excluded returns the directory names to skip while scanning SourceDir
+func (r *ExtractRequest) Excluded() ExcludedDirs {
+	return NewExcludedDirs(r.ExcludedDirs...)
+}
+
+// CheckFormat returns an error wrapping ErrUnsupportedFormat unless the
+// requested format is one of supported
+func (r *ExtractRequest) CheckFormat(supported ...OutputFormat) error {
+	format := r.Format()
+	for _, f := range supported {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s extractor cannot produce %q output (supported: %v)",
+		ErrUnsupportedFormat, r.Language, format, supported)
+}
+
 // ExtractResult contains the result of documentation extraction
 type ExtractResult struct {
 	// Language that was processed
@@ -93,6 +159,32 @@ type ExtractionStats struct {
 
 	// Duration in milliseconds
 	Duration int64
+
+	// DocCoverage counts documented public symbols (populated by Go and Python)
+	DocCoverage DocCoverage
+}
+
+// DocCoverage measures how much of the public API is documented
+type DocCoverage struct {
+	// Documented is the number of exported symbols with a doc comment or docstring
+	Documented int
+
+	// Total is the number of exported symbols
+	Total int
+}
+
+// Ratio returns Documented/Total, or 0 when there are no exported symbols
+func (c DocCoverage) Ratio() float64 {
+	if c.Total == 0 {
+		return 0
+	}
+	return float64(c.Documented) / float64(c.Total)
+}
+
+// Add accumulates another coverage measurement
+func (c *DocCoverage) Add(other DocCoverage) {
+	c.Documented += other.Documented
+	c.Total += other.Total
 }
 
 // Extractor defines the interface for language-specific documentation extractors
@@ -0,0 +1,297 @@
+package glossary
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/normalizer"
+)
+
+// OutputPath is the glossary location relative to the docs root
+var OutputPath = filepath.Join("_reference", "glossary.md")
+
+// markerPattern matches <!-- term:X --> markers that declare a term in place
+var markerPattern = regexp.MustCompile(`<!--\s*term:\s*(.+?)\s*-->`)
+
+// termBoundary matches the edge of a term. Unlike \b it also holds for terms
+// that start or end with punctuation, such as "C++" or ".NET".
+const termBoundary = `[^\p{L}\p{N}_]`
+
+// Occurrence is a page that mentions a term
+type Occurrence struct {
+	// Page is the markdown file path relative to the docs root
+	Page string
+
+	// Title is the page title from front matter, or the file name
+	Title string
+
+	// Link points from the glossary to the page, including the heading anchor
+	// of the section where the term first appears
+	Link string
+}
+
+// Entry is a glossary term and every page it appears on
+type Entry struct {
+	Term        string
+	Occurrences []Occurrence
+}
+
+// page is a parsed markdown file
+type page struct {
+	path      string
+	title     string
+	permalink string
+	lines     []string
+}
+
+// Build scans the markdown files under docsDir for the given terms and for
+// terms declared with <!-- term:X --> markers. Matching is case-insensitive
+// on word boundaries; terms and markers inside fenced code blocks are
+// ignored. Entries are sorted by term; terms that appear nowhere are omitted.
+func Build(docsDir string, terms []string) ([]Entry, error) {
+	pages, err := loadPages(docsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Markers declare additional terms, which are then matched on every page
+	known := make(map[string]string)
+	for _, term := range terms {
+		if term = strings.TrimSpace(term); term != "" {
+			known[strings.ToLower(term)] = term
+		}
+	}
+	for _, p := range pages {
+		for _, line := range p.prose() {
+			for _, m := range markerPattern.FindAllStringSubmatch(line, -1) {
+				if _, ok := known[strings.ToLower(m[1])]; !ok {
+					known[strings.ToLower(m[1])] = m[1]
+				}
+			}
+		}
+	}
+
+	var entries []Entry
+	for key, term := range known {
+		pattern := regexp.MustCompile(`(?i)(^|` + termBoundary + `)` + regexp.QuoteMeta(term) + `($|` + termBoundary + `)`)
+
+		var occurrences []Occurrence
+		for _, p := range pages {
+			anchor, ok := p.find(key, pattern)
+			if !ok {
+				continue
+			}
+			occurrences = append(occurrences, Occurrence{
+				Page:  p.path,
+				Title: p.title,
+				Link:  p.link() + anchor,
+			})
+		}
+
+		if len(occurrences) > 0 {
+			entries = append(entries, Entry{Term: term, Occurrences: occurrences})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return strings.ToLower(entries[i].Term) < strings.ToLower(entries[j].Term)
+	})
+
+	return entries, nil
+}
+
+// Generate builds the glossary for docsDir and writes it to
+// <docsDir>/_reference/glossary.md, returning the path
+func Generate(docsDir string, terms []string) (string, error) {
+	entries, err := Build(docsDir, terms)
+	if err != nil {
+		return "", err
+	}
+
+	fm := normalizer.GenerateFrontMatter(normalizer.FrontMatterOptions{
+		FilePath:    "glossary.md",
+		Section:     "_reference",
+		CustomTitle: "Glossary",
+	})
+	header, err := fm.ToYAML()
+	if err != nil {
+		return "", err
+	}
+
+	outputPath := filepath.Join(docsDir, OutputPath)
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create reference directory: %w", err)
+	}
+
+	if err := os.WriteFile(outputPath, []byte(header+Render(entries)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	return outputPath, nil
+}
+
+// Render produces the glossary page, grouped by initial letter
+func Render(entries []Entry) string {
+	var b strings.Builder
+	b.WriteString("# Glossary\n\n")
+
+	if len(entries) == 0 {
+		b.WriteString("No glossary terms found.\n")
+		return b.String()
+	}
+
+	letter := ""
+	for _, e := range entries {
+		r, _ := utf8.DecodeRuneInString(e.Term)
+		initial := string(unicode.ToUpper(r))
+		if initial != letter {
+			letter = initial
+			fmt.Fprintf(&b, "## %s\n\n", letter)
+		}
+
+		fmt.Fprintf(&b, "### %s\n\n", e.Term)
+		for _, o := range e.Occurrences {
+			fmt.Fprintf(&b, "- [%s](%s)\n", o.Title, o.Link)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// loadPages reads every markdown file under docsDir except the glossary itself
+func loadPages(docsDir string) ([]*page, error) {
+	var pages []*page
+
+	err := filepath.WalkDir(docsDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if path != docsDir && (name == "_site" || name == "node_modules" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".md" && ext != ".markdown" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(docsDir, path)
+		if err != nil || rel == OutputPath {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		fm, body, err := normalizer.ParseFrontMatter(string(data))
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		p := &page{
+			path:  filepath.ToSlash(rel),
+			title: strings.TrimSuffix(filepath.Base(rel), filepath.Ext(rel)),
+			lines: strings.Split(body, "\n"),
+		}
+		if fm != nil {
+			p.permalink = fm.Permalink
+			if fm.Title != "" {
+				p.title = fm.Title
+			}
+		}
+
+		pages = append(pages, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pages, func(i, j int) bool { return pages[i].path < pages[j].path })
+	return pages, nil
+}
+
+// find reports whether the page mentions the term, returning the anchor of the
+// heading above the first mention ("" when it precedes any heading)
+func (p *page) find(key string, pattern *regexp.Regexp) (string, bool) {
+	anchor := ""
+
+	for _, line := range p.prose() {
+		for _, m := range markerPattern.FindAllStringSubmatch(line, -1) {
+			if strings.ToLower(m[1]) == key {
+				return anchor, true
+			}
+		}
+
+		text := markerPattern.ReplaceAllString(line, "")
+		if heading, ok := strings.CutPrefix(text, "#"); ok {
+			anchor = "#" + slugify(strings.TrimLeft(heading, "# "))
+		}
+		if pattern.MatchString(text) {
+			return anchor, true
+		}
+	}
+
+	return "", false
+}
+
+// prose returns the lines of the page outside fenced code blocks
+func (p *page) prose() []string {
+	var lines []string
+	inFence := false
+
+	for _, line := range p.lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// link returns the URL of the page: its permalink when set, otherwise the
+// markdown path relative to the glossary
+func (p *page) link() string {
+	if p.permalink != "" {
+		return p.permalink
+	}
+
+	rel, err := filepath.Rel(filepath.Dir(OutputPath), filepath.FromSlash(p.path))
+	if err != nil {
+		return p.path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// slugify converts a heading to the anchor id generated by kramdown
+func slugify(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,156 @@
+package glossary
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeDocs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func TestGenerateLinksEveryPage(t *testing.T) {
+	docs := writeDocs(t, map[string]string{
+		"_api/pipeline.md": "---\ntitle: Pipeline\npermalink: /api/pipeline/\n---\n\n# Pipeline\n\n## Running Steps\n\nEach step writes a Checkpoint.\n",
+		"guides/resume.md": "# Resuming\n\nA failed run can resume from its last checkpoint.\n",
+		"guides/other.md":  "# Other\n\nNothing relevant here.\n",
+	})
+
+	path, err := Generate(docs, []string{"checkpoint"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if path != filepath.Join(docs, "_reference", "glossary.md") {
+		t.Errorf("unexpected output path %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+
+	for _, want := range []string{
+		"title: Glossary",
+		"### checkpoint",
+		"- [Pipeline](/api/pipeline/#running-steps)",
+		"- [resume](../guides/resume.md#resuming)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("glossary missing %q:\n%s", want, content)
+		}
+	}
+
+	if strings.Contains(content, "other") {
+		t.Errorf("page without the term should not be linked:\n%s", content)
+	}
+}
+
+func TestBuildMarkersDeclareTerms(t *testing.T) {
+	docs := writeDocs(t, map[string]string{
+		"a.md": "# Intro\n\n<!-- term:Front Matter -->\nYAML at the top of a page.\n",
+		"b.md": "# Usage\n\nThe normalizer adds front matter.\n\n```\nfront matter in code is ignored\n```\n",
+		"c.md": "# Code\n\n```yaml\n# front matter\n```\n",
+	})
+
+	entries, err := Build(docs, nil)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if len(entries) != 1 || entries[0].Term != "Front Matter" {
+		t.Fatalf("expected one 'Front Matter' entry, got %+v", entries)
+	}
+
+	occurrences := entries[0].Occurrences
+	if len(occurrences) != 2 {
+		t.Fatalf("expected 2 occurrences, got %+v", occurrences)
+	}
+	if occurrences[0].Link != "../a.md#intro" || occurrences[1].Link != "../b.md#usage" {
+		t.Errorf("unexpected links: %+v", occurrences)
+	}
+}
+
+func TestBuildSkipsExistingGlossary(t *testing.T) {
+	docs := writeDocs(t, map[string]string{
+		"_reference/glossary.md": "# Glossary\n\n### cache\n",
+		"index.md":               "# Home\n",
+	})
+
+	entries, err := Build(docs, []string{"cache"})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("glossary should not index itself, got %+v", entries)
+	}
+}
+
+func TestRenderEmpty(t *testing.T) {
+	if got := Render(nil); !strings.Contains(got, "No glossary terms found.") {
+		t.Errorf("unexpected output: %s", got)
+	}
+}
+
+func TestBuildMatchesTermsWithPunctuation(t *testing.T) {
+	docs := writeDocs(t, map[string]string{
+		"a.md": "# Stack\n\nBuilt with C++ and .NET, served by Node.js.\n",
+		"b.md": "# Other\n\nC++11 and ASP.NETCore are different words.\n",
+	})
+
+	entries, err := Build(docs, []string{"C++", ".NET", "Node.js"})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", entries)
+	}
+	for _, e := range entries {
+		if len(e.Occurrences) != 1 || e.Occurrences[0].Page != "a.md" {
+			t.Errorf("expected %q only on a.md, got %+v", e.Term, e.Occurrences)
+		}
+	}
+}
+
+func TestBuildIgnoresMarkersInCodeBlocks(t *testing.T) {
+	docs := writeDocs(t, map[string]string{
+		"a.md": "# Example\n\n```html\n<!-- term:Widget -->\n```\n\nEvery widget renders itself.\n",
+	})
+
+	entries, err := Build(docs, nil)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("markers inside code blocks should not declare terms, got %+v", entries)
+	}
+}
+
+func TestRenderGroupsByFirstRune(t *testing.T) {
+	got := Render([]Entry{
+		{Term: "échéance", Occurrences: []Occurrence{{Title: "Billing", Link: "billing.md"}}},
+	})
+
+	if !strings.Contains(got, "## É\n") {
+		t.Errorf("expected the heading to use the whole first letter:\n%s", got)
+	}
+}
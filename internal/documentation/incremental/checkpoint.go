@@ -0,0 +1,111 @@
+package incremental
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const defaultCheckpointPath = ".aurumcode/cache/checkpoint.json"
+
+// Checkpoint records the work completed during a single pipeline run so an
+// interrupted run can be resumed. Unlike Cache, it is discarded once the run
+// finishes successfully.
+type Checkpoint struct {
+	// StartedAt is when the checkpointed run began
+	StartedAt time.Time `json:"started_at"`
+
+	// UpdatedAt is when the checkpoint was last written
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Completed maps languages to their finished extraction
+	Completed map[string]LanguageCheckpoint `json:"completed"`
+}
+
+// LanguageCheckpoint describes a completed language extraction
+type LanguageCheckpoint struct {
+	SourceFiles       []string  `json:"source_files"`
+	DocFiles          []string  `json:"doc_files"`
+	FilesProcessed    int       `json:"files_processed"`
+	DocsGenerated     int       `json:"docs_generated"`
+	SymbolsDocumented int       `json:"symbols_documented,omitempty"`
+	SymbolsTotal      int       `json:"symbols_total,omitempty"`
+	CompletedAt       time.Time `json:"completed_at"`
+}
+
+// NewCheckpoint creates an empty checkpoint for a new run
+func NewCheckpoint() *Checkpoint {
+	now := time.Now()
+	return &Checkpoint{
+		StartedAt: now,
+		UpdatedAt: now,
+		Completed: make(map[string]LanguageCheckpoint),
+	}
+}
+
+// MarkCompleted records that extraction for language finished
+func (c *Checkpoint) MarkCompleted(language string, entry LanguageCheckpoint) {
+	if c.Completed == nil {
+		c.Completed = make(map[string]LanguageCheckpoint)
+	}
+
+	if entry.CompletedAt.IsZero() {
+		entry.CompletedAt = time.Now()
+	}
+
+	c.Completed[language] = entry
+	c.UpdatedAt = time.Now()
+}
+
+// IsCompleted returns true if language was already extracted in this run
+func (c *Checkpoint) IsCompleted(language string) bool {
+	_, ok := c.Completed[language]
+	return ok
+}
+
+// Get returns the recorded extraction for language
+func (c *Checkpoint) Get(language string) (LanguageCheckpoint, bool) {
+	entry, ok := c.Completed[language]
+	return entry, ok
+}
+
+// Save persists the checkpoint to a JSON file
+func (c *Checkpoint) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+
+	return nil
+}
+
+// Load reads the checkpoint from a JSON file; a missing file leaves it empty
+func (c *Checkpoint) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+	}
+
+	if c.Completed == nil {
+		c.Completed = make(map[string]LanguageCheckpoint)
+	}
+
+	return nil
+}
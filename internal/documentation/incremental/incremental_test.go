@@ -360,3 +360,105 @@ func TestCache_UpdateCommit(t *testing.T) {
 		t.Error("LastUpdate should be updated")
 	}
 }
+
+func TestHashContent(t *testing.T) {
+	a := HashContent([]byte("package main\n"))
+	b := HashContent([]byte("package main\n"))
+	c := HashContent([]byte("package other\n"))
+
+	if a != b {
+		t.Error("identical content should hash identically")
+	}
+	if a == c {
+		t.Error("different content should hash differently")
+	}
+	if len(a) != 64 {
+		t.Errorf("expected 64 hex chars, got %d", len(a))
+	}
+}
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(path, []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := HashFile(path)
+	if err != nil {
+		t.Fatalf("HashFile failed: %v", err)
+	}
+	if hash != HashContent([]byte("package main\n")) {
+		t.Error("HashFile should match HashContent of the file data")
+	}
+
+	if _, err := HashFile(filepath.Join(t.TempDir(), "missing.go")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestCheckpoint_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "checkpoint.json")
+
+	checkpoint := NewCheckpoint()
+	checkpoint.MarkCompleted("go", LanguageCheckpoint{
+		SourceFiles:    []string{"main.go"},
+		DocFiles:       []string{"docs/go/index.md"},
+		FilesProcessed: 1,
+		DocsGenerated:  1,
+	})
+
+	if err := checkpoint.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded := NewCheckpoint()
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if !loaded.IsCompleted("go") {
+		t.Error("Go should be completed after load")
+	}
+
+	if loaded.IsCompleted("python") {
+		t.Error("Python should not be completed")
+	}
+
+	entry, _ := loaded.Get("go")
+	if entry.DocsGenerated != 1 || entry.CompletedAt.IsZero() {
+		t.Errorf("Unexpected checkpoint entry: %+v", entry)
+	}
+}
+
+func TestManager_ClearCheckpoint(t *testing.T) {
+	tmpDir := t.TempDir()
+	runner := site.NewMockRunner()
+	manager := NewManagerWithCache(runner, tmpDir, filepath.Join(tmpDir, "incremental.json"))
+
+	manager.GetCheckpoint().MarkCompleted("go", LanguageCheckpoint{})
+	if err := manager.SaveCheckpoint(); err != nil {
+		t.Fatalf("SaveCheckpoint failed: %v", err)
+	}
+
+	checkpointPath := filepath.Join(tmpDir, "checkpoint.json")
+	if _, err := os.Stat(checkpointPath); err != nil {
+		t.Fatalf("Checkpoint should be stored next to the cache: %v", err)
+	}
+
+	if err := manager.ClearCheckpoint(); err != nil {
+		t.Fatalf("ClearCheckpoint failed: %v", err)
+	}
+
+	if _, err := os.Stat(checkpointPath); !os.IsNotExist(err) {
+		t.Error("Checkpoint file should be removed")
+	}
+
+	if manager.GetCheckpoint().IsCompleted("go") {
+		t.Error("Cleared checkpoint should be empty")
+	}
+
+	// Clearing again is a no-op
+	if err := manager.ClearCheckpoint(); err != nil {
+		t.Errorf("ClearCheckpoint on missing file failed: %v", err)
+	}
+}
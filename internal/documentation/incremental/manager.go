@@ -3,6 +3,7 @@ package incremental
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/Mpaape/AurumCode/internal/documentation/site"
@@ -12,26 +13,32 @@ const defaultCachePath = ".aurumcode/cache/incremental.json"
 
 // Manager coordinates incremental documentation builds
 type Manager struct {
-	detector  *ChangeDetector
-	cache     *Cache
-	cachePath string
+	detector       *ChangeDetector
+	cache          *Cache
+	cachePath      string
+	checkpoint     *Checkpoint
+	checkpointPath string
 }
 
 // NewManager creates a new incremental documentation manager
 func NewManager(runner site.CommandRunner, repoDir string) *Manager {
 	return &Manager{
-		detector:  NewChangeDetector(runner, repoDir),
-		cache:     NewCache(),
-		cachePath: defaultCachePath,
+		detector:       NewChangeDetector(runner, repoDir),
+		cache:          NewCache(),
+		cachePath:      defaultCachePath,
+		checkpoint:     NewCheckpoint(),
+		checkpointPath: defaultCheckpointPath,
 	}
 }
 
 // NewManagerWithCache creates a manager with custom cache path
 func NewManagerWithCache(runner site.CommandRunner, repoDir, cachePath string) *Manager {
 	return &Manager{
-		detector:  NewChangeDetector(runner, repoDir),
-		cache:     NewCache(),
-		cachePath: cachePath,
+		detector:       NewChangeDetector(runner, repoDir),
+		cache:          NewCache(),
+		cachePath:      cachePath,
+		checkpoint:     NewCheckpoint(),
+		checkpointPath: filepath.Join(filepath.Dir(cachePath), filepath.Base(defaultCheckpointPath)),
 	}
 }
 
@@ -45,6 +52,37 @@ func (m *Manager) SaveCache() error {
 	return m.cache.Save(m.cachePath)
 }
 
+// WithCheckpointPath sets where the per-run checkpoint is stored
+func (m *Manager) WithCheckpointPath(path string) *Manager {
+	m.checkpointPath = path
+	return m
+}
+
+// LoadCheckpoint loads the checkpoint of an interrupted run from disk
+func (m *Manager) LoadCheckpoint() error {
+	m.checkpoint = NewCheckpoint()
+	return m.checkpoint.Load(m.checkpointPath)
+}
+
+// SaveCheckpoint persists the current run's checkpoint to disk
+func (m *Manager) SaveCheckpoint() error {
+	return m.checkpoint.Save(m.checkpointPath)
+}
+
+// ClearCheckpoint discards the checkpoint, starting a fresh run
+func (m *Manager) ClearCheckpoint() error {
+	m.checkpoint = NewCheckpoint()
+	if err := os.Remove(m.checkpointPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove checkpoint file: %w", err)
+	}
+	return nil
+}
+
+// GetCheckpoint returns the current run's checkpoint
+func (m *Manager) GetCheckpoint() *Checkpoint {
+	return m.checkpoint
+}
+
 // GetChangedFiles returns files that changed since last documentation build
 func (m *Manager) GetChangedFiles(ctx context.Context) ([]string, error) {
 	// Check if we're in a git repository
@@ -1,6 +1,7 @@
 package normalizer
 
 import (
+	"bytes"
 	"fmt"
 	"path/filepath"
 	"regexp"
@@ -9,7 +10,8 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// FrontMatter represents Jekyll/Hugo YAML front matter
+// FrontMatter represents Jekyll/Hugo YAML front matter.
+// ToYAML emits fields in declaration order, followed by Extras sorted by key.
 type FrontMatter struct {
 	Title       string `yaml:"title,omitempty"`
 	Layout      string `yaml:"layout,omitempty"`
@@ -18,6 +20,10 @@ type FrontMatter struct {
 	NavOrder    int    `yaml:"nav_order,omitempty"`
 	HasChildren bool   `yaml:"has_children,omitempty"`
 	Permalink   string `yaml:"permalink,omitempty"`
+
+	// Extras holds keys the struct does not model (e.g. nav_exclude,
+	// search_exclude) so they survive parse, merge and emit
+	Extras map[string]interface{} `yaml:",inline"`
 }
 
 // FrontMatterOptions provides context for generating front matter
@@ -50,6 +56,13 @@ func GenerateFrontMatter(opts FrontMatterOptions) *FrontMatter {
 	// Set parent based on section
 	if opts.Section != "" {
 		fm.Parent = sectionToParent(opts.Section)
+
+		// just-the-docs nests three levels deep: pages two or more directories
+		// into a section are grandchildren under the section's subdirectory
+		if childIndex := sectionChildIndex(opts.FilePath, opts.Section); childIndex != "" {
+			fm.GrandParent = fm.Parent
+			fm.Parent = generateTitle(childIndex, detectLanguage(childIndex))
+		}
 	}
 
 	// Generate permalink
@@ -80,18 +93,26 @@ func MergeFrontMatter(existing, new *FrontMatter) *FrontMatter {
 
 	merged.HasChildren = existing.HasChildren || new.HasChildren
 
+	merged.Extras = mergeExtras(existing.Extras, new.Extras)
+
 	return merged
 }
 
-// ToYAML converts front matter to YAML string with delimiters
+// ToYAML converts front matter to YAML string with delimiters.
+// Output is deterministic: the same front matter always yields the same bytes.
 func (fm *FrontMatter) ToYAML() (string, error) {
-	data, err := yaml.Marshal(fm)
-	if err != nil {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2) // Match the two-space style of hand-written front matter
+	if err := enc.Encode(fm); err != nil {
+		return "", fmt.Errorf("failed to marshal front matter: %w", err)
+	}
+	if err := enc.Close(); err != nil {
 		return "", fmt.Errorf("failed to marshal front matter: %w", err)
 	}
 
 	// Add YAML delimiters
-	return fmt.Sprintf("---\n%s---\n\n", string(data)), nil
+	return fmt.Sprintf("---\n%s---\n\n", buf.String()), nil
 }
 
 // ParseFrontMatter extracts front matter from markdown content
@@ -173,6 +194,25 @@ func sectionToParent(section string) string {
 	}
 }
 
+// sectionChildIndex returns the index page of the first directory below
+// section (e.g. "_api/go/index.md" for "_api/go/pkg/sub.md"), or "" when
+// filePath is fewer than two directories into the section
+func sectionChildIndex(filePath, section string) string {
+	dirs := strings.Split(filepath.ToSlash(filepath.Dir(filePath)), "/")
+
+	for i, dir := range dirs {
+		if dir != section {
+			continue
+		}
+		if len(dirs)-i-1 < 2 {
+			return ""
+		}
+		return strings.Join(dirs[:i+2], "/") + "/index.md"
+	}
+
+	return ""
+}
+
 // generatePermalink creates a permalink from file path
 func generatePermalink(filePath, section string) string {
 	// Remove extension and normalize
@@ -205,6 +245,22 @@ func generatePermalink(filePath, section string) string {
 	return path
 }
 
+// mergeExtras combines extra keys, preferring existing values
+func mergeExtras(existing, new map[string]interface{}) map[string]interface{} {
+	if len(existing) == 0 && len(new) == 0 {
+		return nil
+	}
+
+	merged := make(map[string]interface{}, len(existing)+len(new))
+	for k, v := range new {
+		merged[k] = v
+	}
+	for k, v := range existing {
+		merged[k] = v
+	}
+	return merged
+}
+
 // preferExisting returns existing value if non-empty, otherwise returns new value
 func preferExisting(existing, new string) string {
 	if existing != "" {
@@ -20,18 +20,36 @@ func NewNormalizer(docsRoot string) *Normalizer {
 	}
 }
 
-// NormalizeFile adds or updates front matter in a single markdown file
+// NormalizeStats counts the outcome of normalizing a directory
+type NormalizeStats struct {
+	Changed   int // Files rewritten with new front matter
+	Unchanged int // Files already normalized, left untouched
+}
+
+// Processed returns the number of files normalized without error
+func (s NormalizeStats) Processed() int {
+	return s.Changed + s.Unchanged
+}
+
+// NormalizeFile adds or updates front matter in a single markdown file.
+// Files that are already normalized are not rewritten.
 func (n *Normalizer) NormalizeFile(filePath string) error {
+	_, err := n.normalizeFile(filePath)
+	return err
+}
+
+// normalizeFile normalizes filePath, reporting whether its content changed
+func (n *Normalizer) normalizeFile(filePath string) (bool, error) {
 	// Read file content
 	content, err := os.ReadFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return false, fmt.Errorf("failed to read file: %w", err)
 	}
 
 	// Parse existing front matter
 	existingFM, bodyContent, err := ParseFrontMatter(string(content))
 	if err != nil {
-		return fmt.Errorf("failed to parse front matter: %w", err)
+		return false, fmt.Errorf("failed to parse front matter: %w", err)
 	}
 
 	// Generate new front matter based on file context
@@ -49,23 +67,36 @@ func (n *Normalizer) NormalizeFile(filePath string) error {
 	// Convert to YAML
 	fmYAML, err := finalFM.ToYAML()
 	if err != nil {
-		return fmt.Errorf("failed to generate YAML: %w", err)
+		return false, fmt.Errorf("failed to generate YAML: %w", err)
 	}
 
 	// Combine front matter and body
 	normalized := fmYAML + bodyContent
 
+	// Leave already-normalized files alone so their mtimes stay stable
+	if normalized == string(content) {
+		return false, nil
+	}
+
 	// Write back to file
 	if err := os.WriteFile(filePath, []byte(normalized), 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+		return false, fmt.Errorf("failed to write file: %w", err)
 	}
 
-	return nil
+	return true, nil
 }
 
-// NormalizeDir recursively processes all markdown files in a directory
+// NormalizeDir recursively processes all markdown files in a directory,
+// returning how many were normalized
 func (n *Normalizer) NormalizeDir(dirPath string) (int, []error) {
-	var processed int
+	stats, errors := n.NormalizeDirStats(dirPath)
+	return stats.Processed(), errors
+}
+
+// NormalizeDirStats recursively processes all markdown files in a directory,
+// counting rewritten and already-normalized files separately
+func (n *Normalizer) NormalizeDirStats(dirPath string) (NormalizeStats, []error) {
+	var stats NormalizeStats
 	var errors []error
 
 	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
@@ -85,10 +116,14 @@ func (n *Normalizer) NormalizeDir(dirPath string) (int, []error) {
 		}
 
 		// Normalize the file
-		if err := n.NormalizeFile(path); err != nil {
+		changed, err := n.normalizeFile(path)
+		switch {
+		case err != nil:
 			errors = append(errors, fmt.Errorf("failed to normalize %s: %w", path, err))
-		} else {
-			processed++
+		case changed:
+			stats.Changed++
+		default:
+			stats.Unchanged++
 		}
 
 		return nil
@@ -98,7 +133,7 @@ func (n *Normalizer) NormalizeDir(dirPath string) (int, []error) {
 		errors = append(errors, fmt.Errorf("directory walk failed: %w", err))
 	}
 
-	return processed, errors
+	return stats, errors
 }
 
 // buildOptions creates FrontMatterOptions from file path
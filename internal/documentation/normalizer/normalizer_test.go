@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGenerateFrontMatter(t *testing.T) {
@@ -429,3 +430,254 @@ func TestSectionToParent(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizer_NormalizeDirStats_SkipsUnchanged(t *testing.T) {
+	tmpDir := t.TempDir()
+	normalizer := NewNormalizer(tmpDir)
+
+	normalizedPath := filepath.Join(tmpDir, "done.md")
+	rawPath := filepath.Join(tmpDir, "raw.md")
+
+	// Produce an already-normalized file by normalizing it once
+	if err := os.WriteFile(normalizedPath, []byte("# Done\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := normalizer.NormalizeFile(normalizedPath); err != nil {
+		t.Fatalf("NormalizeFile failed: %v", err)
+	}
+	before, _ := os.ReadFile(normalizedPath)
+
+	// Backdate it so a rewrite would be visible in the mtime
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(normalizedPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(rawPath, []byte("# Raw\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, errs := normalizer.NormalizeDirStats(tmpDir)
+	if len(errs) > 0 {
+		t.Fatalf("NormalizeDirStats returned errors: %v", errs)
+	}
+
+	if stats.Changed != 1 || stats.Unchanged != 1 {
+		t.Errorf("stats = %+v, want 1 changed and 1 unchanged", stats)
+	}
+	if stats.Processed() != 2 {
+		t.Errorf("Processed() = %d, want 2", stats.Processed())
+	}
+
+	info, err := os.Stat(normalizedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Errorf("already-normalized file was rewritten: mtime %v, want %v", info.ModTime(), old)
+	}
+	after, _ := os.ReadFile(normalizedPath)
+	if string(after) != string(before) {
+		t.Error("already-normalized file content changed")
+	}
+
+	raw, _ := os.ReadFile(rawPath)
+	if !strings.HasPrefix(string(raw), "---\n") {
+		t.Error("raw file should have been normalized")
+	}
+
+	// A second pass is a no-op
+	stats, _ = normalizer.NormalizeDirStats(tmpDir)
+	if stats.Changed != 0 || stats.Unchanged != 2 {
+		t.Errorf("second pass stats = %+v, want 0 changed and 2 unchanged", stats)
+	}
+}
+
+func TestFrontMatter_ExtrasRoundTrip(t *testing.T) {
+	content := `---
+title: Internal Notes
+nav_exclude: true
+search_exclude: true
+team:
+  owner: docs
+---
+
+# Notes
+`
+
+	fm, body, err := ParseFrontMatter(content)
+	if err != nil {
+		t.Fatalf("ParseFrontMatter failed: %v", err)
+	}
+
+	if fm.Extras["nav_exclude"] != true {
+		t.Errorf("nav_exclude = %v, want true", fm.Extras["nav_exclude"])
+	}
+	if _, ok := fm.Extras["title"]; ok {
+		t.Error("modeled keys should not be duplicated in Extras")
+	}
+
+	merged := MergeFrontMatter(fm, GenerateFrontMatter(FrontMatterOptions{FilePath: "notes.md"}))
+	yamlStr, err := merged.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+
+	for _, want := range []string{"title: Internal Notes", "nav_exclude: true", "search_exclude: true", "owner: docs"} {
+		if !strings.Contains(yamlStr, want) {
+			t.Errorf("emitted front matter should contain %q\nGot:\n%s", want, yamlStr)
+		}
+	}
+
+	reparsed, _, err := ParseFrontMatter(yamlStr + body)
+	if err != nil {
+		t.Fatalf("re-parse failed: %v", err)
+	}
+	if reparsed.Extras["nav_exclude"] != true || reparsed.Extras["search_exclude"] != true {
+		t.Errorf("extras lost on round trip: %+v", reparsed.Extras)
+	}
+}
+
+func TestMergeFrontMatter_ExtrasPreferExisting(t *testing.T) {
+	existing := &FrontMatter{Extras: map[string]interface{}{"nav_exclude": false}}
+	generated := &FrontMatter{Extras: map[string]interface{}{"nav_exclude": true, "lang": "en"}}
+
+	merged := MergeFrontMatter(existing, generated)
+
+	if merged.Extras["nav_exclude"] != false {
+		t.Errorf("nav_exclude = %v, want existing value false", merged.Extras["nav_exclude"])
+	}
+	if merged.Extras["lang"] != "en" {
+		t.Errorf("lang = %v, want en from new front matter", merged.Extras["lang"])
+	}
+
+	if got := MergeFrontMatter(&FrontMatter{}, &FrontMatter{}).Extras; got != nil {
+		t.Errorf("Extras = %v, want nil when neither side has extras", got)
+	}
+}
+
+func TestNormalizer_NormalizeFile_PreservesCustomKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "page.md")
+	os.WriteFile(path, []byte("---\ntitle: Page\nnav_exclude: true\n---\n\n# Page\n"), 0644)
+
+	if err := NewNormalizer(tmpDir).NormalizeFile(path); err != nil {
+		t.Fatalf("NormalizeFile failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), "nav_exclude: true") {
+		t.Errorf("nav_exclude should be preserved\nGot:\n%s", content)
+	}
+}
+
+func TestFrontMatter_ToYAML_StableOrder(t *testing.T) {
+	fm := &FrontMatter{
+		Permalink:   "/api/go/",
+		HasChildren: true,
+		NavOrder:    2,
+		GrandParent: "Reference",
+		Parent:      "API Reference",
+		Layout:      "default",
+		Title:       "Go API",
+		Extras: map[string]interface{}{
+			"search_exclude": false,
+			"nav_exclude":    true,
+			"custom":         map[string]interface{}{"team": "docs"},
+		},
+	}
+
+	first, err := fm.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		again, err := fm.ToYAML()
+		if err != nil {
+			t.Fatalf("ToYAML failed: %v", err)
+		}
+		if again != first {
+			t.Fatalf("emission %d differs:\n%s\nvs\n%s", i, again, first)
+		}
+	}
+
+	want := `---
+title: Go API
+layout: default
+parent: API Reference
+grand_parent: Reference
+nav_order: 2
+has_children: true
+permalink: /api/go/
+custom:
+  team: docs
+nav_exclude: true
+search_exclude: false
+---
+
+`
+	if first != want {
+		t.Errorf("unexpected front matter layout:\n%s\nwant:\n%s", first, want)
+	}
+}
+
+func TestGenerateFrontMatter_GrandParent(t *testing.T) {
+	tests := []struct {
+		path            string
+		wantParent      string
+		wantGrandParent string
+	}{
+		{"_api/example.md", "API Reference", ""},
+		{"_api/go/sub.md", "API Reference", ""},
+		{"_api/go/pkg/sub.md", "Go", "API Reference"},
+		{"_api/go/pkg/deeper/sub.md", "Go", "API Reference"},
+		{"_api/go/pkg/index.md", "Go", "API Reference"},
+		{"_stack/tools/linters/eslint.md", "Tools", "Technology Stack"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			fm := GenerateFrontMatter(FrontMatterOptions{
+				FilePath: tt.path,
+				Section:  detectSection(tt.path),
+				Language: detectLanguage(tt.path),
+				IsIndex:  filepath.Base(tt.path) == "index.md",
+			})
+
+			if fm.Parent != tt.wantParent {
+				t.Errorf("Parent = %q, want %q", fm.Parent, tt.wantParent)
+			}
+			if fm.GrandParent != tt.wantGrandParent {
+				t.Errorf("GrandParent = %q, want %q", fm.GrandParent, tt.wantGrandParent)
+			}
+		})
+	}
+}
+
+func TestNormalizer_NormalizeDir_GrandchildMatchesParentTitle(t *testing.T) {
+	tmpDir := t.TempDir()
+	for path, content := range map[string]string{
+		"_api/go/index.md":   "# Go",
+		"_api/go/pkg/sub.md": "# Sub",
+	} {
+		fullPath := filepath.Join(tmpDir, path)
+		os.MkdirAll(filepath.Dir(fullPath), 0755)
+		os.WriteFile(fullPath, []byte(content), 0644)
+	}
+
+	if _, errs := NewNormalizer(tmpDir).NormalizeDir(tmpDir); len(errs) > 0 {
+		t.Fatalf("NormalizeDir returned errors: %v", errs)
+	}
+
+	parentContent, _ := os.ReadFile(filepath.Join(tmpDir, "_api/go/index.md"))
+	parentFM, _, _ := ParseFrontMatter(string(parentContent))
+	childContent, _ := os.ReadFile(filepath.Join(tmpDir, "_api/go/pkg/sub.md"))
+	childFM, _, _ := ParseFrontMatter(string(childContent))
+
+	if childFM.Parent != parentFM.Title {
+		t.Errorf("grandchild parent %q should match the section child's title %q", childFM.Parent, parentFM.Title)
+	}
+	if childFM.GrandParent != parentFM.Parent {
+		t.Errorf("grandchild grand_parent %q should match the section child's parent %q", childFM.GrandParent, parentFM.Parent)
+	}
+}
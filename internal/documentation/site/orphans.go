@@ -0,0 +1,198 @@
+package site
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/normalizer"
+)
+
+// StandaloneKey is the front matter flag marking a page as intentionally
+// unlinked, so FindOrphans does not report it
+const StandaloneKey = "standalone"
+
+// inlineLinkPattern matches the target of a markdown [text](target) link
+var inlineLinkPattern = regexp.MustCompile(`\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+
+// sitePage is a markdown file and the links leaving it
+type sitePage struct {
+	path        string
+	title       string
+	permalink   string
+	parent      string
+	grandParent string
+	navExclude  bool
+	standalone  bool
+	links       []string
+}
+
+// FindOrphans returns the markdown pages under docsDir, relative to it, that
+// cannot be reached from the root index.md by following navigation (parent
+// and grand_parent front matter, plus titled top-level pages) and inline
+// links. Pages whose front matter sets standalone: true are never reported.
+// Unreadable files are skipped; the result is sorted.
+func FindOrphans(docsDir string) []string {
+	pages := loadSitePages(docsDir)
+
+	byPath := make(map[string]*sitePage, len(pages))
+	for _, p := range pages {
+		byPath[p.path] = p
+	}
+
+	root, ok := byPath["index.md"]
+	if !ok {
+		root = byPath["index.markdown"]
+	}
+
+	reached := make(map[string]bool)
+	if root != nil {
+		reached[root.path] = true
+		queue := []*sitePage{root}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+
+			for _, next := range linkedPages(current, root, pages, byPath) {
+				if !reached[next.path] {
+					reached[next.path] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+
+	var orphans []string
+	for _, p := range pages {
+		if !reached[p.path] && !p.standalone {
+			orphans = append(orphans, p.path)
+		}
+	}
+	return orphans
+}
+
+// linkedPages returns the pages reachable in one step from p: its nav
+// children, the top-level nav entries when p is the root, and inline links.
+// As in just-the-docs, only titled pages appear in the navigation.
+func linkedPages(p, root *sitePage, pages []*sitePage, byPath map[string]*sitePage) []*sitePage {
+	var linked []*sitePage
+
+	for _, other := range pages {
+		if other == p || other.navExclude {
+			continue
+		}
+		if p == root && other.parent == "" && other.title != "" {
+			linked = append(linked, other)
+			continue
+		}
+		if other.parent != "" && other.parent == p.title &&
+			(other.grandParent == "" || other.grandParent == p.parent) {
+			linked = append(linked, other)
+		}
+	}
+
+	for _, target := range p.links {
+		if next := resolveLink(p.path, target, pages, byPath); next != nil {
+			linked = append(linked, next)
+		}
+	}
+
+	return linked
+}
+
+// resolveLink maps an inline link target to a page by permalink or by file
+// path, returning nil for external links and targets outside the docs
+func resolveLink(from, target string, pages []*sitePage, byPath map[string]*sitePage) *sitePage {
+	if strings.Contains(target, "://") || strings.HasPrefix(target, "mailto:") {
+		return nil
+	}
+	if i := strings.IndexAny(target, "#?"); i >= 0 {
+		target = target[:i]
+	}
+	if target == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(target, "/") {
+		permalink := strings.TrimSuffix(target, "/")
+		for _, p := range pages {
+			if p.permalink != "" && strings.TrimSuffix(p.permalink, "/") == permalink {
+				return p
+			}
+		}
+		target = strings.TrimPrefix(target, "/")
+	} else {
+		target = path.Join(path.Dir(from), target)
+	}
+
+	target = strings.TrimSuffix(path.Clean(target), "/")
+	base := strings.TrimSuffix(target, ".html")
+	for _, candidate := range []string{target, base + ".md", base + ".markdown", target + "/index.md"} {
+		if p, ok := byPath[candidate]; ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// loadSitePages reads every markdown file under docsDir, sorted by path
+func loadSitePages(docsDir string) []*sitePage {
+	var pages []*sitePage
+
+	filepath.WalkDir(docsDir, func(file string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if file != docsDir && (name == "_site" || name == "node_modules" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ext := strings.ToLower(filepath.Ext(file))
+		if ext != ".md" && ext != ".markdown" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(docsDir, file)
+		if err != nil {
+			return nil
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil
+		}
+
+		fm, body, err := normalizer.ParseFrontMatter(string(data))
+		if err != nil {
+			return nil
+		}
+
+		p := &sitePage{path: filepath.ToSlash(rel)}
+		if fm != nil {
+			p.title = fm.Title
+			p.permalink = fm.Permalink
+			p.parent = fm.Parent
+			p.grandParent = fm.GrandParent
+			p.navExclude = fm.Extras["nav_exclude"] == true
+			p.standalone = fm.Extras[StandaloneKey] == true
+		}
+
+		for _, m := range inlineLinkPattern.FindAllStringSubmatch(body, -1) {
+			p.links = append(p.links, m[1])
+		}
+
+		pages = append(pages, p)
+		return nil
+	})
+
+	sort.Slice(pages, func(i, j int) bool { return pages[i].path < pages[j].path })
+	return pages
+}
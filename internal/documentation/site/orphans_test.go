@@ -0,0 +1,72 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeSite(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func TestFindOrphansReportsOnlyUnlinkedPages(t *testing.T) {
+	docs := writeSite(t, map[string]string{
+		"index.md":         "---\ntitle: Home\n---\n\n# Home\n\nSee the [guide](guides/setup.md).\n",
+		"guides/setup.md":  "# Setup\n\nContinue with [usage](/guides/usage/#first-run).\n",
+		"guides/usage.md":  "---\npermalink: /guides/usage/\n---\n\n# Usage\n",
+		"guides/forgot.md": "# Nobody links here\n",
+		"_site/index.md":   "# Built output is ignored\n",
+	})
+
+	got := FindOrphans(docs)
+	want := []string{"guides/forgot.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindOrphans() = %v, want %v", got, want)
+	}
+}
+
+func TestFindOrphansFollowsNavigation(t *testing.T) {
+	docs := writeSite(t, map[string]string{
+		"index.md":           "---\ntitle: Home\n---\n\n# Home\n",
+		"_api/index.md":      "---\ntitle: API Reference\nhas_children: true\n---\n",
+		"_api/go/index.md":   "---\ntitle: Go\nparent: API Reference\nhas_children: true\n---\n",
+		"_api/go/pkg/sub.md": "---\ntitle: Sub\nparent: Go\ngrand_parent: API Reference\n---\n",
+		"_api/lost.md":       "---\ntitle: Lost\nparent: Missing Section\n---\n",
+		"_api/hidden.md":     "---\ntitle: Hidden\nnav_exclude: true\n---\n",
+		"_api/404.md":        "---\ntitle: Not Found\nnav_exclude: true\nstandalone: true\n---\n",
+	})
+
+	got := FindOrphans(docs)
+	want := []string{"_api/hidden.md", "_api/lost.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindOrphans() = %v, want %v", got, want)
+	}
+}
+
+func TestFindOrphansWithoutIndex(t *testing.T) {
+	docs := writeSite(t, map[string]string{
+		"a.md": "---\ntitle: A\n---\n",
+		"b.md": "---\ntitle: B\nstandalone: true\n---\n",
+	})
+
+	got := FindOrphans(docs)
+	want := []string{"a.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindOrphans() = %v, want %v", got, want)
+	}
+}
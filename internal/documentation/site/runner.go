@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -72,9 +73,32 @@ func (r *DefaultRunner) Run(ctx context.Context, cmd string, args []string, work
 
 // MockRunner is a mock command runner for testing
 type MockRunner struct {
-	outputs map[string]string
-	errors  map[string]error
-	calls   []MockCall
+	outputs   map[string]string
+	sequences map[string]*outputSequence
+	patterns  []outputPattern
+	errors    map[string]error
+	calls     []MockCall
+	delay     time.Duration
+}
+
+// outputSequence returns its outputs in order, repeating the last one
+type outputSequence struct {
+	outputs []string
+	next    int
+}
+
+func (s *outputSequence) pop() string {
+	output := s.outputs[s.next]
+	if s.next < len(s.outputs)-1 {
+		s.next++
+	}
+	return output
+}
+
+// outputPattern matches a full command line against a regular expression
+type outputPattern struct {
+	re     *regexp.Regexp
+	output string
 }
 
 // MockCall records a command call
@@ -88,9 +112,10 @@ type MockCall struct {
 // NewMockRunner creates a new mock runner
 func NewMockRunner() *MockRunner {
 	return &MockRunner{
-		outputs: make(map[string]string),
-		errors:  make(map[string]error),
-		calls:   []MockCall{},
+		outputs:   make(map[string]string),
+		sequences: make(map[string]*outputSequence),
+		errors:    make(map[string]error),
+		calls:     []MockCall{},
 	}
 }
 
@@ -100,12 +125,40 @@ func (m *MockRunner) WithOutput(cmd string, output string) *MockRunner {
 	return m
 }
 
+// WithOutputSequence returns outputs in order on successive calls to cmd.
+// Once exhausted, the last output is returned for every further call.
+func (m *MockRunner) WithOutputSequence(cmd string, outputs ...string) *MockRunner {
+	if len(outputs) == 0 {
+		delete(m.sequences, cmd)
+		return m
+	}
+	m.sequences[cmd] = &outputSequence{outputs: outputs}
+	return m
+}
+
+// WithOutputMatching sets the output for any command line matching pattern.
+// Patterns are tried in the order added, after exact and short-key outputs.
+// It panics if pattern is not a valid regular expression.
+func (m *MockRunner) WithOutputMatching(pattern string, output string) *MockRunner {
+	m.patterns = append(m.patterns, outputPattern{
+		re:     regexp.MustCompile(pattern),
+		output: output,
+	})
+	return m
+}
+
 // WithError sets an error for a specific command
 func (m *MockRunner) WithError(cmd string, err error) *MockRunner {
 	m.errors[cmd] = err
 	return m
 }
 
+// WithDelay makes every command take d to complete, honoring context cancellation
+func (m *MockRunner) WithDelay(d time.Duration) *MockRunner {
+	m.delay = d
+	return m
+}
+
 // Run executes a mock command
 func (m *MockRunner) Run(ctx context.Context, cmd string, args []string, workdir string, env map[string]string) (string, error) {
 	// Record call
@@ -116,6 +169,14 @@ func (m *MockRunner) Run(ctx context.Context, cmd string, args []string, workdir
 		Env:     env,
 	})
 
+	if m.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(m.delay):
+		}
+	}
+
 	// Build key for lookup
 	key := cmd
 	if len(args) > 0 {
@@ -141,14 +202,10 @@ func (m *MockRunner) Run(ctx context.Context, cmd string, args []string, workdir
 	}
 
 	// Return output
-	if output, ok := m.outputs[key]; ok {
-		return output, nil
-	}
-	if output, ok := m.outputs[shortKey]; ok {
-		return output, nil
-	}
-	if output, ok := m.outputs[cmd]; ok {
-		return output, nil
+	for _, k := range []string{key, shortKey, cmd} {
+		if output, ok := m.lookupOutput(k); ok {
+			return output, nil
+		}
 	}
 
 	for storedKey, err := range m.errors {
@@ -157,6 +214,12 @@ func (m *MockRunner) Run(ctx context.Context, cmd string, args []string, workdir
 		}
 	}
 
+	for _, p := range m.patterns {
+		if p.re.MatchString(key) {
+			return p.output, nil
+		}
+	}
+
 	for storedKey, output := range m.outputs {
 		if strings.HasPrefix(key, storedKey) {
 			return output, nil
@@ -167,12 +230,83 @@ func (m *MockRunner) Run(ctx context.Context, cmd string, args []string, workdir
 	return "", nil
 }
 
+// lookupOutput returns the output registered under key, preferring a sequence
+func (m *MockRunner) lookupOutput(key string) (string, bool) {
+	if seq, ok := m.sequences[key]; ok {
+		return seq.pop(), true
+	}
+	output, ok := m.outputs[key]
+	return output, ok
+}
+
 // GetCalls returns recorded calls
 func (m *MockRunner) GetCalls() []MockCall {
 	return m.calls
 }
 
-// Reset clears recorded calls
+// TestingT is the subset of testing.TB used by MockRunner assertions
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// CallCount returns how many times cmd was run
+func (m *MockRunner) CallCount(cmd string) int {
+	count := 0
+	for _, call := range m.calls {
+		if call.Cmd == cmd {
+			count++
+		}
+	}
+	return count
+}
+
+// AssertCalled reports a test error unless cmd was run with arguments
+// containing every argMatcher as a substring (each may match any argument).
+// It returns whether a matching call was found.
+func (m *MockRunner) AssertCalled(t TestingT, cmd string, argMatchers ...string) bool {
+	t.Helper()
+
+	for _, call := range m.calls {
+		if call.Cmd == cmd && argsMatch(call.Args, argMatchers) {
+			return true
+		}
+	}
+
+	recorded := make([]string, len(m.calls))
+	for i, call := range m.calls {
+		recorded[i] = strings.TrimSpace(call.Cmd + " " + strings.Join(call.Args, " "))
+	}
+	if len(recorded) == 0 {
+		recorded = []string{"(none)"}
+	}
+
+	t.Errorf("expected %q to be called with args matching %q\nrecorded calls:\n  %s",
+		cmd, argMatchers, strings.Join(recorded, "\n  "))
+	return false
+}
+
+// argsMatch reports whether every matcher is a substring of some argument
+func argsMatch(args []string, matchers []string) bool {
+	for _, matcher := range matchers {
+		found := false
+		for _, arg := range args {
+			if strings.Contains(arg, matcher) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Reset clears recorded calls and rewinds output sequences
 func (m *MockRunner) Reset() {
 	m.calls = []MockCall{}
+	for _, seq := range m.sequences {
+		seq.next = 0
+	}
 }
@@ -3,6 +3,7 @@ package site
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -114,3 +115,134 @@ func TestMockRunnerDefaultSuccess(t *testing.T) {
 		t.Errorf("Expected empty output, got: %v", output)
 	}
 }
+
+// fakeT records assertion failures instead of failing the test
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestMockRunnerCallCount(t *testing.T) {
+	mock := NewMockRunner()
+	ctx := context.Background()
+
+	mock.Run(ctx, "git", []string{"status"}, ".", nil)
+	mock.Run(ctx, "git", []string{"diff"}, ".", nil)
+	mock.Run(ctx, "hugo", nil, ".", nil)
+
+	if got := mock.CallCount("git"); got != 2 {
+		t.Errorf("CallCount(git) = %d, want 2", got)
+	}
+	if got := mock.CallCount("hugo"); got != 1 {
+		t.Errorf("CallCount(hugo) = %d, want 1", got)
+	}
+	if got := mock.CallCount("npx"); got != 0 {
+		t.Errorf("CallCount(npx) = %d, want 0", got)
+	}
+}
+
+func TestMockRunnerAssertCalled(t *testing.T) {
+	mock := NewMockRunner()
+	mock.Run(context.Background(), "gomarkdoc", []string{"--output", "/tmp/docs/{{.Dir}}.md", "./..."}, ".", nil)
+
+	mock.AssertCalled(t, "gomarkdoc")
+	mock.AssertCalled(t, "gomarkdoc", "--output", "/tmp/docs")
+	mock.AssertCalled(t, "gomarkdoc", "./...")
+}
+
+func TestMockRunnerAssertCalled_NoMatch(t *testing.T) {
+	mock := NewMockRunner()
+	mock.Run(context.Background(), "gomarkdoc", []string{"--output", "docs"}, ".", nil)
+
+	tests := []struct {
+		name     string
+		cmd      string
+		matchers []string
+	}{
+		{"command not run", "typedoc", nil},
+		{"argument missing", "gomarkdoc", []string{"--verbose"}},
+		{"one of several missing", "gomarkdoc", []string{"--output", "--verbose"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := &fakeT{}
+			if mock.AssertCalled(ft, tt.cmd, tt.matchers...) {
+				t.Error("AssertCalled should return false")
+			}
+			if len(ft.errors) != 1 {
+				t.Fatalf("expected 1 reported error, got %d", len(ft.errors))
+			}
+			if !strings.Contains(ft.errors[0], "gomarkdoc --output docs") {
+				t.Errorf("failure message should list recorded calls, got: %s", ft.errors[0])
+			}
+		})
+	}
+}
+
+func TestMockRunnerWithOutputMatching(t *testing.T) {
+	mock := NewMockRunner().
+		WithOutputMatching(`^gomarkdoc --output \S+/docs/`, "generated").
+		WithOutputMatching(`^hugo`, "built")
+	ctx := context.Background()
+
+	for _, dir := range []string{"/tmp/run-1", "/tmp/run-2"} {
+		output, err := mock.Run(ctx, "gomarkdoc", []string{"--output", dir + "/docs/{{.Dir}}.md", "./..."}, ".", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output != "generated" {
+			t.Errorf("output for %s = %q, want %q", dir, output, "generated")
+		}
+	}
+
+	if output, _ := mock.Run(ctx, "hugo", []string{"--minify"}, ".", nil); output != "built" {
+		t.Errorf("hugo output = %q, want %q", output, "built")
+	}
+
+	if output, _ := mock.Run(ctx, "typedoc", nil, ".", nil); output != "" {
+		t.Errorf("unmatched command output = %q, want empty", output)
+	}
+}
+
+func TestMockRunnerExactMatchBeatsPattern(t *testing.T) {
+	mock := NewMockRunner().
+		WithOutputMatching(`^git`, "pattern").
+		WithOutput("git status", "exact")
+
+	output, _ := mock.Run(context.Background(), "git", []string{"status"}, ".", nil)
+	if output != "exact" {
+		t.Errorf("output = %q, want exact match to take priority", output)
+	}
+
+	output, _ = mock.Run(context.Background(), "git", []string{"log"}, ".", nil)
+	if output != "pattern" {
+		t.Errorf("output = %q, want %q", output, "pattern")
+	}
+}
+
+func TestMockRunnerWithOutputSequence(t *testing.T) {
+	mock := NewMockRunner().WithOutputSequence("git rev-parse HEAD", "abc123", "def456")
+	ctx := context.Background()
+
+	want := []string{"abc123", "def456", "def456"}
+	for i, w := range want {
+		output, err := mock.Run(ctx, "git", []string{"rev-parse", "HEAD"}, ".", nil)
+		if err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+		if output != w {
+			t.Errorf("call %d: output = %q, want %q", i, output, w)
+		}
+	}
+
+	mock.Reset()
+	if output, _ := mock.Run(ctx, "git", []string{"rev-parse", "HEAD"}, ".", nil); output != "abc123" {
+		t.Errorf("after Reset: output = %q, want sequence to restart", output)
+	}
+}
@@ -2,10 +2,13 @@ package welcome
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/Mpaape/AurumCode/internal/llm"
 )
@@ -44,38 +47,49 @@ type GenerateOptions struct {
 	ProjectDir string // Project root directory for resolving paths
 	Title      string // Optional custom title override
 	NavOrder   int    // Navigation order in site
+
+	// Timeout bounds each LLM attempt; 0 uses the orchestrator default
+	Timeout time.Duration
+
+	// Retries is the number of extra LLM attempts after a failure
+	Retries int
+
+	// RetryDelay is the pause between attempts
+	RetryDelay time.Duration
+
+	// Languages lists language codes to generate the page in. The first is
+	// written to OutputPath; each other language is written next to it as
+	// index.<lang>.md. Empty generates a single page without a language.
+	Languages []string
 }
 
 // Generate creates a welcome page from README content using LLM
 func (g *Generator) Generate(ctx context.Context, opts GenerateOptions) (string, error) {
-	// Read README content
-	readmeContent, err := g.readREADME(opts.ReadmePath, opts.ProjectDir)
+	readmeContent, prompt, err := g.buildPrompt(opts)
 	if err != nil {
-		return "", fmt.Errorf("failed to read README: %w", err)
-	}
-
-	// Load prompt template
-	promptTemplate, err := g.loadPromptTemplate(opts.ProjectDir)
-	if err != nil {
-		return "", fmt.Errorf("failed to load prompt template: %w", err)
+		return "", err
 	}
 
-	// Build prompt with README content
-	prompt := strings.Replace(promptTemplate, promptPlaceholder, readmeContent, 1)
-
 	// Generate welcome page content using LLM
-	llmOpts := llm.DefaultOptions()
-	llmOpts.Temperature = 0.7 // More creative for documentation writing
-	llmOpts.MaxTokens = 4000
-	llmOpts.System = "You are an expert technical writer creating engaging documentation."
+	llmOpts := welcomeLLMOptions()
+
+	primary := ""
+	if len(opts.Languages) > 0 {
+		primary = opts.Languages[0]
+	}
 
-	resp, err := g.orchestrator.Complete(ctx, prompt, llmOpts)
+	text, err := g.complete(ctx, localizePrompt(prompt, primary), llmOpts, opts)
 	if err != nil {
-		return "", fmt.Errorf("LLM generation failed: %w", err)
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("LLM generation failed: %w", err)
+		}
+		// Publish the README as-is rather than leaving the site without a landing page
+		log.Printf("⚠️  Welcome page generation failed, using README instead: %v", err)
+		text = readmeContent
 	}
 
 	// Add Jekyll front matter
-	content := g.addFrontMatter(resp.Text, opts)
+	content := g.addLanguageFrontMatter(text, opts, primary, true)
 
 	// Write to output file if specified
 	if opts.OutputPath != "" {
@@ -84,9 +98,121 @@ func (g *Generator) Generate(ctx context.Context, opts GenerateOptions) (string,
 		}
 	}
 
+	// Translations are only useful when written alongside the primary page
+	if opts.OutputPath == "" || len(opts.Languages) < 2 {
+		return content, nil
+	}
+
+	for _, lang := range opts.Languages[1:] {
+		text, err := g.complete(ctx, localizePrompt(prompt, lang), llmOpts, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return "", fmt.Errorf("LLM generation failed for %s: %w", lang, err)
+			}
+			// The README is not translated, so there is nothing to fall back to
+			log.Printf("⚠️  Skipping %s welcome page: %v", lang, err)
+			continue
+		}
+
+		page := g.addLanguageFrontMatter(text, opts, lang, false)
+		if err := g.writeOutput(page, localizedPath(opts.OutputPath, lang), opts.ProjectDir); err != nil {
+			return "", fmt.Errorf("failed to write %s output: %w", lang, err)
+		}
+	}
+
 	return content, nil
 }
 
+// Estimate projects the LLM cost of Generate with the same options: one call
+// per language, or a single call when no languages are set
+func (g *Generator) Estimate(opts GenerateOptions) (llm.CostEstimate, error) {
+	if g.orchestrator == nil {
+		return llm.CostEstimate{}, nil
+	}
+
+	_, prompt, err := g.buildPrompt(opts)
+	if err != nil {
+		return llm.CostEstimate{}, err
+	}
+
+	languages := []string{""}
+	if len(opts.Languages) > 0 {
+		languages = opts.Languages[:1]
+		if opts.OutputPath != "" {
+			// Translations are only generated alongside a written page
+			languages = opts.Languages
+		}
+	}
+
+	var estimate llm.CostEstimate
+	for _, lang := range languages {
+		estimate.Add(g.orchestrator.Estimate(localizePrompt(prompt, lang), welcomeLLMOptions()))
+	}
+	return estimate, nil
+}
+
+// buildPrompt reads the README and fills it into the prompt template
+func (g *Generator) buildPrompt(opts GenerateOptions) (readme, prompt string, err error) {
+	readme, err = g.readREADME(opts.ReadmePath, opts.ProjectDir)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read README: %w", err)
+	}
+
+	promptTemplate, err := g.loadPromptTemplate(opts.ProjectDir)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to load prompt template: %w", err)
+	}
+
+	return readme, strings.Replace(promptTemplate, promptPlaceholder, readme, 1), nil
+}
+
+// welcomeLLMOptions returns the LLM options used for welcome pages
+func welcomeLLMOptions() llm.Options {
+	opts := llm.DefaultOptions()
+	opts.Temperature = 0.7 // More creative for documentation writing
+	opts.MaxTokens = 4000
+	opts.System = "You are an expert technical writer creating engaging documentation."
+	return opts
+}
+
+// complete calls the LLM, retrying failed attempts up to opts.Retries times.
+// Budget errors and cancellation of ctx are not retried.
+func (g *Generator) complete(ctx context.Context, prompt string, llmOpts llm.Options, opts GenerateOptions) (string, error) {
+	if g.orchestrator == nil {
+		return "", llm.ErrNoProviders
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= opts.Retries; attempt++ {
+		if attempt > 0 && opts.RetryDelay > 0 {
+			select {
+			case <-time.After(opts.RetryDelay):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+
+		attemptCtx := ctx
+		cancel := func() {}
+		if opts.Timeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		}
+		resp, err := g.orchestrator.Complete(attemptCtx, prompt, llmOpts)
+		cancel()
+
+		if err == nil {
+			return resp.Text, nil
+		}
+
+		lastErr = err
+		if errors.Is(err, llm.ErrBudgetExceeded) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	return "", lastErr
+}
+
 // readREADME reads and returns README.md content
 func (g *Generator) readREADME(readmePath, projectDir string) (string, error) {
 	path := readmePath
@@ -131,6 +257,12 @@ func (g *Generator) loadPromptTemplate(projectDir string) (string, error) {
 
 // addFrontMatter adds Jekyll YAML front matter to the content
 func (g *Generator) addFrontMatter(content string, opts GenerateOptions) string {
+	return g.addLanguageFrontMatter(content, opts, "", true)
+}
+
+// addLanguageFrontMatter adds front matter for a page in lang. Translations
+// are served under /<lang>/; the primary page keeps the site root.
+func (g *Generator) addLanguageFrontMatter(content string, opts GenerateOptions, lang string, primary bool) string {
 	// Build front matter
 	var fm strings.Builder
 	fm.WriteString("---\n")
@@ -149,7 +281,14 @@ func (g *Generator) addFrontMatter(content string, opts GenerateOptions) string
 	}
 
 	fm.WriteString("description: Welcome to the documentation\n")
-	fm.WriteString("permalink: /\n")
+	if lang != "" {
+		fm.WriteString(fmt.Sprintf("lang: %s\n", lang))
+	}
+	if primary {
+		fm.WriteString("permalink: /\n")
+	} else {
+		fm.WriteString(fmt.Sprintf("permalink: /%s/\n", lang))
+	}
 	fm.WriteString("---\n\n")
 
 	// Strip any existing front matter from LLM output
@@ -184,3 +323,40 @@ func (g *Generator) writeOutput(content, outputPath, projectDir string) error {
 
 	return os.WriteFile(path, []byte(content), 0644)
 }
+
+// languageNames maps common language codes to names used in prompts
+var languageNames = map[string]string{
+	"de":    "German",
+	"en":    "English",
+	"es":    "Spanish",
+	"fr":    "French",
+	"it":    "Italian",
+	"ja":    "Japanese",
+	"ko":    "Korean",
+	"pt":    "Portuguese",
+	"pt-br": "Brazilian Portuguese",
+	"ru":    "Russian",
+	"zh":    "Chinese",
+}
+
+// localizePrompt asks the LLM to write the page in lang; an empty lang
+// leaves the prompt unchanged
+func localizePrompt(prompt, lang string) string {
+	if lang == "" {
+		return prompt
+	}
+
+	name, ok := languageNames[strings.ToLower(lang)]
+	if !ok {
+		name = lang
+	}
+
+	return fmt.Sprintf("%s\n\nWrite the entire page in %s (language code %q). "+
+		"Translate prose and headings, but keep code, commands, and identifiers unchanged.", prompt, name, lang)
+}
+
+// localizedPath inserts the language code before the extension: index.md -> index.es.md
+func localizedPath(path, lang string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + lang + ext
+}
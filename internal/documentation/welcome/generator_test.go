@@ -2,10 +2,13 @@ package welcome
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Mpaape/AurumCode/internal/llm"
 )
@@ -376,3 +379,200 @@ old: value
 		})
 	}
 }
+
+// slowProvider blocks each call for delay, failing the first failures calls
+type slowProvider struct {
+	delay    time.Duration
+	failures int32
+	calls    atomic.Int32
+}
+
+func (p *slowProvider) Complete(prompt string, opts llm.Options) (llm.Response, error) {
+	n := p.calls.Add(1)
+	time.Sleep(p.delay)
+	if n <= p.failures {
+		return llm.Response{}, errors.New("transient failure")
+	}
+	return llm.Response{Text: "# Generated"}, nil
+}
+
+func (p *slowProvider) Tokens(input string) (int, error) { return len(input) / 4, nil }
+func (p *slowProvider) Name() string                     { return "slow-provider" }
+
+func writeWelcomeFixtures(t *testing.T) (readmePath, promptPath string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	readmePath = filepath.Join(tmpDir, "README.md")
+	if err := os.WriteFile(readmePath, []byte("# My Project\n\nREADME body."), 0644); err != nil {
+		t.Fatalf("Failed to create README: %v", err)
+	}
+
+	promptPath = filepath.Join(tmpDir, "prompt.md")
+	if err := os.WriteFile(promptPath, []byte("{{README_CONTENT}}"), 0644); err != nil {
+		t.Fatalf("Failed to create prompt: %v", err)
+	}
+
+	return readmePath, promptPath
+}
+
+func TestGenerate_RetriesTransientFailure(t *testing.T) {
+	readmePath, promptPath := writeWelcomeFixtures(t)
+
+	provider := &slowProvider{failures: 2}
+	gen := NewGeneratorWithPrompt(llm.NewOrchestrator(provider, nil, nil), promptPath)
+
+	content, err := gen.Generate(context.Background(), GenerateOptions{
+		ReadmePath: readmePath,
+		Retries:    2,
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if got := provider.calls.Load(); got != 3 {
+		t.Errorf("Provider called %d times, want 3", got)
+	}
+	if !strings.Contains(content, "# Generated") {
+		t.Errorf("Expected generated content, got: %s", content)
+	}
+}
+
+func TestGenerate_TimeoutFallsBackToReadme(t *testing.T) {
+	readmePath, promptPath := writeWelcomeFixtures(t)
+
+	provider := &slowProvider{delay: 200 * time.Millisecond}
+	gen := NewGeneratorWithPrompt(llm.NewOrchestrator(provider, nil, nil), promptPath)
+
+	start := time.Now()
+	content, err := gen.Generate(context.Background(), GenerateOptions{
+		ReadmePath: readmePath,
+		Timeout:    20 * time.Millisecond,
+		Retries:    1,
+	})
+	if err != nil {
+		t.Fatalf("Generate should fall back instead of failing: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Generate took %v, timeout was not applied", elapsed)
+	}
+	if got := provider.calls.Load(); got != 2 {
+		t.Errorf("Provider called %d times, want 2", got)
+	}
+	if !strings.Contains(content, "README body.") || !strings.HasPrefix(content, "---\n") {
+		t.Errorf("Expected README content with front matter, got: %s", content)
+	}
+}
+
+func TestGenerate_FallbackOnFailure(t *testing.T) {
+	readmePath, promptPath := writeWelcomeFixtures(t)
+
+	mockProvider := &MockProvider{err: errors.New("provider down")}
+	gen := NewGeneratorWithPrompt(llm.NewOrchestrator(mockProvider, nil, nil), promptPath)
+
+	outputPath := filepath.Join(filepath.Dir(readmePath), "docs", "index.md")
+	_, err := gen.Generate(context.Background(), GenerateOptions{
+		ReadmePath: readmePath,
+		OutputPath: outputPath,
+		Retries:    1,
+	})
+	if err != nil {
+		t.Fatalf("Generate should fall back instead of failing: %v", err)
+	}
+
+	if mockProvider.callCount != 2 {
+		t.Errorf("Provider called %d times, want 2", mockProvider.callCount)
+	}
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Fallback page not written: %v", err)
+	}
+	if !strings.Contains(string(data), "# My Project") {
+		t.Errorf("Fallback page should contain README, got: %s", data)
+	}
+}
+
+func TestGenerate_CanceledContextFails(t *testing.T) {
+	readmePath, promptPath := writeWelcomeFixtures(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	gen := NewGeneratorWithPrompt(llm.NewOrchestrator(&slowProvider{delay: 50 * time.Millisecond}, nil, nil), promptPath)
+	if _, err := gen.Generate(ctx, GenerateOptions{ReadmePath: readmePath, Retries: 3}); err == nil {
+		t.Error("Expected error when the context is canceled")
+	}
+}
+
+func TestGenerate_MultipleLanguages(t *testing.T) {
+	readmePath, promptPath := writeWelcomeFixtures(t)
+	docsDir := filepath.Join(filepath.Dir(readmePath), "docs")
+
+	mockProvider := &MockProvider{response: "# Welcome"}
+	gen := NewGeneratorWithPrompt(llm.NewOrchestrator(mockProvider, nil, nil), promptPath)
+
+	_, err := gen.Generate(context.Background(), GenerateOptions{
+		ReadmePath: readmePath,
+		OutputPath: filepath.Join(docsDir, "index.md"),
+		Languages:  []string{"en", "es", "fr"},
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if mockProvider.callCount != 3 {
+		t.Errorf("Provider called %d times, want 3", mockProvider.callCount)
+	}
+	if !strings.Contains(mockProvider.lastPrompt, "French") {
+		t.Errorf("Last prompt should request French, got: %s", mockProvider.lastPrompt)
+	}
+
+	pages := map[string][]string{
+		"index.md":    {"lang: en", "permalink: /\n"},
+		"index.es.md": {"lang: es", "permalink: /es/"},
+		"index.fr.md": {"lang: fr", "permalink: /fr/"},
+	}
+	for name, checks := range pages {
+		data, err := os.ReadFile(filepath.Join(docsDir, name))
+		if err != nil {
+			t.Errorf("%s not written: %v", name, err)
+			continue
+		}
+		for _, check := range checks {
+			if !strings.Contains(string(data), check) {
+				t.Errorf("%s missing %q:\n%s", name, check, data)
+			}
+		}
+	}
+}
+
+func TestGenerate_LanguagesSkippedWithoutLLM(t *testing.T) {
+	readmePath, promptPath := writeWelcomeFixtures(t)
+	docsDir := filepath.Join(filepath.Dir(readmePath), "docs")
+
+	gen := NewGeneratorWithPrompt(nil, promptPath)
+
+	_, err := gen.Generate(context.Background(), GenerateOptions{
+		ReadmePath: readmePath,
+		OutputPath: filepath.Join(docsDir, "index.md"),
+		Languages:  []string{"en", "es"},
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(docsDir, "index.md")); err != nil {
+		t.Errorf("Primary page should fall back to README: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(docsDir, "index.es.md")); !os.IsNotExist(err) {
+		t.Errorf("Translation should be skipped without an LLM, stat err = %v", err)
+	}
+}
+
+func TestLocalizedPath(t *testing.T) {
+	if got := localizedPath(filepath.Join("docs", "index.md"), "pt-br"); got != filepath.Join("docs", "index.pt-br.md") {
+		t.Errorf("localizedPath = %q", got)
+	}
+}
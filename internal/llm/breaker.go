@@ -0,0 +1,106 @@
+package llm
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker skips providers after consecutive failures. Once the
+// cooldown passes a single trial call is let through; its failure reopens
+// the circuit and its success closes it. Providers are tracked by their
+// position in the orchestrator's chain, so two providers of the same kind
+// have separate circuits.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	circuits  map[int]*circuit
+	now       func() time.Time
+}
+
+// circuit is the failure state of one provider
+type circuit struct {
+	failures  int
+	openUntil time.Time
+	trial     bool // A half-open trial call is in flight
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		circuits:  make(map[int]*circuit),
+		now:       time.Now,
+	}
+}
+
+// allow reports whether the provider at pos may be called. After the cooldown only
+// one caller is allowed through until that trial reports its outcome.
+// A nil breaker allows all.
+func (b *circuitBreaker) allow(pos int) bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[pos]
+	if !ok || c.failures < b.threshold {
+		return true
+	}
+	if b.now().Before(c.openUntil) || c.trial {
+		return false
+	}
+	c.trial = true
+	return true
+}
+
+// success closes the circuit for the provider at pos
+func (b *circuitBreaker) success(pos int) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.circuits, pos)
+}
+
+// failure counts a failed call and opens the circuit at the threshold
+func (b *circuitBreaker) failure(pos int) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[pos]
+	if !ok {
+		c = &circuit{}
+		b.circuits[pos] = c
+	}
+	c.failures++
+	c.trial = false
+	if c.failures >= b.threshold {
+		c.openUntil = b.now().Add(b.cooldown)
+	}
+}
+
+// release ends a call without an outcome, e.g. one cancelled by its
+// caller, so a pending trial does not block the provider forever
+func (b *circuitBreaker) release(pos int) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c, ok := b.circuits[pos]; ok {
+		c.trial = false
+	}
+}
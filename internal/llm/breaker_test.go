@@ -0,0 +1,106 @@
+package llm
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(3, time.Minute)
+	b.now = func() time.Time { return now }
+	const openai, litellm = 0, 1
+
+	b.failure(openai)
+	b.failure(openai)
+	if !b.allow(openai) {
+		t.Fatal("Expected the circuit to stay closed below the threshold")
+	}
+
+	b.failure(openai)
+	if b.allow(openai) {
+		t.Fatal("Expected the circuit to open at the threshold")
+	}
+	if !b.allow(litellm) {
+		t.Error("Expected other providers to be unaffected")
+	}
+
+	// After the cooldown one trial call is allowed; failing it reopens
+	now = now.Add(time.Minute)
+	if !b.allow(openai) {
+		t.Fatal("Expected a trial call after the cooldown")
+	}
+	if b.allow(openai) {
+		t.Fatal("Expected only one trial call while it is in flight")
+	}
+	b.failure(openai)
+	if b.allow(openai) {
+		t.Fatal("Expected a failed trial to reopen the circuit")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow(openai) {
+		t.Fatal("Expected another trial after the cooldown")
+	}
+	b.success(openai)
+	b.failure(openai)
+	if !b.allow(openai) {
+		t.Error("Expected a success to reset the failure count")
+	}
+}
+
+func TestCircuitBreaker_SingleTrialUnderConcurrency(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(1, time.Minute)
+	b.now = func() time.Time { return now }
+	const provider = 0
+
+	b.failure(provider)
+	now = now.Add(time.Minute)
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if b.allow(provider) {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := allowed.Load(); got != 1 {
+		t.Errorf("Expected exactly one trial call, got %d", got)
+	}
+}
+
+func TestCircuitBreaker_ReleaseFreesTrial(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(1, time.Minute)
+	b.now = func() time.Time { return now }
+	const provider = 0
+
+	b.failure(provider)
+	now = now.Add(time.Minute)
+
+	if !b.allow(provider) {
+		t.Fatal("Expected a trial call after the cooldown")
+	}
+	b.release(provider)
+	if !b.allow(provider) {
+		t.Error("Expected a released trial to let the next caller through")
+	}
+}
+
+func TestCircuitBreaker_NilAllowsAll(t *testing.T) {
+	var b *circuitBreaker
+	const provider = 0
+	b.failure(provider)
+	if !b.allow(provider) {
+		t.Error("Expected a nil breaker to allow every provider")
+	}
+}
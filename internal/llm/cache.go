@@ -0,0 +1,112 @@
+package llm
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Cache stores completed responses so identical requests skip the provider
+type Cache interface {
+	Get(key string) (Response, bool)
+	Set(key string, resp Response)
+}
+
+// CacheKey identifies a request by a SHA-256 of the prompt, model and options
+func CacheKey(prompt string, opts Options) string {
+	h := sha256.New()
+	h.Write([]byte(prompt))
+	h.Write([]byte{0})
+	h.Write([]byte(opts.ModelKey))
+	h.Write([]byte{0})
+	// Options marshal deterministically; map keys are sorted
+	encoded, _ := json.Marshal(opts)
+	h.Write(encoded)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// LRUCache is an in-memory Cache that evicts the least recently used entry
+// once full. Entries older than the TTL are treated as misses.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List
+	entries  map[string]*list.Element
+	now      func() time.Time
+}
+
+// lruEntry is a cached response and when it was stored
+type lruEntry struct {
+	key    string
+	resp   Response
+	stored time.Time
+}
+
+// NewLRUCache creates a cache holding up to capacity responses; a zero ttl
+// keeps entries until they are evicted
+func NewLRUCache(capacity int, ttl time.Duration) *LRUCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRUCache{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Get returns the response stored under key, if present and not expired
+func (c *LRUCache) Get(key string) (Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return Response{}, false
+	}
+
+	entry := elem.Value.(*lruEntry)
+	if c.ttl > 0 && c.now().Sub(entry.stored) >= c.ttl {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return Response{}, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.resp, true
+}
+
+// Set stores resp under key, evicting the least recently used entry if full
+func (c *LRUCache) Set(key string, resp Response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.resp, entry.stored = resp, c.now()
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, resp: resp, stored: c.now()})
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of cached responses, including expired ones not
+// yet evicted
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
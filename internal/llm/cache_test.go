@@ -0,0 +1,124 @@
+package llm
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCacheKey(t *testing.T) {
+	base := CacheKey("prompt", Options{ModelKey: "gpt-4", MaxTokens: 100})
+
+	if again := CacheKey("prompt", Options{ModelKey: "gpt-4", MaxTokens: 100}); again != base {
+		t.Error("Expected identical requests to share a key")
+	}
+	if len(base) != 64 {
+		t.Errorf("Expected a hex SHA-256 key, got %q", base)
+	}
+
+	variants := map[string]string{
+		"prompt":  CacheKey("other prompt", Options{ModelKey: "gpt-4", MaxTokens: 100}),
+		"model":   CacheKey("prompt", Options{ModelKey: "gpt-3.5", MaxTokens: 100}),
+		"options": CacheKey("prompt", Options{ModelKey: "gpt-4", MaxTokens: 200}),
+		"system":  CacheKey("prompt", Options{ModelKey: "gpt-4", MaxTokens: 100, System: "be brief"}),
+	}
+	for name, key := range variants {
+		if key == base {
+			t.Errorf("Expected a different %s to change the key", name)
+		}
+	}
+}
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewLRUCache(2, 0)
+	cache.Set("a", Response{Text: "A"})
+	cache.Set("b", Response{Text: "B"})
+
+	// Touch a so b is the oldest
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("Expected a to be cached")
+	}
+	cache.Set("c", Response{Text: "C"})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	if resp, ok := cache.Get("a"); !ok || resp.Text != "A" {
+		t.Errorf("Expected a to survive, got %+v, %v", resp, ok)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+}
+
+func TestLRUCache_TTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewLRUCache(10, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("key", Response{Text: "cached"})
+
+	now = now.Add(59 * time.Second)
+	if _, ok := cache.Get("key"); !ok {
+		t.Error("Expected the entry before its TTL")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := cache.Get("key"); ok {
+		t.Error("Expected the entry to expire after its TTL")
+	}
+	if cache.Len() != 0 {
+		t.Error("Expected the expired entry to be dropped")
+	}
+}
+
+func TestOrchestratorComplete_CacheHitSkipsProviderAndTracker(t *testing.T) {
+	provider := &mockProvider{
+		name:     "primary",
+		response: Response{Text: "fresh", TokensIn: 100, TokensOut: 200, Model: "test-model"},
+	}
+	orch := NewOrchestrator(provider, nil, newStreamTracker()).WithCache(NewLRUCache(10, 0))
+	opts := Options{ModelKey: "test-model"}
+
+	first, err := orch.Complete(context.Background(), "prompt", opts)
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	afterFirst, _ := orch.RemainingBudget()
+
+	second, err := orch.Complete(context.Background(), "prompt", opts)
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	if second.Text != first.Text {
+		t.Errorf("Expected the cached response, got %q", second.Text)
+	}
+	if provider.callCount != 1 {
+		t.Errorf("Expected one provider call, got %d", provider.callCount)
+	}
+	if afterSecond, _ := orch.RemainingBudget(); afterSecond != afterFirst {
+		t.Errorf("Expected a cache hit to be free, budget went from %f to %f", afterFirst, afterSecond)
+	}
+
+	// A different request misses
+	if _, err := orch.Complete(context.Background(), "other prompt", opts); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if provider.callCount != 2 {
+		t.Errorf("Expected a new prompt to reach the provider, got %d calls", provider.callCount)
+	}
+}
+
+func TestOrchestratorComplete_FailuresAreNotCached(t *testing.T) {
+	provider := &mockProvider{name: "primary", err: context.DeadlineExceeded}
+	cache := NewLRUCache(10, 0)
+	orch := NewOrchestrator(provider, nil, nil).WithCache(cache)
+
+	if _, err := orch.Complete(context.Background(), "prompt", Options{}); err == nil {
+		t.Fatal("Expected the provider error")
+	}
+	if cache.Len() != 0 {
+		t.Error("Expected failed requests to stay out of the cache")
+	}
+}
@@ -20,6 +20,11 @@ type Tracker struct {
 	dailyUSD          float64
 	dailyUsedUSD      float64
 	lastReset         time.Time
+
+	// labelBudgets caps daily spend per label (e.g. "owner/repo") on top of
+	// the global budgets; labels without an entry are only globally limited
+	labelBudgets map[string]float64
+	labelUsedUSD map[string]float64
 }
 
 // NewTracker creates a new cost tracker with the given budgets and prices
@@ -31,14 +36,31 @@ func NewTracker(perRunUSD, dailyUSD float64, prices map[string]PriceMap) *Tracke
 		dailyUSD:          dailyUSD,
 		dailyUsedUSD:      0.0,
 		lastReset:         time.Now(),
+		labelBudgets:      make(map[string]float64),
+		labelUsedUSD:      make(map[string]float64),
 	}
 }
 
+// WithLabelBudget sets a daily sub-budget for label, so one label reaching
+// its cap does not use up the shared daily budget
+func (t *Tracker) WithLabelBudget(label string, dailyUSD float64) *Tracker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.labelBudgets[label] = dailyUSD
+	return t
+}
+
 // Allow checks if the estimated cost is within budget
 func (t *Tracker) Allow(tokensIn, tokensOut int, model string) bool {
-	t.mu.RLock()
-	defer t.mu.RUnlock()
-	
+	// The daily reset writes counters, so this needs the write lock
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.allow(tokensIn, tokensOut, model)
+}
+
+// allow checks the global budgets. Must be called with the write lock held.
+func (t *Tracker) allow(tokensIn, tokensOut int, model string) bool {
 	// Reset daily budget if needed
 	t.resetDailyIfNeeded()
 	
@@ -64,11 +86,60 @@ func (t *Tracker) Allow(tokensIn, tokensOut int, model string) bool {
 	return true
 }
 
+// AllowLabel checks the global budgets and, when label has a sub-budget,
+// that the estimated cost also fits within it
+func (t *Tracker) AllowLabel(label string, tokensIn, tokensOut int, model string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.allow(tokensIn, tokensOut, model) {
+		return false
+	}
+
+	budget, limited := t.labelBudgets[label]
+	price, priced := t.priceMap[model]
+	if !limited || !priced {
+		return true
+	}
+
+	costUSD := (float64(tokensIn)/1000.0)*price.InputPer1K + (float64(tokensOut)/1000.0)*price.OutputPer1K
+	return t.labelUsedUSD[label]+costUSD <= budget
+}
+
+// Priced reports whether model has a configured price
+func (t *Tracker) Priced(model string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	_, ok := t.priceMap[model]
+	return ok
+}
+
+// Estimate returns the cost of a request without recording it. The second
+// result is false when the model has no configured price.
+func (t *Tracker) Estimate(tokensIn, tokensOut int, model string) (float64, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	price, ok := t.priceMap[model]
+	if !ok {
+		return 0, false
+	}
+
+	return (float64(tokensIn)/1000.0)*price.InputPer1K + (float64(tokensOut)/1000.0)*price.OutputPer1K, true
+}
+
 // Spend records the actual cost of a request
 func (t *Tracker) Spend(tokensIn, tokensOut int, model string) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	
+
+	return t.spend(tokensIn, tokensOut, model)
+}
+
+// spend records a cost against the global budgets. Must be called with the
+// write lock held.
+func (t *Tracker) spend(tokensIn, tokensOut int, model string) error {
 	// Reset daily budget if needed
 	t.resetDailyIfNeeded()
 	
@@ -88,10 +159,47 @@ func (t *Tracker) Spend(tokensIn, tokensOut int, model string) error {
 	return nil
 }
 
+// SpendLabel records the actual cost of a request against the global
+// budgets and label's usage
+func (t *Tracker) SpendLabel(label string, tokensIn, tokensOut int, model string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.spend(tokensIn, tokensOut, model); err != nil {
+		return err
+	}
+	if label == "" {
+		return nil
+	}
+
+	price, ok := t.priceMap[model]
+	if !ok {
+		return nil
+	}
+	t.labelUsedUSD[label] += (float64(tokensIn)/1000.0)*price.InputPer1K + (float64(tokensOut)/1000.0)*price.OutputPer1K
+
+	return nil
+}
+
+// RemainingLabel returns the remaining daily sub-budget for label. The
+// second result is false when label has no sub-budget.
+func (t *Tracker) RemainingLabel(label string) (float64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.resetDailyIfNeeded()
+
+	budget, ok := t.labelBudgets[label]
+	if !ok {
+		return 0, false
+	}
+	return budget - t.labelUsedUSD[label], true
+}
+
 // Remaining returns the remaining budget as a tuple (perRun, daily)
 func (t *Tracker) Remaining() (float64, float64) {
-	t.mu.RLock()
-	defer t.mu.RUnlock()
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	
 	t.resetDailyIfNeeded()
 	
@@ -99,11 +207,12 @@ func (t *Tracker) Remaining() (float64, float64) {
 }
 
 // resetDailyIfNeeded checks if we need to reset the daily budget
-// Must be called with lock held
+// Must be called with the write lock held
 func (t *Tracker) resetDailyIfNeeded() {
 	now := time.Now()
 	if now.Day() != t.lastReset.Day() || now.Month() != t.lastReset.Month() || now.Year() != t.lastReset.Year() {
 		t.dailyUsedUSD = 0.0
+		t.labelUsedUSD = make(map[string]float64)
 		t.lastReset = now
 	}
 }
@@ -1,6 +1,7 @@
 package cost
 
 import (
+	"sync"
 	"testing"
 )
 
@@ -99,3 +100,156 @@ func TestCostTrackerDailyReset(t *testing.T) {
 	}
 }
 
+
+func TestCostTrackerEstimate(t *testing.T) {
+	tracker := NewTracker(10.0, 100.0, map[string]PriceMap{
+		"gpt-4": {InputPer1K: 0.03, OutputPer1K: 0.06},
+	})
+
+	usd, ok := tracker.Estimate(1000, 500, "gpt-4")
+	if !ok || usd < 0.0599 || usd > 0.0601 {
+		t.Errorf("Estimate = %v, %v; want 0.06, true", usd, ok)
+	}
+
+	if _, ok := tracker.Estimate(1000, 500, "unknown"); ok {
+		t.Error("Expected unknown model to be unpriced")
+	}
+
+	// Estimating must not consume budget
+	if remaining, _ := tracker.Remaining(); remaining != 10.0 {
+		t.Errorf("Remaining = %v, want 10.0", remaining)
+	}
+}
+
+func TestCostTrackerLabelBudgets(t *testing.T) {
+	prices := map[string]PriceMap{
+		"gpt-4": {InputPer1K: 1.0, OutputPer1K: 1.0},
+	}
+
+	tracker := NewTracker(100.0, 100.0, prices).
+		WithLabelBudget("acme/noisy", 2.0).
+		WithLabelBudget("acme/quiet", 2.0)
+
+	// 1000 in + 1000 out = $2, exactly the noisy repo's cap
+	if err := tracker.SpendLabel("acme/noisy", 1000, 1000, "gpt-4"); err != nil {
+		t.Fatalf("SpendLabel failed: %v", err)
+	}
+
+	if tracker.AllowLabel("acme/noisy", 100, 100, "gpt-4") {
+		t.Error("Expected noisy repo to be blocked by its sub-budget")
+	}
+	if !tracker.AllowLabel("acme/quiet", 100, 100, "gpt-4") {
+		t.Error("Expected quiet repo to be allowed within its sub-budget")
+	}
+	if !tracker.AllowLabel("acme/unlimited", 100, 100, "gpt-4") {
+		t.Error("Expected a label without a sub-budget to use the global budget")
+	}
+
+	if remaining, ok := tracker.RemainingLabel("acme/noisy"); !ok || remaining != 0 {
+		t.Errorf("RemainingLabel(noisy) = %v, %v; want 0, true", remaining, ok)
+	}
+	if remaining, ok := tracker.RemainingLabel("acme/quiet"); !ok || remaining != 2.0 {
+		t.Errorf("RemainingLabel(quiet) = %v, %v; want 2, true", remaining, ok)
+	}
+	if _, ok := tracker.RemainingLabel("acme/unlimited"); ok {
+		t.Error("Expected no sub-budget for unlimited label")
+	}
+
+	// Labeled spend also counts against the global budgets
+	perRun, daily := tracker.Remaining()
+	if perRun != 98.0 || daily != 98.0 {
+		t.Errorf("Remaining() = %v, %v; want 98, 98", perRun, daily)
+	}
+}
+
+func TestCostTrackerLabelBudgetsRespectGlobal(t *testing.T) {
+	prices := map[string]PriceMap{
+		"gpt-4": {InputPer1K: 1.0, OutputPer1K: 1.0},
+	}
+
+	tracker := NewTracker(1.0, 1.0, prices).WithLabelBudget("acme/repo", 50.0)
+
+	if tracker.AllowLabel("acme/repo", 1000, 1000, "gpt-4") {
+		t.Error("Expected the global budget to apply even when the sub-budget has room")
+	}
+}
+
+func TestCostTrackerLabelBudgetsDailyReset(t *testing.T) {
+	prices := map[string]PriceMap{
+		"gpt-4": {InputPer1K: 1.0, OutputPer1K: 1.0},
+	}
+
+	tracker := NewTracker(100.0, 100.0, prices).WithLabelBudget("acme/repo", 1.0)
+	tracker.SpendLabel("acme/repo", 500, 500, "gpt-4")
+
+	tracker.mu.Lock()
+	tracker.lastReset = tracker.lastReset.AddDate(0, 0, -1)
+	tracker.mu.Unlock()
+
+	if remaining, _ := tracker.RemainingLabel("acme/repo"); remaining != 1.0 {
+		t.Errorf("Expected sub-budget to reset daily, remaining %v", remaining)
+	}
+}
+
+func TestCostTrackerConcurrentDailyReset(t *testing.T) {
+	prices := map[string]PriceMap{
+		"gpt-4": {InputPer1K: 0.001, OutputPer1K: 0.001},
+	}
+
+	tracker := NewTracker(100.0, 100.0, prices).WithLabelBudget("acme/repo", 100.0)
+	tracker.SpendLabel("acme/repo", 1000, 1000, "gpt-4")
+
+	// Every reader sees a stale day and resets the counters
+	tracker.mu.Lock()
+	tracker.lastReset = tracker.lastReset.AddDate(0, 0, -1)
+	tracker.mu.Unlock()
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			tracker.Allow(1000, 1000, "gpt-4")
+			tracker.AllowLabel("acme/repo", 1000, 1000, "gpt-4")
+			tracker.Remaining()
+			tracker.SpendLabel("acme/repo", 1000, 1000, "gpt-4")
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if remaining, _ := tracker.RemainingLabel("acme/repo"); remaining < 99.98 || remaining > 99.99 {
+		t.Errorf("Expected only today's spend against the sub-budget, remaining %v", remaining)
+	}
+}
+
+func TestDefaultPricesAreUsable(t *testing.T) {
+	tracker := NewTracker(10.0, 100.0, DefaultPrices())
+
+	for _, model := range []string{"gpt-4", "gpt-4o-mini"} {
+		if !tracker.Priced(model) {
+			t.Errorf("Expected %s to be priced", model)
+		}
+	}
+	if tracker.Priced("default") {
+		t.Error("Expected the placeholder model key to be unpriced")
+	}
+}
+
+func TestParsePrice(t *testing.T) {
+	price, err := ParsePrice("0.01", "0.03")
+	if err != nil {
+		t.Fatalf("ParsePrice failed: %v", err)
+	}
+	if price.InputPer1K != 0.01 || price.OutputPer1K != 0.03 {
+		t.Errorf("Unexpected price %+v", price)
+	}
+
+	for _, bad := range [][2]string{{"", "0.03"}, {"0.01", "abc"}, {"-1", "0.03"}} {
+		if _, err := ParsePrice(bad[0], bad[1]); err == nil {
+			t.Errorf("Expected an error for %q", bad)
+		}
+	}
+}
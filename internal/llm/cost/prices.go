@@ -0,0 +1,34 @@
+package cost
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// DefaultPrices returns list prices for common models, in USD per 1k tokens
+func DefaultPrices() map[string]PriceMap {
+	return map[string]PriceMap{
+		"gpt-4":         {InputPer1K: 0.03, OutputPer1K: 0.06},
+		"gpt-4-turbo":   {InputPer1K: 0.01, OutputPer1K: 0.03},
+		"gpt-4o":        {InputPer1K: 0.0025, OutputPer1K: 0.01},
+		"gpt-4o-mini":   {InputPer1K: 0.00015, OutputPer1K: 0.0006},
+		"gpt-3.5-turbo": {InputPer1K: 0.0005, OutputPer1K: 0.0015},
+	}
+}
+
+// ParsePrice parses input and output prices in USD per 1k tokens, e.g.
+// from environment variables
+func ParsePrice(input, output string) (PriceMap, error) {
+	in, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		return PriceMap{}, fmt.Errorf("invalid input price %q: %w", input, err)
+	}
+	out, err := strconv.ParseFloat(output, 64)
+	if err != nil {
+		return PriceMap{}, fmt.Errorf("invalid output price %q: %w", output, err)
+	}
+	if in < 0 || out < 0 {
+		return PriceMap{}, fmt.Errorf("prices must not be negative")
+	}
+	return PriceMap{InputPer1K: in, OutputPer1K: out}, nil
+}
@@ -0,0 +1,64 @@
+package httpbase
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrRetryBudgetExhausted is returned when a request would retry but the
+// retry budget shared through its context has been spent
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// RetryBudget caps the total number of retries across every request that
+// shares it, so a flaky run fails fast instead of multiplying retries.
+// It is safe for concurrent use.
+type RetryBudget struct {
+	mu        sync.Mutex
+	remaining int
+}
+
+// NewRetryBudget creates a budget allowing max retries in total
+func NewRetryBudget(max int) *RetryBudget {
+	if max < 0 {
+		max = 0
+	}
+	return &RetryBudget{remaining: max}
+}
+
+// Take consumes one retry, reporting false once the budget is spent.
+// A nil budget never runs out.
+func (b *RetryBudget) Take() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.remaining == 0 {
+		return false
+	}
+	b.remaining--
+	return true
+}
+
+// Remaining returns how many retries are left
+func (b *RetryBudget) Remaining() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.remaining
+}
+
+type retryBudgetKey struct{}
+
+// WithRetryBudget returns a context whose requests draw retries from budget
+func WithRetryBudget(ctx context.Context, budget *RetryBudget) context.Context {
+	return context.WithValue(ctx, retryBudgetKey{}, budget)
+}
+
+// RetryBudgetFromContext returns the budget attached to ctx, or nil
+func RetryBudgetFromContext(ctx context.Context) *RetryBudget {
+	budget, _ := ctx.Value(retryBudgetKey{}).(*RetryBudget)
+	return budget
+}
@@ -11,26 +11,108 @@ import (
 	"time"
 
 	"log"
+
+	"github.com/Mpaape/AurumCode/internal/tracing"
 )
 
 // Client is an HTTP client with retry, backoff, and secret redaction
 type Client struct {
-	httpClient *http.Client
-	timeout    time.Duration
-	maxRetries int
-	baseURL    string
+	httpClient     *http.Client
+	streamClient   *http.Client
+	timeout        time.Duration
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	baseURL        string
+	userAgent      string
+	clock          Clock
+}
+
+// ClientOptions tunes retries and timeouts. Zero fields use the defaults
+// from DefaultClientOptions.
+type ClientOptions struct {
+	// MaxRetries is how many times a failed request is retried
+	// (negative disables retries)
+	MaxRetries int
+
+	// InitialBackoff is the wait before the first retry; retry n waits
+	// InitialBackoff*n², capped at MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// Timeout bounds each attempt
+	Timeout time.Duration
+}
+
+// DefaultClientOptions returns the settings used by NewClient
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		MaxRetries:     3,
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     30 * time.Second,
+		Timeout:        30 * time.Second,
+	}
 }
 
 // NewClient creates a new HTTP client with default settings
 func NewClient(baseURL string) *Client {
+	return NewClientWithOptions(baseURL, DefaultClientOptions())
+}
+
+// NewClientWithOptions creates an HTTP client with custom retry and timeout settings
+func NewClientWithOptions(baseURL string, opts ClientOptions) *Client {
+	defaults := DefaultClientOptions()
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = defaults.MaxRetries
+	} else if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+	if opts.InitialBackoff == 0 {
+		opts.InitialBackoff = defaults.InitialBackoff
+	}
+	if opts.MaxBackoff == 0 {
+		opts.MaxBackoff = defaults.MaxBackoff
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = defaults.Timeout
+	}
+
 	return &Client{
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: opts.Timeout,
 		},
-		timeout:    30 * time.Second,
-		maxRetries: 3,
-		baseURL:    baseURL,
+		streamClient:   newStreamClient(opts.Timeout),
+		timeout:        opts.Timeout,
+		maxRetries:     opts.MaxRetries,
+		initialBackoff: opts.InitialBackoff,
+		maxBackoff:     opts.MaxBackoff,
+		baseURL:        baseURL,
+		userAgent:      DefaultUserAgent(),
+		clock:          realClock{},
+	}
+}
+
+// newStreamClient returns a client for long-lived streaming responses. Only
+// the wait for response headers is bounded; the body may take as long as
+// the request context allows.
+func newStreamClient(headerTimeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = headerTimeout
+	return &http.Client{Transport: transport}
+}
+
+// WithClock sets the clock used to wait between retries
+func (c *Client) WithClock(clock Clock) *Client {
+	c.clock = clock
+	return c
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func (c *Client) WithUserAgent(userAgent string) *Client {
+	if userAgent != "" {
+		c.userAgent = userAgent
 	}
+	return c
 }
 
 // Request represents an HTTP request
@@ -41,22 +123,45 @@ type Request struct {
 	Body    interface{}
 }
 
-// Do performs an HTTP request with retries and backoff
+// Do performs an HTTP request with retries and backoff. Retries also draw
+// from any RetryBudget attached to ctx with WithRetryBudget. The whole call,
+// retries included, is traced as one span.
 func (c *Client) Do(ctx context.Context, req *Request) (*http.Response, error) {
+	ctx, span := tracing.Start(ctx, "http "+req.Method+" "+req.Path)
+	resp, err := c.do(ctx, req, c.httpClient)
+	span.End(err)
+	return resp, err
+}
+
+// DoStream is like Do for streaming responses such as server-sent events.
+// The client timeout only bounds the wait for response headers, so reading
+// the body is limited by ctx alone.
+func (c *Client) DoStream(ctx context.Context, req *Request) (*http.Response, error) {
+	ctx, span := tracing.Start(ctx, "http "+req.Method+" "+req.Path)
+	resp, err := c.do(ctx, req, c.streamClient)
+	span.End(err)
+	return resp, err
+}
+
+// do performs the request attempts for Do and DoStream
+func (c *Client) do(ctx context.Context, req *Request, client *http.Client) (*http.Response, error) {
 	var lastErr error
 	
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
 		if attempt > 0 {
-			// Exponential backoff with jitter
-			backoff := time.Duration(attempt*attempt) * time.Second
+			// Retries shared across a run stop once its budget is spent
+			if !RetryBudgetFromContext(ctx).Take() {
+				return nil, fmt.Errorf("%w: %v", ErrRetryBudgetExhausted, lastErr)
+			}
+
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-time.After(backoff):
+			case <-c.clock.After(c.backoff(attempt)):
 			}
 		}
 		
-		resp, err := c.doAttempt(ctx, req)
+		resp, err := c.doAttempt(ctx, req, client)
 		if err == nil && c.shouldRetry(resp) {
 			if resp != nil && resp.Body != nil {
 				resp.Body.Close()
@@ -71,8 +176,17 @@ func (c *Client) Do(ctx context.Context, req *Request) (*http.Response, error) {
 	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
+// backoff returns the wait before retry attempt, growing quadratically up to maxBackoff
+func (c *Client) backoff(attempt int) time.Duration {
+	backoff := time.Duration(attempt*attempt) * c.initialBackoff
+	if backoff > c.maxBackoff {
+		return c.maxBackoff
+	}
+	return backoff
+}
+
 // doAttempt performs a single HTTP request
-func (c *Client) doAttempt(ctx context.Context, req *Request) (*http.Response, error) {
+func (c *Client) doAttempt(ctx context.Context, req *Request, client *http.Client) (*http.Response, error) {
 	var body io.Reader
 	
 	if req.Body != nil {
@@ -89,6 +203,8 @@ func (c *Client) doAttempt(ctx context.Context, req *Request) (*http.Response, e
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	
+	httpReq.Header.Set("User-Agent", c.userAgent)
+
 	// Set headers
 	if req.Headers != nil {
 		for k, v := range req.Headers {
@@ -104,7 +220,7 @@ func (c *Client) doAttempt(ctx context.Context, req *Request) (*http.Response, e
 	// Log request (with redaction)
 	c.logRequest(req)
 	
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := client.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -2,10 +2,15 @@ package httpbase
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/Mpaape/AurumCode/internal/tracing"
 )
 
 func TestClientRetryOn500(t *testing.T) {
@@ -143,3 +148,341 @@ func TestTimeout(t *testing.T) {
 	}
 }
 
+func TestClientBackoffUsesClock(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	client := NewClient(server.URL).WithClock(clock)
+
+	began := time.Now()
+	resp, err := client.Do(context.Background(), &Request{Method: "GET", Path: "/test"})
+	if err != nil {
+		t.Fatalf("Request should succeed after retries: %v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(began); elapsed > time.Second {
+		t.Errorf("backoff should not sleep on a fake clock, took %v", elapsed)
+	}
+
+	want := []time.Duration{1 * time.Second, 4 * time.Second}
+	waits := clock.Waits()
+	if len(waits) != len(want) {
+		t.Fatalf("Expected %d backoff waits, got %v", len(want), waits)
+	}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Errorf("wait %d = %v, want %v", i, waits[i], want[i])
+		}
+	}
+
+	if got := clock.Now().Sub(start); got != 5*time.Second {
+		t.Errorf("Expected clock to advance 5s, advanced %v", got)
+	}
+}
+
+func TestClientMaxRetriesWithFakeClock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := NewClient(server.URL).WithClock(clock)
+
+	if _, err := client.Do(context.Background(), &Request{Method: "GET", Path: "/test"}); err == nil {
+		t.Fatal("Expected max retries error")
+	}
+
+	if got := len(clock.Waits()); got != client.maxRetries {
+		t.Errorf("Expected %d backoff waits, got %d", client.maxRetries, got)
+	}
+}
+
+func TestFakeClockAdvance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	clock.Advance(90 * time.Minute)
+	if got := clock.Now(); !got.Equal(start.Add(90 * time.Minute)) {
+		t.Errorf("Now() = %v, want %v", got, start.Add(90*time.Minute))
+	}
+
+	select {
+	case fired := <-clock.After(time.Minute):
+		if !fired.Equal(start.Add(91 * time.Minute)) {
+			t.Errorf("After fired at %v, want %v", fired, start.Add(91*time.Minute))
+		}
+	default:
+		t.Error("After should fire immediately on a fake clock")
+	}
+}
+
+func TestClientRetryBudgetSharedAcrossRequests(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL).WithClock(NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	budget := NewRetryBudget(2)
+	ctx := WithRetryBudget(context.Background(), budget)
+
+	// The first request spends the whole budget before its own retries run out
+	if _, err := client.Do(ctx, &Request{Method: "GET", Path: "/test"}); !errors.Is(err, ErrRetryBudgetExhausted) {
+		t.Fatalf("Expected ErrRetryBudgetExhausted, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts for the first request, got %d", attempts)
+	}
+
+	// Later requests fail fast after a single attempt
+	if _, err := client.Do(ctx, &Request{Method: "GET", Path: "/test"}); !errors.Is(err, ErrRetryBudgetExhausted) {
+		t.Fatalf("Expected ErrRetryBudgetExhausted, got %v", err)
+	}
+	if attempts != 4 {
+		t.Errorf("Expected 1 more attempt once the budget is spent, got %d total", attempts)
+	}
+	if budget.Remaining() != 0 {
+		t.Errorf("Expected budget to be spent, %d left", budget.Remaining())
+	}
+}
+
+func TestRetryBudgetConcurrentTake(t *testing.T) {
+	budget := NewRetryBudget(50)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	granted := 0
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if budget.Take() {
+				mu.Lock()
+				granted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if granted != 50 {
+		t.Errorf("Expected exactly 50 retries granted, got %d", granted)
+	}
+
+	var unlimited *RetryBudget
+	if !unlimited.Take() {
+		t.Error("a nil budget should never run out")
+	}
+}
+
+func TestClientUserAgent(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(server.URL).Do(context.Background(), &Request{Method: "GET", Path: "/"})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	resp.Body.Close()
+	if got != "AurumCode/"+Version {
+		t.Errorf("default User-Agent = %q, want %q", got, "AurumCode/"+Version)
+	}
+
+	resp, err = NewClient(server.URL).WithUserAgent("custom/1.0").Do(context.Background(), &Request{Method: "GET", Path: "/"})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	resp.Body.Close()
+	if got != "custom/1.0" {
+		t.Errorf("User-Agent = %q, want custom/1.0", got)
+	}
+}
+
+func TestClientDoRecordsSpan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	recorder := tracing.NewRecorder()
+	ctx := tracing.WithTracer(context.Background(), recorder)
+
+	resp, err := NewClient(server.URL).Do(ctx, &Request{Method: "POST", Path: "/v1/chat"})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	resp.Body.Close()
+
+	names := recorder.Names()
+	if len(names) != 1 || names[0] != "http POST /v1/chat" {
+		t.Errorf("Expected one span for the request, got %v", names)
+	}
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := NewClientWithOptions(server.URL, ClientOptions{
+		MaxRetries:     5,
+		InitialBackoff: 2 * time.Second,
+		MaxBackoff:     20 * time.Second,
+		Timeout:        5 * time.Second,
+	}).WithClock(clock)
+
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("Expected 5s timeout, got %v", client.httpClient.Timeout)
+	}
+
+	if _, err := client.Do(context.Background(), &Request{Method: "GET", Path: "/test"}); err == nil {
+		t.Fatal("Expected max retries error")
+	}
+
+	want := []time.Duration{2 * time.Second, 8 * time.Second, 18 * time.Second, 20 * time.Second, 20 * time.Second}
+	waits := clock.Waits()
+	if len(waits) != len(want) {
+		t.Fatalf("Expected %d backoff waits, got %v", len(want), waits)
+	}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Errorf("wait %d = %v, want %v", i, waits[i], want[i])
+		}
+	}
+}
+
+func TestNewClientWithOptions_Defaults(t *testing.T) {
+	client := NewClientWithOptions("http://example.com", ClientOptions{})
+	defaults := DefaultClientOptions()
+
+	if client.maxRetries != defaults.MaxRetries || client.initialBackoff != defaults.InitialBackoff ||
+		client.maxBackoff != defaults.MaxBackoff || client.timeout != defaults.Timeout {
+		t.Errorf("Zero options should use defaults, got %+v", client)
+	}
+
+	if noRetry := NewClientWithOptions("http://example.com", ClientOptions{MaxRetries: -1}); noRetry.maxRetries != 0 {
+		t.Errorf("Negative MaxRetries should disable retries, got %d", noRetry.maxRetries)
+	}
+}
+
+func TestClientDoStream_BodyOutlivesTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("data: first\n\n"))
+		w.(http.Flusher).Flush()
+		time.Sleep(150 * time.Millisecond)
+		w.Write([]byte("data: second\n\n"))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(server.URL, ClientOptions{Timeout: 50 * time.Millisecond})
+
+	resp, err := client.DoStream(context.Background(), &Request{Method: "GET", Path: "/stream"})
+	if err != nil {
+		t.Fatalf("DoStream failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var events []string
+	err = ReadSSE(resp.Body, func(data []byte) error {
+		events = append(events, string(data))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream was cut off: %v", err)
+	}
+	if strings.Join(events, ",") != "first,second" {
+		t.Errorf("Expected both events, got %v", events)
+	}
+}
+
+func TestClientDoStream_BoundsResponseHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(server.URL, ClientOptions{Timeout: 50 * time.Millisecond, MaxRetries: -1})
+
+	if _, err := client.DoStream(context.Background(), &Request{Method: "GET", Path: "/stream"}); err == nil {
+		t.Error("Expected the slow response headers to time out")
+	}
+}
+
+func TestReadSSE(t *testing.T) {
+	stream := ": keep-alive\n\n" +
+		"data: {\"a\":1}\n\n" +
+		"event: message\n" +
+		"data: line one\n" +
+		"data: line two\n\n" +
+		"data: [DONE]\n\n" +
+		"data: after done\n\n"
+
+	var events []string
+	err := ReadSSE(strings.NewReader(stream), func(data []byte) error {
+		events = append(events, string(data))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadSSE failed: %v", err)
+	}
+
+	want := []string{`{"a":1}`, "line one\nline two"}
+	if strings.Join(events, "|") != strings.Join(want, "|") {
+		t.Errorf("events = %q, want %q", events, want)
+	}
+}
+
+func TestReadSSE_StopsOnCallbackError(t *testing.T) {
+	stop := errors.New("stop")
+	calls := 0
+	err := ReadSSE(strings.NewReader("data: 1\n\ndata: 2\n\n"), func(data []byte) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Expected to stop after the first event, got %v after %d calls", err, calls)
+	}
+
+	// A final event without a trailing blank line is still delivered
+	var last string
+	ReadSSE(strings.NewReader("data: tail"), func(data []byte) error {
+		last = string(data)
+		return nil
+	})
+	if last != "tail" {
+		t.Errorf("Expected the unterminated event, got %q", last)
+	}
+}
+
+func TestReadSSEFinished(t *testing.T) {
+	ignore := func(data []byte) error { return nil }
+
+	done, err := ReadSSEFinished(strings.NewReader("data: 1\n\ndata: [DONE]\n\n"), ignore)
+	if err != nil || !done {
+		t.Errorf("Expected a finished stream, got done=%v err=%v", done, err)
+	}
+
+	done, err = ReadSSEFinished(strings.NewReader("data: 1\n\ndata: 2\n"), ignore)
+	if err != nil || done {
+		t.Errorf("Expected a stream cut off before [DONE], got done=%v err=%v", done, err)
+	}
+}
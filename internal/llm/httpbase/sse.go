@@ -0,0 +1,61 @@
+package httpbase
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// maxEventSize bounds a single server-sent event
+const maxEventSize = 1 << 20
+
+// ReadSSE reads server-sent events from r and calls fn with the data of
+// each event until r ends, fn fails, or the OpenAI-style "[DONE]" sentinel
+// arrives. Comments and fields other than data are ignored.
+func ReadSSE(r io.Reader, fn func(data []byte) error) error {
+	_, err := ReadSSEFinished(r, fn)
+	return err
+}
+
+// ReadSSEFinished is like ReadSSE and also reports whether the "[DONE]"
+// sentinel arrived. A stream that ends without it may have been cut off.
+func ReadSSEFinished(r io.Reader, fn func(data []byte) error) (bool, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+
+	var data [][]byte
+	dispatch := func() (bool, error) {
+		if len(data) == 0 {
+			return false, nil
+		}
+		event := bytes.Join(data, []byte("\n"))
+		data = nil
+
+		if string(event) == "[DONE]" {
+			return true, nil
+		}
+		return false, fn(event)
+	}
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		if len(line) == 0 {
+			done, err := dispatch()
+			if done || err != nil {
+				return done, err
+			}
+			continue
+		}
+
+		if value, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+			data = append(data, append([]byte(nil), bytes.TrimPrefix(value, []byte(" "))...))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read event stream: %w", err)
+	}
+
+	return dispatch()
+}
@@ -0,0 +1,11 @@
+package httpbase
+
+// Version identifies the AurumCode build in the default user agent. Release
+// builds set it with
+// -ldflags "-X github.com/Mpaape/AurumCode/internal/llm/httpbase.Version=1.2.3".
+var Version = "1.0"
+
+// DefaultUserAgent returns the user agent sent when none is configured
+func DefaultUserAgent() string {
+	return "AurumCode/" + Version
+}
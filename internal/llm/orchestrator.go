@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/Mpaape/AurumCode/internal/llm/cost"
+	"github.com/Mpaape/AurumCode/internal/tracing"
+	"strings"
 	"time"
 )
 
@@ -17,6 +19,12 @@ var (
 
 	// ErrAllProvidersFailed indicates all providers in the chain failed
 	ErrAllProvidersFailed = errors.New("all providers failed")
+
+	// ErrUnreachable indicates no provider endpoint answered a reachability probe
+	ErrUnreachable = errors.New("no provider reachable")
+
+	// ErrCircuitOpen indicates a provider was skipped after repeated failures
+	ErrCircuitOpen = errors.New("circuit open")
 )
 
 // Orchestrator manages LLM provider chains with fallback and budget enforcement
@@ -25,88 +33,279 @@ type Orchestrator struct {
 	fallbacks []Provider
 	tracker   *cost.Tracker
 	estimator *Estimator
+	cache     Cache
+	strategy  Strategy
+	breaker   *circuitBreaker
+	modelKey  string
 }
 
 // NewOrchestrator creates a new orchestrator with a primary provider and optional fallbacks
 func NewOrchestrator(primary Provider, fallbacks []Provider, tracker *cost.Tracker) *Orchestrator {
+	return NewOrchestratorWithStrategy(primary, fallbacks, tracker, nil)
+}
+
+// NewOrchestratorWithStrategy creates an orchestrator whose strategy picks
+// the provider tried first; the rest stay fallbacks in order. A nil strategy
+// always starts with the primary.
+func NewOrchestratorWithStrategy(primary Provider, fallbacks []Provider, tracker *cost.Tracker, strategy Strategy) *Orchestrator {
 	return &Orchestrator{
 		primary:   primary,
 		fallbacks: fallbacks,
 		tracker:   tracker,
 		estimator: NewEstimator(),
+		strategy:  strategy,
 	}
 }
 
+// WithModelKey sets the model key used for requests that do not name one,
+// so budgets are checked and charged against a priced model
+func (o *Orchestrator) WithModelKey(key string) *Orchestrator {
+	o.modelKey = key
+	return o
+}
+
+// WithCache serves repeated requests from cache instead of the providers.
+// Cache hits are free: no provider is called and nothing is charged.
+func (o *Orchestrator) WithCache(cache Cache) *Orchestrator {
+	o.cache = cache
+	return o
+}
+
+// WithCircuitBreaker skips a provider for cooldown after threshold
+// consecutive failures, so requests go straight to the fallbacks
+func (o *Orchestrator) WithCircuitBreaker(threshold int, cooldown time.Duration) *Orchestrator {
+	o.breaker = newCircuitBreaker(threshold, cooldown)
+	return o
+}
+
 // Complete executes a completion request with fallback chain and budget enforcement
 func (o *Orchestrator) Complete(ctx context.Context, prompt string, opts Options) (Response, error) {
 	if o.primary == nil && len(o.fallbacks) == 0 {
 		return Response{}, ErrNoProviders
 	}
+	opts = o.withModelKey(opts)
 
-	// Build provider chain: primary + fallbacks
-	providers := []Provider{o.primary}
-	providers = append(providers, o.fallbacks...)
-
-	// Estimate tokens
-	tokensIn, err := o.estimator.EstimateTokens(prompt)
-	if err != nil {
-		// Use heuristic if estimation fails
-		tokensIn = len(prompt) / 4
+	var cacheKey string
+	if o.cache != nil {
+		cacheKey = CacheKey(prompt, opts)
+		if resp, ok := o.cache.Get(cacheKey); ok {
+			return resp, nil
+		}
 	}
 
-	tokensOut := opts.MaxTokens
-	if tokensOut == 0 {
-		tokensOut = 1000 // reasonable default estimate
-	}
+	tokensIn, tokensOut, model := o.plan(prompt, opts)
 
 	var lastErr error
+	var skipped []string
 
 	// Try each provider in order
-	for i, provider := range providers {
-		if provider == nil {
-			continue
-		}
+	for _, l := range o.chain() {
+		provider := l.provider
 
 		// Check budget before attempting
-		model := opts.ModelKey
-		if model == "" {
-			model = "default"
+		if err := o.checkBudget(provider, opts, tokensIn, tokensOut, model); err != nil {
+			return Response{}, err
 		}
 
-		if o.tracker != nil && !o.tracker.Allow(tokensIn, tokensOut, model) {
-			return Response{}, fmt.Errorf("%w: insufficient budget for %s", ErrBudgetExceeded, provider.Name())
+		if !o.breaker.allow(l.pos) {
+			skipped = append(skipped, provider.Name())
+			continue
 		}
 
 		// Execute with timeout
 		resp, err := o.executeWithTimeout(ctx, provider, prompt, opts)
+		o.recordOutcome(ctx, l, err)
 		if err != nil {
 			lastErr = fmt.Errorf("provider %s failed: %w", provider.Name(), err)
-
-			// If this is not the last provider, continue to next
-			if i < len(providers)-1 {
-				continue
-			}
-
-			// All providers failed
-			return Response{}, fmt.Errorf("%w: %v", ErrAllProvidersFailed, lastErr)
+			continue
 		}
 
 		// Success - record spending
-		if o.tracker != nil {
-			if err := o.tracker.Spend(resp.TokensIn, resp.TokensOut, resp.Model); err != nil {
-				// Log but don't fail on tracking error
-				fmt.Printf("warning: failed to record spending: %v\n", err)
-			}
+		o.recordSpend(opts, resp.TokensIn, resp.TokensOut, resp.Model)
+
+		if o.cache != nil {
+			o.cache.Set(cacheKey, resp)
 		}
 
 		return resp, nil
 	}
 
-	return Response{}, fmt.Errorf("%w: %v", ErrAllProvidersFailed, lastErr)
+	return Response{}, chainError(lastErr, skipped)
+}
+
+// link is a provider in the chain and its configured position, which keys
+// its circuit
+type link struct {
+	provider Provider
+	pos      int
+}
+
+// chain returns the providers in the order to try them: the strategy's
+// pick, or the primary, followed by the remaining providers
+func (o *Orchestrator) chain() []link {
+	var links []link
+	for i, p := range append([]Provider{o.primary}, o.fallbacks...) {
+		if p != nil {
+			links = append(links, link{provider: p, pos: i})
+		}
+	}
+	if o.strategy == nil || len(links) < 2 {
+		return links
+	}
+
+	providers := make([]Provider, len(links))
+	for i, l := range links {
+		providers[i] = l.provider
+	}
+
+	// Positions are compared rather than providers, which may not be comparable
+	picked := o.strategy.Pick(providers)
+	if picked <= 0 || picked >= len(links) {
+		return links
+	}
+	ordered := append([]link{links[picked]}, links[:picked]...)
+	return append(ordered, links[picked+1:]...)
+}
+
+// chainError reports why no provider answered: the last provider error, with
+// the providers skipped by an open circuit added to it
+func chainError(lastErr error, skipped []string) error {
+	if len(skipped) == 0 {
+		return fmt.Errorf("%w: %w", ErrAllProvidersFailed, lastErr)
+	}
+
+	skipErr := fmt.Errorf("%w for %s", ErrCircuitOpen, strings.Join(skipped, ", "))
+	if lastErr == nil {
+		return fmt.Errorf("%w: %w", ErrAllProvidersFailed, skipErr)
+	}
+	return fmt.Errorf("%w: %w (%w)", ErrAllProvidersFailed, lastErr, skipErr)
+}
+
+// recordOutcome feeds a call's result to the circuit breaker. Failures
+// caused by the caller's own context ending are not held against the provider.
+func (o *Orchestrator) recordOutcome(ctx context.Context, l link, err error) {
+	switch {
+	case err == nil:
+		o.breaker.success(l.pos)
+	case ctx.Err() == nil:
+		o.breaker.failure(l.pos)
+	default:
+		o.breaker.release(l.pos)
+	}
+}
+
+// checkBudget returns ErrBudgetExceeded if the tracker cannot afford a request
+func (o *Orchestrator) checkBudget(provider Provider, opts Options, tokensIn, tokensOut int, model string) error {
+	if o.tracker == nil || o.tracker.AllowLabel(opts.BudgetLabel, tokensIn, tokensOut, model) {
+		return nil
+	}
+	if opts.BudgetLabel != "" {
+		return fmt.Errorf("%w: insufficient budget for %s (%s)", ErrBudgetExceeded, provider.Name(), opts.BudgetLabel)
+	}
+	return fmt.Errorf("%w: insufficient budget for %s", ErrBudgetExceeded, provider.Name())
+}
+
+// withModelKey fills in the orchestrator's model key when opts has none
+func (o *Orchestrator) withModelKey(opts Options) Options {
+	if opts.ModelKey == "" {
+		opts.ModelKey = o.modelKey
+	}
+	return opts
+}
+
+// recordSpend charges a finished request to the tracker. Responses naming a
+// model without a price, e.g. a dated snapshot, are charged at the price of
+// the requested model key.
+func (o *Orchestrator) recordSpend(opts Options, tokensIn, tokensOut int, model string) {
+	if o.tracker == nil {
+		return
+	}
+	if !o.tracker.Priced(model) {
+		model = budgetModel(opts)
+	}
+	if err := o.tracker.SpendLabel(opts.BudgetLabel, tokensIn, tokensOut, model); err != nil {
+		// Log but don't fail on tracking error
+		fmt.Printf("warning: failed to record spending: %v\n", err)
+	}
+}
+
+// CostEstimate is the projected token usage and price of planned LLM calls
+type CostEstimate struct {
+	Calls     int     `json:"calls"`
+	TokensIn  int     `json:"tokens_in"`
+	TokensOut int     `json:"tokens_out"`
+	USD       float64 `json:"usd"`
+
+	// Unpriced counts calls whose model has no configured price; their cost
+	// is not included in USD
+	Unpriced int `json:"unpriced,omitempty"`
+}
+
+// Add accumulates another estimate into e
+func (e *CostEstimate) Add(other CostEstimate) {
+	e.Calls += other.Calls
+	e.TokensIn += other.TokensIn
+	e.TokensOut += other.TokensOut
+	e.USD += other.USD
+	e.Unpriced += other.Unpriced
+}
+
+// Estimate projects the cost of Complete(prompt, opts) without calling a
+// provider. Output tokens are assumed to reach opts.MaxTokens.
+func (o *Orchestrator) Estimate(prompt string, opts Options) CostEstimate {
+	opts = o.withModelKey(opts)
+	tokensIn, tokensOut, model := o.plan(prompt, opts)
+
+	estimate := CostEstimate{Calls: 1, TokensIn: tokensIn, TokensOut: tokensOut}
+	if o.tracker == nil {
+		estimate.Unpriced = 1
+		return estimate
+	}
+
+	usd, priced := o.tracker.Estimate(tokensIn, tokensOut, model)
+	if !priced {
+		estimate.Unpriced = 1
+	}
+	estimate.USD = usd
+	return estimate
+}
+
+// plan returns the estimated token counts and budget model key for a request
+func (o *Orchestrator) plan(prompt string, opts Options) (int, int, string) {
+	tokensIn, err := o.estimator.EstimateTokens(prompt)
+	if err != nil {
+		// Use heuristic if estimation fails
+		tokensIn = len(prompt) / 4
+	}
+
+	tokensOut := opts.MaxTokens
+	if tokensOut == 0 {
+		tokensOut = 1000 // reasonable default estimate
+	}
+
+	return tokensIn, tokensOut, budgetModel(opts)
+}
+
+// budgetModel returns the model key a request is budgeted under
+func budgetModel(opts Options) string {
+	if opts.ModelKey == "" {
+		return "default"
+	}
+	return opts.ModelKey
 }
 
 // executeWithTimeout wraps provider execution with context timeout
-func (o *Orchestrator) executeWithTimeout(ctx context.Context, provider Provider, prompt string, opts Options) (Response, error) {
+func (o *Orchestrator) executeWithTimeout(ctx context.Context, provider Provider, prompt string, opts Options) (resp Response, err error) {
+	ctx, span := tracing.Start(ctx, "llm."+provider.Name())
+	defer func() { span.End(err) }()
+
+	// Bound each attempt so a hanging provider leaves time for the fallbacks
+	if opts.PerCallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.PerCallTimeout)
+		defer cancel()
+	}
+
 	// Create timeout context if not already set
 	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
 		var cancel context.CancelFunc
@@ -123,8 +322,13 @@ func (o *Orchestrator) executeWithTimeout(ctx context.Context, provider Provider
 	resultCh := make(chan result, 1)
 
 	go func() {
-		resp, err := provider.Complete(prompt, opts)
-		resultCh <- result{resp: resp, err: err}
+		var res result
+		if completer, ok := provider.(ContextCompleter); ok {
+			res.resp, res.err = completer.CompleteContext(ctx, prompt, opts)
+		} else {
+			res.resp, res.err = provider.Complete(prompt, opts)
+		}
+		resultCh <- res
 	}()
 
 	select {
@@ -135,6 +339,36 @@ func (o *Orchestrator) executeWithTimeout(ctx context.Context, provider Provider
 	}
 }
 
+// Probe checks that at least one provider in the chain is reachable.
+// Providers that do not implement Pinger are assumed reachable.
+func (o *Orchestrator) Probe(ctx context.Context) error {
+	if o.primary == nil && len(o.fallbacks) == 0 {
+		return ErrNoProviders
+	}
+
+	providers := append([]Provider{o.primary}, o.fallbacks...)
+
+	var errs []error
+	for _, provider := range providers {
+		if provider == nil {
+			continue
+		}
+
+		pinger, ok := provider.(Pinger)
+		if !ok {
+			return nil
+		}
+
+		err := pinger.Ping(ctx)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name(), err))
+	}
+
+	return fmt.Errorf("%w: %w", ErrUnreachable, errors.Join(errs...))
+}
+
 // GetProviderChain returns the current provider chain (primary + fallbacks)
 func (o *Orchestrator) GetProviderChain() []string {
 	names := []string{}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/Mpaape/AurumCode/internal/llm/cost"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -217,9 +218,11 @@ type slowProvider struct {
 	name     string
 	delay    time.Duration
 	response Response
+	calls    atomic.Int32
 }
 
 func (s *slowProvider) Complete(prompt string, opts Options) (Response, error) {
+	s.calls.Add(1)
 	time.Sleep(s.delay)
 	return s.response, nil
 }
@@ -272,6 +275,143 @@ func TestOrchestratorComplete_ContextTimeout(t *testing.T) {
 	}
 }
 
+func TestOrchestratorComplete_PerCallTimeoutFallsBack(t *testing.T) {
+	slow := &slowProvider{name: "slow", delay: 500 * time.Millisecond}
+	fallback := &mockProvider{name: "fallback", response: Response{Text: "from fallback"}}
+	orch := NewOrchestrator(slow, []Provider{fallback}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := orch.Complete(ctx, "test prompt", Options{PerCallTimeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	if resp.Text != "from fallback" {
+		t.Errorf("Expected the fallback's response, got %q", resp.Text)
+	}
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Errorf("Expected the slow provider to be abandoned promptly, took %v", elapsed)
+	}
+}
+
+func TestOrchestratorComplete_CircuitBreakerSkipsFailingProvider(t *testing.T) {
+	slow := &slowProvider{name: "slow", delay: 500 * time.Millisecond}
+	fallback := &mockProvider{name: "fallback", response: Response{Text: "from fallback"}}
+	orch := NewOrchestrator(slow, []Provider{fallback}, nil).WithCircuitBreaker(2, time.Minute)
+	opts := Options{PerCallTimeout: 20 * time.Millisecond}
+
+	// Two timeouts open the circuit
+	for i := 0; i < 2; i++ {
+		if _, err := orch.Complete(context.Background(), "test prompt", opts); err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+	}
+
+	start := time.Now()
+	resp, err := orch.Complete(context.Background(), "test prompt", opts)
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	if resp.Text != "from fallback" {
+		t.Errorf("Expected the fallback's response, got %q", resp.Text)
+	}
+	if calls := slow.calls.Load(); calls != 2 {
+		t.Errorf("Expected the open circuit to skip the slow provider, got %d calls", calls)
+	}
+	if elapsed := time.Since(start); elapsed >= 20*time.Millisecond {
+		t.Errorf("Expected the fallback to be used without waiting, took %v", elapsed)
+	}
+}
+
+func TestOrchestratorComplete_CircuitOpenForAllProviders(t *testing.T) {
+	primary := &mockProvider{name: "primary", err: errors.New("down")}
+	orch := NewOrchestrator(primary, nil, nil).WithCircuitBreaker(1, time.Minute)
+
+	orch.Complete(context.Background(), "test prompt", Options{})
+	_, err := orch.Complete(context.Background(), "test prompt", Options{})
+
+	if !errors.Is(err, ErrAllProvidersFailed) || !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected an open-circuit failure, got %v", err)
+	}
+	if primary.callCount != 1 {
+		t.Errorf("Expected the provider to be skipped, got %d calls", primary.callCount)
+	}
+}
+
+func TestOrchestratorComplete_SkippedLastProviderKeepsRealError(t *testing.T) {
+	primary := &mockProvider{name: "primary", err: errors.New("rate limited")}
+	fallback := &mockProvider{name: "fallback", response: Response{Text: "from fallback"}}
+	orch := NewOrchestrator(primary, []Provider{fallback}, nil).WithCircuitBreaker(1, time.Minute)
+
+	// Open the fallback's circuit
+	orch.breaker.failure(1)
+
+	_, err := orch.Complete(context.Background(), "test prompt", Options{})
+	if !errors.Is(err, ErrAllProvidersFailed) || !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected a failure naming the open circuit, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "rate limited") || !strings.Contains(err.Error(), "fallback") {
+		t.Errorf("Expected the primary's error and the skipped fallback, got %v", err)
+	}
+	if fallback.callCount != 0 {
+		t.Errorf("Expected the fallback to be skipped, got %d calls", fallback.callCount)
+	}
+}
+
+func TestOrchestratorComplete_SameNameSeparateCircuits(t *testing.T) {
+	east := &mockProvider{name: "openai", err: errors.New("down")}
+	west := &mockProvider{name: "openai", response: Response{Text: "from west"}}
+	orch := NewOrchestrator(east, []Provider{west}, nil).WithCircuitBreaker(1, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		resp, err := orch.Complete(context.Background(), "test prompt", Options{})
+		if err != nil || resp.Text != "from west" {
+			t.Fatalf("Expected the second endpoint to answer, got %q, %v", resp.Text, err)
+		}
+	}
+
+	if east.callCount != 1 {
+		t.Errorf("Expected the failing endpoint's circuit to open, got %d calls", east.callCount)
+	}
+}
+
+// valueProvider is a provider used by value whose type is not comparable
+type valueProvider struct {
+	name  string
+	tags  []string
+	calls *int
+}
+
+func (v valueProvider) Complete(prompt string, opts Options) (Response, error) {
+	*v.calls++
+	return Response{Text: v.name}, nil
+}
+
+func (v valueProvider) Tokens(input string) (int, error) { return len(input) / 4, nil }
+
+func (v valueProvider) Name() string { return v.name }
+
+func TestOrchestratorComplete_NonComparableProviders(t *testing.T) {
+	var aCalls, bCalls int
+	a := valueProvider{name: "a", tags: []string{"x"}, calls: &aCalls}
+	b := valueProvider{name: "b", tags: []string{"y"}, calls: &bCalls}
+	orch := NewOrchestratorWithStrategy(a, []Provider{b}, nil, &RoundRobin{}).WithCircuitBreaker(1, time.Minute)
+
+	for i := 0; i < 4; i++ {
+		if _, err := orch.Complete(context.Background(), "test prompt", Options{}); err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+	}
+
+	if aCalls != 2 || bCalls != 2 {
+		t.Errorf("Expected requests split evenly, got %d and %d", aCalls, bCalls)
+	}
+}
+
 func TestOrchestratorComplete_MultipleFallbacks(t *testing.T) {
 	primary := &mockProvider{
 		name: "primary",
@@ -466,3 +606,106 @@ func BenchmarkOrchestratorComplete(b *testing.B) {
 		orch.ResetPerRunBudget()
 	}
 }
+
+func TestOrchestratorEstimate(t *testing.T) {
+	primary := &mockProvider{name: "primary"}
+	tracker := cost.NewTracker(10.0, 100.0, map[string]cost.PriceMap{
+		"test-model": {InputPer1K: 0.01, OutputPer1K: 0.02},
+	})
+	orch := NewOrchestrator(primary, nil, tracker)
+
+	estimate := orch.Estimate(strings.Repeat("a", 4000), Options{ModelKey: "test-model", MaxTokens: 500})
+
+	if estimate.Calls != 1 || estimate.TokensIn != 1000 || estimate.TokensOut != 500 {
+		t.Errorf("unexpected estimate: %+v", estimate)
+	}
+	if estimate.USD < 0.0199 || estimate.USD > 0.0201 || estimate.Unpriced != 0 {
+		t.Errorf("USD = %v, want 0.02", estimate.USD)
+	}
+	if primary.callCount != 0 {
+		t.Error("Estimate should not call the provider")
+	}
+
+	unpriced := orch.Estimate("prompt", Options{ModelKey: "other"})
+	if unpriced.Unpriced != 1 || unpriced.USD != 0 {
+		t.Errorf("expected unpriced estimate, got %+v", unpriced)
+	}
+}
+
+// pingProvider is a mockProvider that also implements Pinger
+type pingProvider struct {
+	mockProvider
+	pingErr error
+}
+
+func (p *pingProvider) Ping(ctx context.Context) error {
+	return p.pingErr
+}
+
+func TestOrchestratorProbe(t *testing.T) {
+	down := &pingProvider{mockProvider: mockProvider{name: "down"}, pingErr: errors.New("connection refused")}
+	up := &pingProvider{mockProvider: mockProvider{name: "up"}}
+
+	if err := NewOrchestrator(down, []Provider{up}, nil).Probe(context.Background()); err != nil {
+		t.Errorf("expected reachable fallback to pass, got %v", err)
+	}
+
+	err := NewOrchestrator(down, nil, nil).Probe(context.Background())
+	if !errors.Is(err, ErrUnreachable) {
+		t.Errorf("expected ErrUnreachable, got %v", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("error should include the probe failure, got %v", err)
+	}
+
+	// Providers without Ping are assumed reachable
+	if err := NewOrchestrator(&mockProvider{name: "plain"}, nil, nil).Probe(context.Background()); err != nil {
+		t.Errorf("expected nil for provider without Ping, got %v", err)
+	}
+}
+
+func TestOrchestratorComplete_LabelBudget(t *testing.T) {
+	primary := &mockProvider{
+		name: "primary",
+		response: Response{
+			Text:      "Success",
+			TokensIn:  100,
+			TokensOut: 100,
+			Model:     "test-model",
+		},
+	}
+
+	tracker := cost.NewTracker(100.0, 100.0, map[string]cost.PriceMap{
+		"test-model": {InputPer1K: 1.0, OutputPer1K: 1.0},
+	}).
+		WithLabelBudget("acme/noisy", 0.5).
+		WithLabelBudget("acme/quiet", 5.0)
+
+	orch := NewOrchestrator(primary, nil, tracker)
+	opts := func(label string) Options {
+		return Options{ModelKey: "test-model", MaxTokens: 100, BudgetLabel: label}
+	}
+
+	// Each call costs about $0.2; the noisy repo runs out after two
+	for i := 0; i < 2; i++ {
+		if _, err := orch.Complete(context.Background(), "review", opts("acme/noisy")); err != nil {
+			t.Fatalf("call %d for noisy repo failed: %v", i, err)
+		}
+	}
+
+	_, err := orch.Complete(context.Background(), "review", opts("acme/noisy"))
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("expected ErrBudgetExceeded for noisy repo, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "acme/noisy") {
+		t.Errorf("budget error should name the label, got: %v", err)
+	}
+
+	if _, err := orch.Complete(context.Background(), "review", opts("acme/quiet")); err != nil {
+		t.Errorf("quiet repo should still have budget, got: %v", err)
+	}
+
+	if primary.callCount != 3 {
+		t.Errorf("expected 3 provider calls, got %d", primary.callCount)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"net/http"
 )
 
+var _ llm.ContextCompleter = (*Provider)(nil)
+
 // Provider is the Anthropic Claude LLM provider
 type Provider struct {
 	baseURL string
@@ -24,6 +26,12 @@ func NewProvider(apiKey string) *Provider {
 	}
 }
 
+// WithUserAgent sets the User-Agent header sent to the API
+func (p *Provider) WithUserAgent(userAgent string) *Provider {
+	p.client.WithUserAgent(userAgent)
+	return p
+}
+
 // Name returns the provider name
 func (p *Provider) Name() string {
 	return "anthropic"
@@ -31,6 +39,12 @@ func (p *Provider) Name() string {
 
 // Complete sends a completion request to Anthropic
 func (p *Provider) Complete(prompt string, opts llm.Options) (llm.Response, error) {
+	return p.CompleteContext(context.Background(), prompt, opts)
+}
+
+// CompleteContext sends a completion request to Anthropic, honouring ctx for
+// cancellation and any retry budget attached to it
+func (p *Provider) CompleteContext(ctx context.Context, prompt string, opts llm.Options) (llm.Response, error) {
 	model := opts.ModelKey
 	if model == "" {
 		model = "claude-3-5-sonnet-20241022"
@@ -51,7 +65,6 @@ func (p *Provider) Complete(prompt string, opts llm.Options) (llm.Response, erro
 		},
 	}
 
-	ctx := context.Background()
 	resp, err := p.client.Do(ctx, req)
 	if err != nil {
 		return llm.Response{}, fmt.Errorf("anthropic request failed: %w", err)
@@ -1,40 +1,62 @@
 package litellm
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/internal/llm/httpbase"
 	"io"
 	"net/http"
 	"time"
 )
 
+var _ llm.ContextCompleter = (*Provider)(nil)
+
 // Provider implements LiteLLM proxy provider (OpenAI-compatible)
 type Provider struct {
-	apiKey  string
-	baseURL string
-	model   string
-	client  *http.Client
+	apiKey    string
+	baseURL   string
+	model     string
+	userAgent string
+	client    *http.Client     // Reachability probes
+	api       *httpbase.Client // Completions and streams, with retries
 }
 
 // NewProvider creates a new LiteLLM provider
 func NewProvider(apiKey, baseURL, model string) *Provider {
 	return &Provider{
-		apiKey:  apiKey,
-		baseURL: baseURL,
-		model:   model,
+		apiKey:    apiKey,
+		baseURL:   baseURL,
+		model:     model,
+		userAgent: httpbase.DefaultUserAgent(),
 		client: &http.Client{
 			Timeout: 60 * time.Second,
 		},
+		api: httpbase.NewClientWithOptions(baseURL, httpbase.ClientOptions{Timeout: 60 * time.Second}),
 	}
 }
 
+// WithUserAgent sets the User-Agent header sent to the proxy
+func (p *Provider) WithUserAgent(userAgent string) *Provider {
+	if userAgent != "" {
+		p.userAgent = userAgent
+		p.api.WithUserAgent(userAgent)
+	}
+	return p
+}
+
 type completionRequest struct {
-	Model       string    `json:"model"`
-	Messages    []message `json:"messages"`
-	Temperature float64   `json:"temperature"`
-	MaxTokens   int       `json:"max_tokens,omitempty"`
+	Model         string         `json:"model"`
+	Messages      []message      `json:"messages"`
+	Temperature   float64        `json:"temperature"`
+	MaxTokens     int            `json:"max_tokens,omitempty"`
+	Stream        bool           `json:"stream,omitempty"`
+	StreamOptions *streamOptions `json:"stream_options,omitempty"`
+}
+
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
 }
 
 type message struct {
@@ -63,9 +85,8 @@ type usage struct {
 	TotalTokens      int `json:"total_tokens"`
 }
 
-// Complete sends a completion request to LiteLLM
-func (p *Provider) Complete(prompt string, opts llm.Options) (llm.Response, error) {
-	// Build request
+// newCompletionRequest builds the chat request body for prompt
+func (p *Provider) newCompletionRequest(prompt string, opts llm.Options) completionRequest {
 	reqBody := completionRequest{
 		Model: p.model,
 		Messages: []message{
@@ -83,23 +104,28 @@ func (p *Provider) Complete(prompt string, opts llm.Options) (llm.Response, erro
 		}
 	}
 
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return llm.Response{}, fmt.Errorf("failed to marshal request: %w", err)
-	}
+	return reqBody
+}
 
-	// Create HTTP request
-	url := p.baseURL + "/chat/completions"
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return llm.Response{}, fmt.Errorf("failed to create request: %w", err)
-	}
+// Complete sends a completion request to LiteLLM
+func (p *Provider) Complete(prompt string, opts llm.Options) (llm.Response, error) {
+	return p.CompleteContext(context.Background(), prompt, opts)
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+// CompleteContext sends a completion request to LiteLLM, honouring ctx for
+// cancellation and any retry budget attached to it
+func (p *Provider) CompleteContext(ctx context.Context, prompt string, opts llm.Options) (llm.Response, error) {
+	req := &httpbase.Request{
+		Method: http.MethodPost,
+		Path:   "/chat/completions",
+		Headers: map[string]string{
+			"Authorization": "Bearer " + p.apiKey,
+		},
+		Body: p.newCompletionRequest(prompt, opts),
+	}
 
 	// Send request
-	resp, err := p.client.Do(req)
+	resp, err := p.api.Do(ctx, req)
 	if err != nil {
 		return llm.Response{}, fmt.Errorf("request failed: %w", err)
 	}
@@ -133,6 +159,35 @@ func (p *Provider) Complete(prompt string, opts llm.Options) (llm.Response, erro
 	}, nil
 }
 
+// Ping checks that the LiteLLM proxy answers at baseURL. Any HTTP response
+// below 500 counts as reachable, including authentication errors.
+func (p *Provider) Ping(ctx context.Context) error {
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/models", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("User-Agent", p.userAgent)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("LiteLLM unreachable at %s: %w", p.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("LiteLLM unavailable at %s (status %d)", p.baseURL, resp.StatusCode)
+	}
+
+	return nil
+}
+
 // Tokens estimates token count (approximate)
 func (p *Provider) Tokens(input string) (int, error) {
 	// Rough approximation: 1 token â‰ˆ 4 characters
@@ -1,8 +1,12 @@
 package litellm
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/internal/llm/httpbase"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -199,3 +203,151 @@ func TestProviderComplete_InvalidJSON(t *testing.T) {
 		t.Fatal("expected error for invalid JSON")
 	}
 }
+
+func TestProviderPing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models" {
+			t.Errorf("expected /models, got %s", r.URL.Path)
+		}
+		// Auth failures still prove the endpoint is up
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider := NewProvider("test-key", server.URL, "test-model")
+	if err := provider.Ping(context.Background()); err != nil {
+		t.Errorf("expected reachable endpoint, got %v", err)
+	}
+}
+
+func TestProviderPing_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	baseURL := server.URL
+	server.Close()
+
+	provider := NewProvider("test-key", baseURL, "test-model")
+	if err := provider.Ping(context.Background()); err == nil {
+		t.Error("expected error for closed endpoint")
+	}
+}
+
+func TestProviderPing_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	provider := NewProvider("test-key", server.URL, "test-model")
+	if err := provider.Ping(context.Background()); err == nil {
+		t.Error("expected error for 502 response")
+	}
+}
+
+func TestProviderUserAgent(t *testing.T) {
+	var gotUserAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+		json.NewEncoder(w).Encode(completionResponse{
+			Choices: []choice{{Message: message{Role: "assistant", Content: "ok"}}},
+		})
+	}))
+	defer server.Close()
+
+	if _, err := NewProvider("key", server.URL, "model").Complete("hi", llm.Options{}); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if gotUserAgent != httpbase.DefaultUserAgent() {
+		t.Errorf("default User-Agent = %q, want %q", gotUserAgent, httpbase.DefaultUserAgent())
+	}
+
+	p := NewProvider("key", server.URL, "model").WithUserAgent("aurumcode-app/2.1")
+	if _, err := p.Complete("hi", llm.Options{}); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if gotUserAgent != "aurumcode-app/2.1" {
+		t.Errorf("configured User-Agent = %q, want aurumcode-app/2.1", gotUserAgent)
+	}
+
+	if err := p.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if gotUserAgent != "aurumcode-app/2.1" {
+		t.Errorf("Ping User-Agent = %q, want aurumcode-app/2.1", gotUserAgent)
+	}
+}
+
+func TestProviderCompleteStream(t *testing.T) {
+	var req completionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(`data: {"choices":[{"delta":{"role":"assistant","content":"Hi"}}]}` + "\n\n"))
+		w.Write([]byte(`data: {"choices":[{"delta":{"content":" there"},"finish_reason":"stop"}]}` + "\n\n"))
+		w.Write([]byte(`data: {"choices":[],"usage":{"prompt_tokens":7,"completion_tokens":3}}` + "\n\n"))
+		w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	provider := NewProvider("test-key", server.URL, "test-model")
+
+	chunks, err := provider.CompleteStream(context.Background(), "hello", llm.Options{System: "be brief"})
+	if err != nil {
+		t.Fatalf("CompleteStream failed: %v", err)
+	}
+
+	var text string
+	var last llm.StreamChunk
+	for chunk := range chunks {
+		text += chunk.Text
+		last = chunk
+	}
+
+	if text != "Hi there" {
+		t.Errorf("expected 'Hi there', got %q", text)
+	}
+	if !last.Done || last.TokensIn != 7 || last.TokensOut != 3 || last.Model != "test-model" {
+		t.Errorf("unexpected final chunk: %+v", last)
+	}
+	if !req.Stream || req.StreamOptions == nil || !req.StreamOptions.IncludeUsage || len(req.Messages) != 2 {
+		t.Errorf("unexpected stream request: %+v", req)
+	}
+}
+
+func TestProviderCompleteStream_TruncatedBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(`data: {"choices":[{"delta":{"content":"Hi"}}]}` + "\n\n"))
+		// The connection drops part way through the next event
+		w.Write([]byte(`data: {"choices":[{"delta":{"content":" there"}}]}` + "\n"))
+	}))
+	defer server.Close()
+
+	provider := NewProvider("test-key", server.URL, "test-model")
+
+	chunks, err := provider.CompleteStream(context.Background(), "hello", llm.Options{})
+	if err != nil {
+		t.Fatalf("CompleteStream failed: %v", err)
+	}
+
+	var last llm.StreamChunk
+	for chunk := range chunks {
+		last = chunk
+	}
+
+	if last.Done || !errors.Is(last.Err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected a truncated stream to fail, got %+v", last)
+	}
+}
+
+func TestProviderCompleteStream_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider := NewProvider("invalid-key", server.URL, "test-model")
+
+	if _, err := provider.CompleteStream(context.Background(), "hello", llm.Options{}); err == nil {
+		t.Fatal("expected error for invalid API key")
+	}
+}
@@ -0,0 +1,106 @@
+package litellm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/internal/llm/httpbase"
+)
+
+var _ llm.Streamer = (*Provider)(nil)
+
+// streamEvent is one server-sent event of a streamed chat completion
+type streamEvent struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Delta        message `json:"delta"`
+		FinishReason string  `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *usage `json:"usage"`
+}
+
+// CompleteStream streams a chat completion from the LiteLLM proxy as
+// server-sent events
+func (p *Provider) CompleteStream(ctx context.Context, prompt string, opts llm.Options) (<-chan llm.StreamChunk, error) {
+	reqBody := p.newCompletionRequest(prompt, opts)
+	reqBody.Stream = true
+	reqBody.StreamOptions = &streamOptions{IncludeUsage: true}
+
+	req := &httpbase.Request{
+		Method: http.MethodPost,
+		Path:   "/chat/completions",
+		Headers: map[string]string{
+			"Accept":        "text/event-stream",
+			"Authorization": "Bearer " + p.apiKey,
+		},
+		Body: reqBody,
+	}
+
+	// Streams can run longer than the client timeout; only headers are bounded
+	resp, err := p.api.DoStream(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("LiteLLM API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	chunks := make(chan llm.StreamChunk)
+
+	go func() {
+		defer close(chunks)
+		defer resp.Body.Close()
+
+		send := func(chunk llm.StreamChunk) error {
+			select {
+			case chunks <- chunk:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		final := llm.StreamChunk{Done: true, Model: p.model}
+		sawDone, err := httpbase.ReadSSEFinished(resp.Body, func(data []byte) error {
+			var event streamEvent
+			if err := json.Unmarshal(data, &event); err != nil {
+				return fmt.Errorf("failed to parse stream event: %w", err)
+			}
+
+			if event.Model != "" {
+				final.Model = event.Model
+			}
+			if event.Usage != nil {
+				final.TokensIn = event.Usage.PromptTokens
+				final.TokensOut = event.Usage.CompletionTokens
+			}
+			if len(event.Choices) == 0 {
+				return nil
+			}
+			if reason := event.Choices[0].FinishReason; reason != "" {
+				final.FinishReason = reason
+			}
+			if text := event.Choices[0].Delta.Content; text != "" {
+				return send(llm.StreamChunk{Text: text})
+			}
+			return nil
+		})
+		// A body that ends without [DONE] or a finish reason was cut off
+		if err == nil && !sawDone && final.FinishReason == "" {
+			err = fmt.Errorf("litellm stream ended before the response finished: %w", io.ErrUnexpectedEOF)
+		}
+		if err != nil {
+			final.Done = false
+			final.Err = err
+		}
+		send(final)
+	}()
+
+	return chunks, nil
+}
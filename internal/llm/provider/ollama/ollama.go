@@ -8,6 +8,8 @@ import (
 	"net/http"
 )
 
+var _ llm.ContextCompleter = (*Provider)(nil)
+
 // Provider is the Ollama LLM provider
 type Provider struct {
 	baseURL string
@@ -25,6 +27,12 @@ func NewProvider(baseURL string) *Provider {
 	}
 }
 
+// WithUserAgent sets the User-Agent header sent to the server
+func (p *Provider) WithUserAgent(userAgent string) *Provider {
+	p.client.WithUserAgent(userAgent)
+	return p
+}
+
 // Name returns the provider name
 func (p *Provider) Name() string {
 	return "ollama"
@@ -32,6 +40,12 @@ func (p *Provider) Name() string {
 
 // Complete sends a completion request to Ollama
 func (p *Provider) Complete(prompt string, opts llm.Options) (llm.Response, error) {
+	return p.CompleteContext(context.Background(), prompt, opts)
+}
+
+// CompleteContext sends a completion request to Ollama, honouring ctx for
+// cancellation and any retry budget attached to it
+func (p *Provider) CompleteContext(ctx context.Context, prompt string, opts llm.Options) (llm.Response, error) {
 	model := opts.ModelKey
 	if model == "" {
 		model = "llama3"
@@ -48,7 +62,6 @@ func (p *Provider) Complete(prompt string, opts llm.Options) (llm.Response, erro
 		},
 	}
 
-	ctx := context.Background()
 	resp, err := p.client.Do(ctx, req)
 	if err != nil {
 		return llm.Response{}, fmt.Errorf("ollama request failed: %w", err)
@@ -0,0 +1,75 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/internal/llm/httpbase"
+)
+
+// DefaultEmbeddingModel is the model used by Embed unless overridden
+const DefaultEmbeddingModel = "text-embedding-3-small"
+
+var _ llm.Embeddings = (*Provider)(nil)
+
+// WithEmbeddingModel sets the model used by Embed
+func (p *Provider) WithEmbeddingModel(model string) *Provider {
+	p.embeddingModel = model
+	return p
+}
+
+// Embed returns one embedding vector per input from the /embeddings endpoint
+func (p *Provider) Embed(ctx context.Context, inputs []string) ([][]float64, error) {
+	if len(inputs) == 0 {
+		return [][]float64{}, nil
+	}
+
+	model := p.embeddingModel
+	if model == "" {
+		model = DefaultEmbeddingModel
+	}
+
+	req := &httpbase.Request{
+		Method: http.MethodPost,
+		Path:   "/embeddings",
+		Headers: map[string]string{
+			"Authorization": "Bearer " + p.apiKey,
+		},
+		Body: map[string]interface{}{
+			"model": model,
+			"input": inputs,
+		},
+	}
+
+	resp, err := p.client.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("openai embeddings request failed: %w", err)
+	}
+
+	var embeddingsResp struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+	}
+
+	if err := httpbase.DecodeJSON(resp, &embeddingsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode openai embeddings response: %w", err)
+	}
+
+	if len(embeddingsResp.Data) != len(inputs) {
+		return nil, fmt.Errorf("openai returned %d embeddings for %d inputs", len(embeddingsResp.Data), len(inputs))
+	}
+
+	// Entries carry their input index and are not guaranteed to be in order
+	vectors := make([][]float64, len(inputs))
+	for _, d := range embeddingsResp.Data {
+		if d.Index < 0 || d.Index >= len(inputs) || vectors[d.Index] != nil {
+			return nil, fmt.Errorf("invalid embedding index %d in openai response", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
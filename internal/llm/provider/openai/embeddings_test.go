@@ -0,0 +1,75 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/internal/llm/httpbase"
+)
+
+func TestProviderEmbed(t *testing.T) {
+	var got struct {
+		Model string   `json:"model"`
+		Input []string `json:"input"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embeddings" {
+			t.Errorf("Expected path /embeddings, got %s", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-key" {
+			t.Errorf("Expected bearer auth, got %q", auth)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+
+		// Out of order on purpose; Embed must reorder by index
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"index": 1, "embedding": []float64{0, 1}},
+				{"index": 0, "embedding": []float64{1, 0}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	p := NewProvider("test-key").WithEmbeddingModel("embed-small")
+	p.client = httpbase.NewClient(server.URL)
+
+	vectors, err := p.Embed(context.Background(), []string{"first", "second"})
+	if err != nil {
+		t.Fatalf("Embed() failed: %v", err)
+	}
+
+	want := [][]float64{{1, 0}, {0, 1}}
+	if !reflect.DeepEqual(vectors, want) {
+		t.Errorf("Expected %v, got %v", want, vectors)
+	}
+	if got.Model != "embed-small" {
+		t.Errorf("Expected model embed-small, got %q", got.Model)
+	}
+	if !reflect.DeepEqual(got.Input, []string{"first", "second"}) {
+		t.Errorf("Unexpected input %v", got.Input)
+	}
+}
+
+func TestProviderEmbedCountMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"index": 0, "embedding": []float64{1, 0}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	p := NewProvider("test-key")
+	p.client = httpbase.NewClient(server.URL)
+
+	if _, err := p.Embed(context.Background(), []string{"a", "b"}); err == nil {
+		t.Error("Expected error when fewer embeddings than inputs are returned")
+	}
+}
@@ -8,22 +8,58 @@ import (
 	"net/http"
 )
 
+var _ llm.ContextCompleter = (*Provider)(nil)
+
 // Provider is the OpenAI LLM provider
 type Provider struct {
-	baseURL string
-	apiKey  string
-	client  *httpbase.Client
+	baseURL   string
+	apiKey    string
+	userAgent string
+	client    *httpbase.Client
+
+	clientOptions httpbase.ClientOptions
+
+	embeddingModel string
 }
 
+// DefaultBaseURL is the OpenAI API endpoint used by NewProvider
+const DefaultBaseURL = "https://api.openai.com/v1"
+
 // NewProvider creates a new OpenAI provider
 func NewProvider(apiKey string) *Provider {
+	return NewProviderWithBaseURL(apiKey, DefaultBaseURL)
+}
+
+// NewProviderWithBaseURL creates an OpenAI provider for an OpenAI-compatible
+// endpoint such as a gateway or proxy
+func NewProviderWithBaseURL(apiKey, baseURL string) *Provider {
 	return &Provider{
-		baseURL: "https://api.openai.com/v1",
+		baseURL: baseURL,
 		apiKey:  apiKey,
-		client:  httpbase.NewClient("https://api.openai.com/v1"),
+		client:  httpbase.NewClient(baseURL),
 	}
 }
 
+// SetBaseURL points the provider at a different OpenAI-compatible endpoint
+func (p *Provider) SetBaseURL(baseURL string) {
+	p.baseURL = baseURL
+	p.client = httpbase.NewClientWithOptions(baseURL, p.clientOptions).WithUserAgent(p.userAgent)
+}
+
+// WithClientOptions replaces the HTTP client's retry and timeout settings
+func (p *Provider) WithClientOptions(opts httpbase.ClientOptions) *Provider {
+	p.clientOptions = opts
+	p.client = httpbase.NewClientWithOptions(p.baseURL, opts).WithUserAgent(p.userAgent)
+	return p
+}
+
+// WithUserAgent sets the User-Agent header sent to the API
+func (p *Provider) WithUserAgent(userAgent string) *Provider {
+	p.userAgent = userAgent
+	p.client.WithUserAgent(userAgent)
+	return p
+}
+
 // Name returns the provider name
 func (p *Provider) Name() string {
 	return "openai"
@@ -31,6 +67,12 @@ func (p *Provider) Name() string {
 
 // Complete sends a completion request to OpenAI
 func (p *Provider) Complete(prompt string, opts llm.Options) (llm.Response, error) {
+	return p.CompleteContext(context.Background(), prompt, opts)
+}
+
+// CompleteContext sends a completion request to OpenAI, honouring ctx for
+// cancellation and any retry budget attached to it
+func (p *Provider) CompleteContext(ctx context.Context, prompt string, opts llm.Options) (llm.Response, error) {
 	model := opts.ModelKey
 	if model == "" {
 		model = "gpt-4"
@@ -50,7 +92,6 @@ func (p *Provider) Complete(prompt string, opts llm.Options) (llm.Response, erro
 		},
 	}
 
-	ctx := context.Background()
 	resp, err := p.client.Do(ctx, req)
 	if err != nil {
 		return llm.Response{}, fmt.Errorf("openai request failed: %w", err)
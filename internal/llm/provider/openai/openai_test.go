@@ -1,12 +1,17 @@
 package openai
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"github.com/Mpaape/AurumCode/internal/llm"
 	"github.com/Mpaape/AurumCode/internal/llm/httpbase"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestProviderName(t *testing.T) {
@@ -74,3 +79,187 @@ func TestProviderComplete(t *testing.T) {
 		t.Errorf("Expected 12 output tokens, got %d", resp.TokensOut)
 	}
 }
+
+func TestNewProviderWithBaseURL(t *testing.T) {
+	var gotPath, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"content": "from gateway"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	p := NewProviderWithBaseURL("gateway-key", server.URL+"/v1")
+
+	resp, err := p.Complete("hello", llm.Options{})
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	if resp.Text != "from gateway" {
+		t.Errorf("Expected response from custom endpoint, got %q", resp.Text)
+	}
+	if gotPath != "/v1/chat/completions" {
+		t.Errorf("Expected request to /v1/chat/completions, got %s", gotPath)
+	}
+	if gotAuth != "Bearer gateway-key" {
+		t.Errorf("Expected API key to be sent, got %q", gotAuth)
+	}
+}
+
+func TestProviderSetBaseURL(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"content": "ok"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	var provider llm.Provider = NewProvider("test-key")
+
+	// Callers that type-assert the setter keep working
+	setter, ok := provider.(interface{ SetBaseURL(string) })
+	if !ok {
+		t.Fatal("Provider should implement SetBaseURL")
+	}
+	setter.SetBaseURL(server.URL)
+
+	if _, err := provider.Complete("hello", llm.Options{}); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if !called {
+		t.Error("Request should go to the new base URL")
+	}
+}
+
+func TestProviderUserAgent(t *testing.T) {
+	var gotUserAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgents = append(gotUserAgents, r.Header.Get("User-Agent"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]interface{}{"content": "ok"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	NewProviderWithBaseURL("key", server.URL).Complete("hello", llm.Options{})
+
+	// A configured user agent survives SetBaseURL
+	p := NewProvider("key").WithUserAgent("aurumcode-app/2.1 (+https://example.com/contact)")
+	p.SetBaseURL(server.URL)
+	if _, err := p.Complete("hello", llm.Options{}); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	want := []string{httpbase.DefaultUserAgent(), "aurumcode-app/2.1 (+https://example.com/contact)"}
+	if len(gotUserAgents) != len(want) {
+		t.Fatalf("Expected %d requests, got %v", len(want), gotUserAgents)
+	}
+	for i := range want {
+		if gotUserAgents[i] != want[i] {
+			t.Errorf("request %d User-Agent = %q, want %q", i, gotUserAgents[i], want[i])
+		}
+	}
+}
+
+func TestProviderComplete_StopsRetryingWhenRunBudgetIsSpent(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	p := NewProviderWithBaseURL("test-key", server.URL).WithClientOptions(httpbase.ClientOptions{
+		MaxRetries:     10,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+	orch := llm.NewOrchestrator(p, nil, nil)
+
+	// The budget a pipeline run attaches to its context
+	ctx := httpbase.WithRetryBudget(context.Background(), httpbase.NewRetryBudget(2))
+
+	_, err := orch.Complete(ctx, "hello", llm.Options{})
+	if !errors.Is(err, httpbase.ErrRetryBudgetExhausted) {
+		t.Errorf("Expected ErrRetryBudgetExhausted, got %v", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected the first attempt and 2 budgeted retries, got %d requests", got)
+	}
+}
+
+func TestProviderCompleteStream_TruncatedBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(`data: {"model":"gpt-4o","choices":[{"delta":{"content":"Hel"}}]}` + "\n\n"))
+		// The connection drops part way through the next event
+		w.Write([]byte(`data: {"model":"gpt-4o","choices":[{"delta":{"content":"lo"}}]}` + "\n"))
+	}))
+	defer server.Close()
+
+	p := NewProvider("test-key")
+	p.client = httpbase.NewClient(server.URL)
+
+	chunks, err := p.CompleteStream(context.Background(), "hello", llm.Options{})
+	if err != nil {
+		t.Fatalf("CompleteStream() failed: %v", err)
+	}
+
+	var last llm.StreamChunk
+	for chunk := range chunks {
+		last = chunk
+	}
+
+	if last.Done || !errors.Is(last.Err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected a truncated stream to fail, got %+v", last)
+	}
+}
+
+func TestProviderCompleteStream(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(`data: {"model":"gpt-4o","choices":[{"delta":{"content":"Hel"}}]}` + "\n\n"))
+		w.Write([]byte(`data: {"model":"gpt-4o","choices":[{"delta":{"content":"lo"},"finish_reason":"stop"}]}` + "\n\n"))
+		w.Write([]byte(`data: {"model":"gpt-4o","choices":[],"usage":{"prompt_tokens":5,"completion_tokens":2}}` + "\n\n"))
+		w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	p := NewProvider("test-key")
+	p.client = httpbase.NewClient(server.URL)
+
+	chunks, err := p.CompleteStream(context.Background(), "hello", llm.Options{})
+	if err != nil {
+		t.Fatalf("CompleteStream() failed: %v", err)
+	}
+
+	var text string
+	var last llm.StreamChunk
+	for chunk := range chunks {
+		text += chunk.Text
+		last = chunk
+	}
+
+	if text != "Hello" {
+		t.Errorf("Expected streamed text 'Hello', got %q", text)
+	}
+	if !last.Done || last.TokensIn != 5 || last.TokensOut != 2 || last.Model != "gpt-4o" || last.FinishReason != "stop" {
+		t.Errorf("Unexpected final chunk: %+v", last)
+	}
+	if body["stream"] != true {
+		t.Errorf("Expected a streaming request, got %v", body)
+	}
+}
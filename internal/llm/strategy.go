@@ -0,0 +1,94 @@
+package llm
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Strategy picks the provider that serves a request first, returning its
+// index in providers. The others remain fallbacks in their configured order.
+type Strategy interface {
+	Pick(providers []Provider) int
+}
+
+// RoundRobin spreads requests evenly across providers. The zero value is
+// ready to use.
+type RoundRobin struct {
+	mu   sync.Mutex
+	next int
+}
+
+// Pick returns the index of the next provider in turn, or -1 when there are
+// no providers
+func (r *RoundRobin) Pick(providers []Provider) int {
+	if len(providers) == 0 {
+		return -1
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	picked := r.next % len(providers)
+	r.next = (picked + 1) % len(providers)
+	return picked
+}
+
+// WeightedRandom routes a share of requests to each provider in proportion
+// to its weight, keyed by provider name. Providers without a weight are only
+// used as fallbacks.
+type WeightedRandom struct {
+	mu      sync.Mutex
+	weights map[string]float64
+	rng     *rand.Rand
+}
+
+// NewWeightedRandom creates a strategy from provider name to weight, e.g.
+// {"openai": 0.2, "litellm": 0.8}
+func NewWeightedRandom(weights map[string]float64) *WeightedRandom {
+	return &WeightedRandom{
+		weights: weights,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Pick returns the index of a provider at random, weighted by name; with no
+// positive weights it returns the first provider, and -1 with no providers
+func (w *WeightedRandom) Pick(providers []Provider) int {
+	if len(providers) == 0 {
+		return -1
+	}
+
+	total := 0.0
+	for _, p := range providers {
+		if weight := w.weights[p.Name()]; weight > 0 {
+			total += weight
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	w.mu.Lock()
+	target := w.rng.Float64() * total
+	w.mu.Unlock()
+
+	for i, p := range providers {
+		weight := w.weights[p.Name()]
+		if weight <= 0 {
+			continue
+		}
+		if target < weight {
+			return i
+		}
+		target -= weight
+	}
+
+	// Rounding can leave target just past the last weight
+	for i := len(providers) - 1; i >= 0; i-- {
+		if w.weights[providers[i].Name()] > 0 {
+			return i
+		}
+	}
+	return 0
+}
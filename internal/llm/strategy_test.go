@@ -0,0 +1,113 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestRoundRobin_Pick(t *testing.T) {
+	a, b, c := &mockProvider{name: "a"}, &mockProvider{name: "b"}, &mockProvider{name: "c"}
+	providers := []Provider{a, b, c}
+
+	var rr RoundRobin
+	var got []string
+	for i := 0; i < 5; i++ {
+		got = append(got, providers[rr.Pick(providers)].Name())
+	}
+
+	want := []string{"a", "b", "c", "a", "b"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected picks %v, got %v", want, got)
+		}
+	}
+}
+
+func TestWeightedRandom_Pick(t *testing.T) {
+	cheap, premium := &mockProvider{name: "cheap"}, &mockProvider{name: "premium"}
+	providers := []Provider{premium, cheap}
+
+	strategy := NewWeightedRandom(map[string]float64{"cheap": 80, "premium": 20})
+	strategy.rng = rand.New(rand.NewSource(1))
+
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		counts[providers[strategy.Pick(providers)].Name()]++
+	}
+
+	if share := float64(counts["cheap"]) / 10000; share < 0.77 || share > 0.83 {
+		t.Errorf("Expected about 80%% of picks for cheap, got %.2f", share)
+	}
+}
+
+func TestWeightedRandom_UnweightedProvidersAreNotPicked(t *testing.T) {
+	a, b := &mockProvider{name: "a"}, &mockProvider{name: "b"}
+
+	strategy := NewWeightedRandom(map[string]float64{"b": 1})
+	for i := 0; i < 100; i++ {
+		if picked := strategy.Pick([]Provider{a, b}); picked != 1 {
+			t.Fatalf("Expected only b to be picked, got index %d", picked)
+		}
+	}
+
+	if picked := NewWeightedRandom(nil).Pick([]Provider{a, b}); picked != 0 {
+		t.Errorf("Expected the first provider without weights, got index %d", picked)
+	}
+	if picked := strategy.Pick(nil); picked != -1 {
+		t.Errorf("Expected -1 without providers, got %d", picked)
+	}
+}
+
+func TestOrchestratorWithStrategy_StartsWithPick(t *testing.T) {
+	primary := &mockProvider{name: "primary", response: Response{Text: "primary"}}
+	fallback := &mockProvider{name: "fallback", response: Response{Text: "fallback"}}
+	orch := NewOrchestratorWithStrategy(primary, []Provider{fallback}, nil, &RoundRobin{})
+
+	var texts []string
+	for i := 0; i < 4; i++ {
+		resp, err := orch.Complete(context.Background(), "prompt", Options{})
+		if err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+		texts = append(texts, resp.Text)
+	}
+
+	if primary.callCount != 2 || fallback.callCount != 2 {
+		t.Errorf("Expected requests split evenly, got %v", texts)
+	}
+}
+
+func TestOrchestratorWithStrategy_FallsBackAfterPick(t *testing.T) {
+	primary := &mockProvider{name: "primary", response: Response{Text: "primary"}}
+	cheap := &mockProvider{name: "cheap", err: errors.New("overloaded")}
+	orch := NewOrchestratorWithStrategy(primary, []Provider{cheap}, nil,
+		NewWeightedRandom(map[string]float64{"cheap": 1}))
+
+	resp, err := orch.Complete(context.Background(), "prompt", Options{})
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	if cheap.callCount != 1 {
+		t.Errorf("Expected the picked provider to be tried first, got %d calls", cheap.callCount)
+	}
+	if resp.Text != "primary" {
+		t.Errorf("Expected to fall back to primary, got %q", resp.Text)
+	}
+}
+
+func TestOrchestratorWithStrategy_AllFail(t *testing.T) {
+	a := &mockProvider{name: "a", err: errors.New("down")}
+	b := &mockProvider{name: "b", err: errors.New("down")}
+	orch := NewOrchestratorWithStrategy(a, []Provider{b}, nil, &RoundRobin{})
+
+	_, err := orch.Complete(context.Background(), "prompt", Options{})
+	if !errors.Is(err, ErrAllProvidersFailed) {
+		t.Errorf("Expected ErrAllProvidersFailed, got %v", err)
+	}
+	if a.callCount != 1 || b.callCount != 1 {
+		t.Errorf("Expected each provider tried once, got %d and %d", a.callCount, b.callCount)
+	}
+}
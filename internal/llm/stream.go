@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Mpaape/AurumCode/internal/tracing"
+)
+
+// CompleteStream is like Complete but delivers text as it is generated.
+// Providers that implement Streamer stream deltas; others send the full text
+// as a single chunk, so callers have one code path. The last chunk has Done
+// or Err set, and spending is recorded once the stream ends. Fallbacks are
+// only tried when a stream cannot be started.
+func (o *Orchestrator) CompleteStream(ctx context.Context, prompt string, opts Options) (<-chan StreamChunk, error) {
+	if o.primary == nil && len(o.fallbacks) == 0 {
+		return nil, ErrNoProviders
+	}
+	opts = o.withModelKey(opts)
+
+	tokensIn, tokensOut, model := o.plan(prompt, opts)
+
+	var lastErr error
+	var skipped []string
+	for _, l := range o.chain() {
+		provider := l.provider
+		if err := o.checkBudget(provider, opts, tokensIn, tokensOut, model); err != nil {
+			return nil, err
+		}
+
+		if !o.breaker.allow(l.pos) {
+			skipped = append(skipped, provider.Name())
+			continue
+		}
+
+		source, end, err := o.openStream(ctx, provider, prompt, opts)
+		if err != nil {
+			o.recordOutcome(ctx, l, err)
+			lastErr = fmt.Errorf("provider %s failed: %w", provider.Name(), err)
+			continue
+		}
+
+		// A stream that fails part way counts against the provider too
+		finish := func(err error) {
+			o.recordOutcome(ctx, l, err)
+			end(err)
+		}
+		return o.meterStream(ctx, source, finish, opts, tokensIn, model), nil
+	}
+
+	return nil, chainError(lastErr, skipped)
+}
+
+// openStream starts a stream from provider. end is called with the stream's
+// outcome once it finishes.
+func (o *Orchestrator) openStream(ctx context.Context, provider Provider, prompt string, opts Options) (<-chan StreamChunk, func(error), error) {
+	streamer, ok := provider.(Streamer)
+	if !ok {
+		resp, err := o.executeWithTimeout(ctx, provider, prompt, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		chunks := make(chan StreamChunk, 1)
+		chunks <- StreamChunk{
+			Text:         resp.Text,
+			Done:         true,
+			TokensIn:     resp.TokensIn,
+			TokensOut:    resp.TokensOut,
+			Model:        resp.Model,
+			FinishReason: resp.FinishReason,
+		}
+		close(chunks)
+		return chunks, func(error) {}, nil
+	}
+
+	ctx, span := tracing.Start(ctx, "llm."+provider.Name())
+	chunks, err := streamer.CompleteStream(ctx, prompt, opts)
+	if err != nil {
+		span.End(err)
+		return nil, nil, err
+	}
+	return chunks, span.End, nil
+}
+
+// meterStream forwards chunks from source and records spending once it
+// closes. Providers that report no usage are charged the planned input
+// tokens and an estimate of the generated text.
+func (o *Orchestrator) meterStream(ctx context.Context, source <-chan StreamChunk, end func(error), opts Options, plannedIn int, plannedModel string) <-chan StreamChunk {
+	out := make(chan StreamChunk)
+
+	go func() {
+		defer close(out)
+
+		var final StreamChunk
+		generated := 0
+		forwarding := true
+
+		for chunk := range source {
+			generated += len(chunk.Text)
+			if chunk.Done || chunk.Err != nil {
+				final = chunk
+			}
+
+			if forwarding {
+				select {
+				case out <- chunk:
+				case <-ctx.Done():
+					// Keep draining so the provider can finish and be charged
+					forwarding = false
+				}
+			}
+		}
+
+		tokensIn, tokensOut, model := final.TokensIn, final.TokensOut, final.Model
+		if tokensIn == 0 && tokensOut == 0 {
+			tokensIn, tokensOut = plannedIn, generated/4
+		}
+		if model == "" {
+			model = plannedModel
+		}
+		o.recordSpend(opts, tokensIn, tokensOut, model)
+		end(final.Err)
+	}()
+
+	return out
+}
@@ -0,0 +1,192 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mpaape/AurumCode/internal/llm/cost"
+)
+
+// streamingProvider streams canned deltas and reports usage in the last chunk
+type streamingProvider struct {
+	mockProvider
+	deltas    []string
+	noUsage   bool
+	streamErr error
+	midErr    error // Ends the stream with this error after the deltas
+}
+
+func (s *streamingProvider) CompleteStream(ctx context.Context, prompt string, opts Options) (<-chan StreamChunk, error) {
+	s.callCount++
+	if s.streamErr != nil {
+		return nil, s.streamErr
+	}
+
+	chunks := make(chan StreamChunk, len(s.deltas)+1)
+	for _, d := range s.deltas {
+		chunks <- StreamChunk{Text: d}
+	}
+	final := StreamChunk{Done: true, Model: "test-model", FinishReason: "stop"}
+	if !s.noUsage {
+		final.TokensIn, final.TokensOut = 100, 200
+	}
+	if s.midErr != nil {
+		final = StreamChunk{Err: s.midErr}
+	}
+	chunks <- final
+	close(chunks)
+	return chunks, nil
+}
+
+func collect(t *testing.T, chunks <-chan StreamChunk) (string, StreamChunk) {
+	t.Helper()
+	var text strings.Builder
+	var last StreamChunk
+	for chunk := range chunks {
+		text.WriteString(chunk.Text)
+		last = chunk
+	}
+	return text.String(), last
+}
+
+func newStreamTracker() *cost.Tracker {
+	return cost.NewTracker(10.0, 100.0, map[string]cost.PriceMap{
+		"test-model": {InputPer1K: 0.01, OutputPer1K: 0.02},
+	})
+}
+
+func TestOrchestratorCompleteStream_StreamsDeltas(t *testing.T) {
+	provider := &streamingProvider{mockProvider: mockProvider{name: "streamer"}, deltas: []string{"Hel", "lo", "!"}}
+	orch := NewOrchestrator(provider, nil, newStreamTracker())
+
+	chunks, err := orch.CompleteStream(context.Background(), "prompt", Options{ModelKey: "test-model"})
+	if err != nil {
+		t.Fatalf("CompleteStream failed: %v", err)
+	}
+
+	var deltas []string
+	var last StreamChunk
+	for chunk := range chunks {
+		if chunk.Text != "" {
+			deltas = append(deltas, chunk.Text)
+		}
+		last = chunk
+	}
+
+	if strings.Join(deltas, "|") != "Hel|lo|!" {
+		t.Errorf("Expected deltas in order, got %v", deltas)
+	}
+	if !last.Done || last.TokensOut != 200 || last.FinishReason != "stop" {
+		t.Errorf("Unexpected final chunk: %+v", last)
+	}
+	if provider.mockProvider.callCount != 1 {
+		t.Errorf("Expected one stream, got %d calls", provider.mockProvider.callCount)
+	}
+
+	// 100 in at 0.01/1K and 200 out at 0.02/1K
+	perRun, _ := orch.RemainingBudget()
+	if want := 10.0 - 0.005; perRun < want-1e-9 || perRun > want+1e-9 {
+		t.Errorf("Expected the reported usage to be charged, remaining %f", perRun)
+	}
+}
+
+func TestOrchestratorCompleteStream_NonStreamingProvider(t *testing.T) {
+	provider := &mockProvider{
+		name:     "plain",
+		response: Response{Text: "full text", TokensIn: 10, TokensOut: 20, Model: "test-model"},
+	}
+	orch := NewOrchestrator(provider, nil, newStreamTracker())
+
+	chunks, err := orch.CompleteStream(context.Background(), "prompt", Options{ModelKey: "test-model"})
+	if err != nil {
+		t.Fatalf("CompleteStream failed: %v", err)
+	}
+
+	count := 0
+	var only StreamChunk
+	for chunk := range chunks {
+		count++
+		only = chunk
+	}
+	if count != 1 || only.Text != "full text" || !only.Done {
+		t.Errorf("Expected a single final chunk with the full text, got %d chunks, last %+v", count, only)
+	}
+
+	if perRun, _ := orch.RemainingBudget(); perRun >= 10.0 {
+		t.Error("Expected the completion to be charged")
+	}
+}
+
+func TestOrchestratorCompleteStream_EstimatesMissingUsage(t *testing.T) {
+	provider := &streamingProvider{mockProvider: mockProvider{name: "streamer"}, deltas: []string{strings.Repeat("x", 4000)}, noUsage: true}
+	orch := NewOrchestrator(provider, nil, newStreamTracker())
+
+	chunks, err := orch.CompleteStream(context.Background(), "prompt", Options{ModelKey: "test-model"})
+	if err != nil {
+		t.Fatalf("CompleteStream failed: %v", err)
+	}
+	collect(t, chunks)
+
+	// About 1000 generated tokens at 0.02/1K
+	if perRun, _ := orch.RemainingBudget(); perRun > 10.0-0.02 {
+		t.Errorf("Expected generated text to be charged, remaining %f", perRun)
+	}
+}
+
+func TestOrchestratorCompleteStream_FallsBackWhenStreamFails(t *testing.T) {
+	primary := &streamingProvider{mockProvider: mockProvider{name: "primary"}, streamErr: errors.New("connection refused")}
+	fallback := &mockProvider{name: "fallback", response: Response{Text: "from fallback", Model: "test-model"}}
+	orch := NewOrchestrator(primary, []Provider{fallback}, nil)
+
+	chunks, err := orch.CompleteStream(context.Background(), "prompt", Options{})
+	if err != nil {
+		t.Fatalf("CompleteStream failed: %v", err)
+	}
+	if text, _ := collect(t, chunks); text != "from fallback" {
+		t.Errorf("Expected the fallback's text, got %q", text)
+	}
+}
+
+func TestOrchestratorCompleteStream_BudgetExceeded(t *testing.T) {
+	provider := &streamingProvider{mockProvider: mockProvider{name: "streamer"}}
+	tracker := cost.NewTracker(0.0001, 100.0, map[string]cost.PriceMap{
+		"test-model": {InputPer1K: 1, OutputPer1K: 1},
+	})
+	orch := NewOrchestrator(provider, nil, tracker)
+
+	_, err := orch.CompleteStream(context.Background(), "prompt", Options{ModelKey: "test-model", MaxTokens: 1000})
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("Expected ErrBudgetExceeded, got %v", err)
+	}
+	if provider.mockProvider.callCount != 0 {
+		t.Error("No stream should start without budget")
+	}
+}
+
+func TestOrchestratorCompleteStream_MidStreamErrorTripsBreaker(t *testing.T) {
+	flaky := &streamingProvider{mockProvider: mockProvider{name: "flaky"}, deltas: []string{"par"}, midErr: errors.New("connection reset")}
+	fallback := &mockProvider{name: "fallback", response: Response{Text: "from fallback", Model: "test-model"}}
+	orch := NewOrchestrator(flaky, []Provider{fallback}, nil).WithCircuitBreaker(1, time.Minute)
+
+	chunks, err := orch.CompleteStream(context.Background(), "prompt", Options{})
+	if err != nil {
+		t.Fatalf("CompleteStream failed: %v", err)
+	}
+	if _, last := collect(t, chunks); last.Err == nil {
+		t.Fatal("Expected the stream to end with an error")
+	}
+
+	chunks, err = orch.CompleteStream(context.Background(), "prompt", Options{})
+	if err != nil {
+		t.Fatalf("CompleteStream failed: %v", err)
+	}
+	if text, _ := collect(t, chunks); text != "from fallback" {
+		t.Errorf("Expected the open circuit to route to the fallback, got %q", text)
+	}
+	if flaky.mockProvider.callCount != 1 {
+		t.Errorf("Expected the flaky provider to be skipped, got %d streams", flaky.mockProvider.callCount)
+	}
+}
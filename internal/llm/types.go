@@ -1,5 +1,10 @@
 package llm
 
+import (
+	"context"
+	"time"
+)
+
 // Options represents LLM request options
 type Options struct {
 	System      string            `json:"system,omitempty"`
@@ -8,6 +13,13 @@ type Options struct {
 	Stop        []string          `json:"stop,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
 	ModelKey    string            `json:"model_key,omitempty"`
+
+	// BudgetLabel charges the call to a cost sub-budget, e.g. "owner/repo"
+	BudgetLabel string `json:"budget_label,omitempty"`
+
+	// PerCallTimeout bounds each provider attempt so a hanging provider
+	// does not use up the caller's deadline before the fallbacks run
+	PerCallTimeout time.Duration `json:"-"`
 }
 
 // Response represents an LLM response
@@ -27,6 +39,43 @@ type Provider interface {
 	Name() string
 }
 
+// ContextCompleter is implemented by providers whose requests honour the
+// caller's context, including cancellation and a shared retry budget
+type ContextCompleter interface {
+	CompleteContext(ctx context.Context, prompt string, opts Options) (Response, error)
+}
+
+// Pinger is implemented by providers that can check their endpoint is
+// reachable without spending tokens
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// StreamChunk is one piece of a streamed completion. The final chunk has Done
+// set and carries the token usage, or Err if the stream failed part way.
+type StreamChunk struct {
+	Text string // Text generated since the previous chunk
+
+	Done         bool
+	TokensIn     int
+	TokensOut    int
+	Model        string
+	FinishReason string
+	Err          error
+}
+
+// Streamer is implemented by providers that can stream completions as they
+// are generated. The channel is closed after the final chunk.
+type Streamer interface {
+	CompleteStream(ctx context.Context, prompt string, opts Options) (<-chan StreamChunk, error)
+}
+
+// Embeddings is implemented by providers that can turn text into vectors.
+// Vectors are returned in the same order as inputs.
+type Embeddings interface {
+	Embed(ctx context.Context, inputs []string) ([][]float64, error)
+}
+
 // DefaultOptions returns sensible defaults for LLM options
 func DefaultOptions() Options {
 	return Options{
@@ -0,0 +1,27 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Mpaape/AurumCode/internal/llm"
+)
+
+// ErrCostLimitExceeded is returned when the estimated LLM cost is above the allowed limit
+var ErrCostLimitExceeded = errors.New("estimated LLM cost exceeds limit")
+
+// CheckCostLimit returns ErrCostLimitExceeded when estimate.USD is above limitUSD,
+// or when some calls are unpriced and their cost cannot be bounded.
+// A limit of 0 or less disables the check.
+func CheckCostLimit(estimate llm.CostEstimate, limitUSD float64) error {
+	if limitUSD <= 0 {
+		return nil
+	}
+	if estimate.Unpriced > 0 {
+		return fmt.Errorf("%w: %d call(s) use a model without configured pricing", ErrCostLimitExceeded, estimate.Unpriced)
+	}
+	if estimate.USD <= limitUSD {
+		return nil
+	}
+	return fmt.Errorf("%w: $%.4f > $%.4f", ErrCostLimitExceeded, estimate.USD, limitUSD)
+}
@@ -0,0 +1,126 @@
+package pipeline
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/internal/llm/cost"
+)
+
+// stubProvider is an llm.Provider that is never expected to be called
+type stubProvider struct{}
+
+func (stubProvider) Complete(prompt string, opts llm.Options) (llm.Response, error) {
+	return llm.Response{}, errors.New("unexpected call")
+}
+func (stubProvider) Tokens(input string) (int, error) { return len(input) / 4, nil }
+func (stubProvider) Name() string                     { return "stub" }
+
+func newWelcomeProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	promptDir := filepath.Join(dir, ".aurumcode", "prompts", "documentation")
+	if err := os.MkdirAll(promptDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(promptDir, "welcome-page.md"), []byte("Summarize:\n{{README_CONTENT}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), make([]byte, 4000), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestExtractorPipeline_EstimateLLMCost(t *testing.T) {
+	dir := newWelcomeProject(t)
+
+	tracker := cost.NewTracker(10, 100, map[string]cost.PriceMap{
+		"default": {InputPer1K: 0.01, OutputPer1K: 0.03},
+	})
+	orch := llm.NewOrchestrator(stubProvider{}, nil, tracker)
+
+	config := &ExtractorPipelineConfig{
+		SourceDir:        dir,
+		DocsDir:          filepath.Join(dir, "docs"),
+		GenerateWelcome:  true,
+		WelcomeLanguages: []string{"en", "es"},
+	}
+	p := NewExtractorPipeline(config, site.NewMockRunner(), orch)
+
+	estimate, err := p.EstimateLLMCost()
+	if err != nil {
+		t.Fatalf("EstimateLLMCost failed: %v", err)
+	}
+
+	if estimate.Calls != 2 {
+		t.Errorf("Calls = %d, want 2 (one per language)", estimate.Calls)
+	}
+	if estimate.TokensIn < 2000 || estimate.TokensOut != 8000 {
+		t.Errorf("unexpected token estimate: %+v", estimate)
+	}
+	if estimate.USD <= 0.24 || estimate.Unpriced != 0 {
+		t.Errorf("unexpected cost estimate: %+v", estimate)
+	}
+
+	if err := CheckCostLimit(estimate, 0.10); !errors.Is(err, ErrCostLimitExceeded) {
+		t.Errorf("expected ErrCostLimitExceeded, got %v", err)
+	}
+	if err := CheckCostLimit(estimate, 5); err != nil {
+		t.Errorf("estimate under the limit should pass, got %v", err)
+	}
+	if err := CheckCostLimit(estimate, 0); err != nil {
+		t.Errorf("zero limit disables the check, got %v", err)
+	}
+
+	estimate.Unpriced = 1
+	if err := CheckCostLimit(estimate, 5); !errors.Is(err, ErrCostLimitExceeded) {
+		t.Errorf("unpriced calls should exceed the limit, got %v", err)
+	}
+}
+
+func TestExtractorPipeline_EstimateLLMCost_PricesConfiguredModel(t *testing.T) {
+	dir := newWelcomeProject(t)
+
+	// Set up like regenerate-docs: the welcome page names no model
+	tracker := cost.NewTracker(10, 100, cost.DefaultPrices())
+	orch := llm.NewOrchestrator(stubProvider{}, nil, tracker).WithModelKey("gpt-4o-mini")
+
+	config := &ExtractorPipelineConfig{
+		SourceDir:       dir,
+		DocsDir:         filepath.Join(dir, "docs"),
+		GenerateWelcome: true,
+	}
+	estimate, err := NewExtractorPipeline(config, site.NewMockRunner(), orch).EstimateLLMCost()
+	if err != nil {
+		t.Fatalf("EstimateLLMCost failed: %v", err)
+	}
+
+	if estimate.Unpriced != 0 || estimate.USD <= 0 {
+		t.Errorf("expected the configured model to be priced, got %+v", estimate)
+	}
+	if err := CheckCostLimit(estimate, 0.0001); !errors.Is(err, ErrCostLimitExceeded) {
+		t.Errorf("expected a tiny limit to be exceeded, got %v", err)
+	}
+}
+
+func TestExtractorPipeline_EstimateLLMCost_NoLLMSteps(t *testing.T) {
+	dir := newWelcomeProject(t)
+
+	config := &ExtractorPipelineConfig{SourceDir: dir, DocsDir: dir, GenerateWelcome: true}
+	p := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+
+	estimate, err := p.EstimateLLMCost()
+	if err != nil {
+		t.Fatalf("EstimateLLMCost failed: %v", err)
+	}
+	if estimate.Calls != 0 || estimate.USD != 0 {
+		t.Errorf("expected empty estimate without an orchestrator, got %+v", estimate)
+	}
+}
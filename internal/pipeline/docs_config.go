@@ -0,0 +1,26 @@
+package pipeline
+
+import (
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// ApplyDocumentation copies the settings of a repo's documentation config
+// that shape a run into c
+func (c *ExtractorPipelineConfig) ApplyDocumentation(doc types.DocumentationConfig) error {
+	timeout, err := doc.TimeoutDuration()
+	if err != nil {
+		return err
+	}
+	welcomeRetryDelay, welcomeTimeout, err := doc.Welcome.Durations()
+	if err != nil {
+		return err
+	}
+
+	c.Timeout = timeout
+	c.ExcludedDirs = doc.ExcludedDirs
+	c.ExtensionOverrides = doc.ExtensionOverrides
+	c.WelcomeRetries = doc.Welcome.Retries
+	c.WelcomeRetryDelay = welcomeRetryDelay
+	c.WelcomeTimeout = welcomeTimeout
+	return nil
+}
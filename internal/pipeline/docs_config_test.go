@@ -0,0 +1,168 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Mpaape/AurumCode/internal/config"
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// loadRepoDocs writes files and a repo config into a new repository and
+// loads its documentation settings the way regenerate-docs does
+func loadRepoDocs(t *testing.T, repoConfig string, files ...string) (string, types.DocumentationConfig) {
+	t.Helper()
+	repoDir := t.TempDir()
+
+	for _, file := range files {
+		path := filepath.Join(repoDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("package main"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(repoDir, config.RepoConfigPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(repoConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadForRepo(types.NewDefaultConfig(), repoDir)
+	if err != nil {
+		t.Fatalf("LoadForRepo failed: %v", err)
+	}
+	if _, err := config.Validate(cfg); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	return repoDir, cfg.Documentation
+}
+
+func TestApplyDocumentation_RepoConfigExcludesDirs(t *testing.T) {
+	repoDir, docs := loadRepoDocs(t, "version: \"2.0\"\ndocumentation:\n  excluded_dirs: [fixtures]\n",
+		"main.go", "fixtures/sample.go")
+
+	docsDir := filepath.Join(repoDir, "docs")
+	cfg := &ExtractorPipelineConfig{
+		SourceDir:      repoDir,
+		OutputDir:      docsDir,
+		DocsDir:        docsDir,
+		CheckpointPath: filepath.Join(repoDir, "checkpoint.json"),
+	}
+	if err := cfg.ApplyDocumentation(docs); err != nil {
+		t.Fatalf("ApplyDocumentation failed: %v", err)
+	}
+
+	pipeline := NewExtractorPipeline(cfg, site.NewMockRunner(), nil)
+	extractor := &fakeExtractor{lang: extractors.LanguageGo}
+	if err := pipeline.RegisterExtractor(extractor); err != nil {
+		t.Fatalf("RegisterExtractor failed: %v", err)
+	}
+
+	if _, err := pipeline.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if extractor.lastReq == nil {
+		t.Fatal("extractor was not called")
+	}
+
+	excluded := extractor.lastReq.Excluded()
+	for _, dir := range []string{"fixtures", "vendor"} {
+		if !excluded.Contains(dir) {
+			t.Errorf("extract request should exclude %q, got %v", dir, excluded.List())
+		}
+	}
+
+	detection, err := pipeline.Detector().Detect(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if stats := detection.Languages[extractors.LanguageGo]; stats == nil || stats.FileCount != 1 {
+		t.Errorf("detector should skip fixtures/, got %+v", stats)
+	}
+}
+
+func TestApplyDocumentation_RepoConfigOverridesExtensions(t *testing.T) {
+	repoDir, docs := loadRepoDocs(t, "version: \"2.0\"\ndocumentation:\n  extension_overrides:\n    \".dsl\": python\n",
+		"rules.dsl")
+
+	docsDir := filepath.Join(repoDir, "docs")
+	cfg := &ExtractorPipelineConfig{
+		SourceDir:      repoDir,
+		OutputDir:      docsDir,
+		DocsDir:        docsDir,
+		CheckpointPath: filepath.Join(repoDir, "checkpoint.json"),
+	}
+	if err := cfg.ApplyDocumentation(docs); err != nil {
+		t.Fatalf("ApplyDocumentation failed: %v", err)
+	}
+
+	pipeline := NewExtractorPipeline(cfg, site.NewMockRunner(), nil)
+	extractor := &fakeExtractor{lang: extractors.LanguagePython}
+	if err := pipeline.RegisterExtractor(extractor); err != nil {
+		t.Fatalf("RegisterExtractor failed: %v", err)
+	}
+
+	detection, err := pipeline.Detector().Detect(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if stats := detection.Languages[extractors.LanguagePython]; stats == nil || stats.FileCount != 1 {
+		t.Errorf("detector should count rules.dsl as Python, got %+v", detection.Languages)
+	}
+
+	if _, err := pipeline.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if extractor.calls != 1 {
+		t.Errorf("Expected the Python extractor to run once, ran %d times", extractor.calls)
+	}
+}
+
+func TestApplyDocumentation_RepoConfigTimeout(t *testing.T) {
+	_, docs := loadRepoDocs(t, "version: \"2.0\"\ndocumentation:\n  timeout: 45m\n")
+
+	cfg := &ExtractorPipelineConfig{}
+	if err := cfg.ApplyDocumentation(docs); err != nil {
+		t.Fatalf("ApplyDocumentation failed: %v", err)
+	}
+	if cfg.Timeout != 45*time.Minute {
+		t.Errorf("Timeout = %v, want 45m", cfg.Timeout)
+	}
+
+	docs.Timeout = "soon"
+	if err := cfg.ApplyDocumentation(docs); err == nil {
+		t.Error("Expected an invalid timeout to be rejected")
+	}
+}
+
+func TestApplyDocumentation_RepoConfigWelcomeAndGlossary(t *testing.T) {
+	_, docs := loadRepoDocs(t, "version: \"2.0\"\ndocumentation:\n"+
+		"  glossary_terms: [Tenant, Shard]\n"+
+		"  welcome:\n    retries: -1\n    retry_delay: 5s\n    timeout: 30s\n")
+
+	cfg := &ExtractorPipelineConfig{}
+	if err := cfg.ApplyDocumentation(docs); err != nil {
+		t.Fatalf("ApplyDocumentation failed: %v", err)
+	}
+
+	if cfg.WelcomeRetries != -1 || cfg.WelcomeRetryDelay != 5*time.Second || cfg.WelcomeTimeout != 30*time.Second {
+		t.Errorf("welcome settings not taken from the repo config: retries %d, delay %v, timeout %v",
+			cfg.WelcomeRetries, cfg.WelcomeRetryDelay, cfg.WelcomeTimeout)
+	}
+
+	// regenerate-docs passes these to glossary.Generate
+	if want := []string{"Tenant", "Shard"}; !reflect.DeepEqual(docs.GlossaryTerms, want) {
+		t.Errorf("GlossaryTerms = %v, want %v", docs.GlossaryTerms, want)
+	}
+}
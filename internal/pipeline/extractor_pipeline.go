@@ -6,13 +6,26 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/bash"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/cpp"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/csharp"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/go"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/javascript"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/powershell"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/python"
+	_ "github.com/Mpaape/AurumCode/internal/documentation/extractors/rust"
 	"github.com/Mpaape/AurumCode/internal/documentation/incremental"
 	"github.com/Mpaape/AurumCode/internal/documentation/normalizer"
 	"github.com/Mpaape/AurumCode/internal/documentation/site"
 	"github.com/Mpaape/AurumCode/internal/documentation/welcome"
 	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/internal/llm/httpbase"
+	"github.com/Mpaape/AurumCode/internal/tracing"
 )
 
 // ExtractorPipelineConfig configures the documentation extraction pipeline
@@ -25,8 +38,91 @@ type ExtractorPipelineConfig struct {
 	GenerateWelcome bool     // Generate LLM-powered welcome page
 	ValidateJekyll  bool     // Validate Jekyll site after generation
 	DeployGHPages   bool     // Deploy to gh-pages branch
+
+	// Timeout bounds the whole run, including all extraction and build steps (0 = no limit)
+	Timeout time.Duration
+
+	// OutputFormat selects the format extractors produce (empty = markdown)
+	OutputFormat extractors.OutputFormat
+
+	// OutputExtension is the extension extracted files are renamed to, so the
+	// normalizer sees every page (empty = .md, or .html for HTML output)
+	OutputExtension string
+
+	// Transforms are applied in order to every generated file before
+	// normalization; their errors are reported but not fatal by default
+	Transforms []DocTransform
+
+	// Resume skips languages completed by a previous interrupted run
+	Resume bool
+
+	// CheckpointPath overrides where per-run progress is recorded
+	CheckpointPath string
+
+	// WelcomeLanguages generates the welcome page in each language; the
+	// first is written to index.md, others to index.<lang>.md
+	WelcomeLanguages []string
+
+	// WelcomeRetries is how many times a failed welcome page LLM call is
+	// retried (0 = DefaultWelcomeRetries, negative disables retries)
+	WelcomeRetries int
+
+	// WelcomeRetryDelay is the pause between welcome page attempts
+	// (0 = DefaultWelcomeRetryDelay)
+	WelcomeRetryDelay time.Duration
+
+	// WelcomeTimeout bounds each welcome page LLM attempt, so a hung call
+	// leaves time to retry (0 = DefaultWelcomeTimeout)
+	WelcomeTimeout time.Duration
+
+	// ExcludedDirs lists directory names skipped by discovery, detection and
+	// extraction, in addition to extractors.DefaultExcludedDirs
+	ExcludedDirs []string
+
+	// ExtensionOverrides maps file extensions (".tsx" or "tsx") to the
+	// language whose extractor handles them, taking precedence over the
+	// built-in mapping
+	ExtensionOverrides map[string]string
+
+	// MaxFileBytes skips source files larger than this many bytes (0 = no limit)
+	MaxFileBytes int64
+
+	// OnProgress, if set, is called after each language is extracted with
+	// the files done so far and an estimate of the time remaining
+	OnProgress func(ProgressEvent)
+
+	// RetryBudget caps the total HTTP retries across every LLM call in a run,
+	// so calls fail fast once it is spent (0 = each call retries independently)
+	RetryBudget int
+
+	// Tracer records spans for each step, extractor and external call
+	// (nil = the tracer carried by the context, a no-op by default)
+	Tracer tracing.Tracer
+
+	// ErrorMode selects whether non-fatal errors abort the run
+	// (empty = ErrorModeBestEffort)
+	ErrorMode ErrorMode
 }
 
+// Welcome page generation defaults
+const (
+	DefaultWelcomeRetries    = 2
+	DefaultWelcomeRetryDelay = 2 * time.Second
+	DefaultWelcomeTimeout    = 2 * time.Minute
+)
+
+// ErrorMode controls how Run reacts to errors in extraction and later steps
+type ErrorMode string
+
+const (
+	// ErrorModeBestEffort logs errors, continues with the remaining work and
+	// reports everything in the result
+	ErrorModeBestEffort ErrorMode = "best_effort"
+
+	// ErrorModeFailFast aborts the run at the first error
+	ErrorModeFailFast ErrorMode = "fail_fast"
+)
+
 // ExtractorPipeline orchestrates complete documentation extraction and site generation
 type ExtractorPipeline struct {
 	config         *ExtractorPipelineConfig
@@ -36,6 +132,8 @@ type ExtractorPipeline struct {
 	normalizer     *normalizer.Normalizer
 	welcomeGen     *welcome.Generator
 	llmOrch        *llm.Orchestrator
+	excluded       extractors.ExcludedDirs
+	extensions     map[string]extractors.Language
 }
 
 // NewExtractorPipeline creates a new documentation extraction pipeline
@@ -45,50 +143,155 @@ func NewExtractorPipeline(
 	llmOrch *llm.Orchestrator,
 ) *ExtractorPipeline {
 	registry := extractors.NewRegistry()
+	if err := extractors.RegisterAll(registry, runner); err != nil {
+		log.Printf("[Pipeline] Warning: Failed to register built-in extractors: %v", err)
+	}
 
-	// Register all extractors (assuming they're already registered in init())
-	// This would be done in the main package or via extractors.RegisterAll()
+	incrementalMgr := incremental.NewManager(runner, config.SourceDir)
+	if config.CheckpointPath != "" {
+		incrementalMgr.WithCheckpointPath(config.CheckpointPath)
+	}
 
 	return &ExtractorPipeline{
 		config:         config,
 		registry:       registry,
 		runner:         runner,
-		incrementalMgr: incremental.NewManager(runner, config.SourceDir),
+		incrementalMgr: incrementalMgr,
 		normalizer:     normalizer.NewNormalizer(config.DocsDir),
 		welcomeGen:     welcome.NewGenerator(llmOrch),
 		llmOrch:        llmOrch,
+		excluded:       extractors.NewExcludedDirs(config.ExcludedDirs...),
+		extensions:     extractors.ExtensionOverrides(config.ExtensionOverrides),
+	}
+}
+
+// Detector returns a language detector that skips the same directories as
+// the pipeline
+func (p *ExtractorPipeline) Detector() *extractors.Detector {
+	return extractors.NewDetector().
+		WithExcludedDirs(p.config.ExcludedDirs...).
+		WithExtensions(p.extensions)
+}
+
+// validateExtensionOverrides checks that every overridden extension maps to
+// a language with a registered extractor
+func (p *ExtractorPipeline) validateExtensionOverrides() error {
+	exts := make([]string, 0, len(p.extensions))
+	for ext := range p.extensions {
+		exts = append(exts, ext)
 	}
+	sort.Strings(exts)
+
+	for _, ext := range exts {
+		if _, err := p.registry.Get(p.extensions[ext]); err != nil {
+			return fmt.Errorf("invalid extension override %s: %w", ext, err)
+		}
+	}
+	return nil
 }
 
-// RegisterExtractor registers a language extractor with the pipeline registry.
+// RegisterExtractor registers a language extractor with the pipeline registry,
+// taking the place of the built-in extractor for that language.
 func (p *ExtractorPipeline) RegisterExtractor(extractor extractors.Extractor) error {
-	return p.registry.Register(extractor)
+	return p.registry.Replace(extractor)
+}
+
+// Run executes the complete documentation pipeline.
+// The returned result records which steps completed, even when Run fails;
+// files written by successful steps are left in place.
+func (p *ExtractorPipeline) Run(ctx context.Context) (*PipelineResult, error) {
+	if p.config.Tracer != nil {
+		ctx = tracing.WithTracer(ctx, p.config.Tracer)
+	}
+
+	ctx, span := tracing.Start(ctx, "pipeline.run")
+	result, err := p.run(ctx)
+	span.End(err)
+	return result, err
 }
 
-// Run executes the complete documentation pipeline
-func (p *ExtractorPipeline) Run(ctx context.Context) error {
+// run executes the pipeline steps, each in its own tracing span
+func (p *ExtractorPipeline) run(ctx context.Context) (*PipelineResult, error) {
 	log.Printf("[Pipeline] Starting documentation extraction pipeline")
 	log.Printf("[Pipeline] Source: %s, Output: %s", p.config.SourceDir, p.config.OutputDir)
 
+	result := &PipelineResult{}
+
+	if p.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.config.Timeout)
+		defer cancel()
+		log.Printf("[Pipeline] Timeout: %s", p.config.Timeout)
+	}
+
+	if p.config.RetryBudget > 0 {
+		ctx = httpbase.WithRetryBudget(ctx, httpbase.NewRetryBudget(p.config.RetryBudget))
+	}
+
+	// A fresh run discards any checkpoint left by an interrupted one
+	if p.config.Resume {
+		if err := p.incrementalMgr.LoadCheckpoint(); err != nil {
+			log.Printf("[Pipeline] Warning: Failed to load checkpoint, starting over: %v", err)
+		}
+	} else if err := p.incrementalMgr.ClearCheckpoint(); err != nil {
+		log.Printf("[Pipeline] Warning: Failed to clear checkpoint: %v", err)
+	}
+
 	// Step 1: Determine what needs to be extracted
-	filesToProcess, err := p.determineFilesToProcess(ctx)
+	discoverCtx, span := startStep(ctx, result, StepDiscover)
+	if err := p.validateExtensionOverrides(); err != nil {
+		span.End(err)
+		result.fail(StepDiscover, err)
+		return result, err
+	}
+
+	filesToProcess, err := p.determineFilesToProcess(discoverCtx, result)
 	if err != nil {
-		return fmt.Errorf("failed to determine files to process: %w", err)
+		err = fmt.Errorf("failed to determine files to process: %w", err)
+		span.End(err)
+		result.fail(StepDiscover, err)
+		return result, err
 	}
+	if err := checkContext(ctx, "file discovery"); err != nil {
+		span.End(err)
+		result.fail(StepDiscover, err)
+		return result, err
+	}
+	span.End(nil)
+	result.succeed(StepDiscover)
 
 	if len(filesToProcess) == 0 {
 		log.Printf("[Pipeline] No files to process")
-		return nil
+		logSkipped(result)
+		return result, nil
 	}
 
 	log.Printf("[Pipeline] Found %d files to process", len(filesToProcess))
 
 	// Step 2: Extract documentation for each language
-	stats, errors := p.extractDocumentation(ctx, filesToProcess)
+	if p.config.Resume {
+		var resumedStats extractors.ExtractionStats
+		filesToProcess, resumedStats, result.Resumed = p.skipCompletedLanguages(filesToProcess, result)
+		result.Stats = resumedStats
+	}
+
+	extractCtx, span := startStep(ctx, result, StepExtract)
+	stats, errors := p.extractDocumentation(extractCtx, filesToProcess, result)
+	stats.FilesProcessed += result.Stats.FilesProcessed
+	stats.DocsGenerated += result.Stats.DocsGenerated
+	stats.DocCoverage.Add(result.Stats.DocCoverage)
+	result.Stats = stats
+	result.addErrors(errors...)
 
 	// Log statistics
 	log.Printf("[Pipeline] Extraction complete: %d files processed, %d docs generated",
 		stats.FilesProcessed, stats.DocsGenerated)
+	if stats.DocCoverage.Total > 0 {
+		log.Printf("[Pipeline] Doc coverage: %d/%d public symbols documented (%.1f%%)",
+			stats.DocCoverage.Documented, stats.DocCoverage.Total, stats.DocCoverage.Ratio()*100)
+	}
+
+	logSkipped(result)
 
 	if len(errors) > 0 {
 		log.Printf("[Pipeline] %d extraction errors occurred", len(errors))
@@ -97,64 +300,212 @@ func (p *ExtractorPipeline) Run(ctx context.Context) error {
 		}
 	}
 
+	if err := checkContext(ctx, "extraction"); err != nil {
+		span.End(err)
+		result.fail(StepExtract, err)
+		return result, err
+	}
+	if p.failFast() && len(errors) > 0 {
+		err := fmt.Errorf("extraction failed: %w", errors[0])
+		span.End(err)
+		result.fail(StepExtract, err)
+		return result, err
+	}
+	span.End(nil)
+	result.succeed(StepExtract)
+
 	// Step 3: Normalize markdown files with Jekyll front matter
 	if stats.DocsGenerated > 0 {
 		log.Printf("[Pipeline] Normalizing markdown files...")
-		normalized, normErrors := p.normalizer.NormalizeDir(p.config.OutputDir)
-		log.Printf("[Pipeline] Normalized %d markdown files", normalized)
+		_, span := startStep(ctx, result, StepNormalize)
+		normalized, normErrors := p.normalizer.NormalizeDirStats(p.config.OutputDir)
+		log.Printf("[Pipeline] Normalized %d markdown files (%d rewritten, %d unchanged)",
+			normalized.Processed(), normalized.Changed, normalized.Unchanged)
 
 		if len(normErrors) > 0 {
 			log.Printf("[Pipeline] %d normalization errors occurred", len(normErrors))
+			result.addErrors(normErrors...)
+			if p.failFast() {
+				err := fmt.Errorf("normalization failed: %w", normErrors[0])
+				span.End(err)
+				result.fail(StepNormalize, err)
+				return result, err
+			}
+		}
+		if err := checkContext(ctx, "normalization"); err != nil {
+			span.End(err)
+			result.fail(StepNormalize, err)
+			return result, err
+		}
+		span.End(nil)
+		result.succeed(StepNormalize)
+	} else {
+		result.skip(StepNormalize)
+	}
+
+	// Step 4: Generate LLM-powered welcome page if enabled.
+	// Extraction can run for a long time, so recheck the endpoint first and
+	// skip LLM steps rather than fail them when it is unreachable.
+	if p.config.GenerateWelcome && p.llmOrch != nil {
+		if err := p.llmOrch.Probe(ctx); err != nil {
+			if p.failFast() {
+				err = fmt.Errorf("LLM unavailable: %w", err)
+				result.fail(StepWelcome, err)
+				return result, err
+			}
+			log.Printf("[Pipeline] Warning: LLM unavailable, disabling LLM steps: %v", err)
+			result.addErrors(err)
+			p.llmOrch = nil
 		}
 	}
 
-	// Step 4: Generate LLM-powered welcome page if enabled
 	if p.config.GenerateWelcome && p.llmOrch != nil {
+		if estimate, err := p.EstimateLLMCost(); err != nil {
+			log.Printf("[Pipeline] Warning: %v", err)
+		} else {
+			result.LLMEstimate = estimate
+			log.Printf("[Pipeline] Estimated LLM usage: %d calls, %d tokens in, up to %d tokens out, $%.4f",
+				estimate.Calls, estimate.TokensIn, estimate.TokensOut, estimate.USD)
+		}
+
 		log.Printf("[Pipeline] Generating welcome page...")
-		if err := p.generateWelcomePage(ctx); err != nil {
+		welcomeCtx, span := startStep(ctx, result, StepWelcome)
+		err := p.generateWelcomePage(welcomeCtx)
+		if ctxErr := checkContext(ctx, "welcome page generation"); ctxErr != nil {
+			span.End(ctxErr)
+			result.fail(StepWelcome, ctxErr)
+			return result, ctxErr
+		}
+		span.End(err)
+		if err != nil {
 			log.Printf("[Pipeline] Warning: Welcome page generation failed: %v", err)
+			result.fail(StepWelcome, err)
+			if p.failFast() {
+				return result, fmt.Errorf("welcome page generation failed: %w", err)
+			}
 		} else {
 			log.Printf("[Pipeline] Welcome page generated successfully")
+			result.succeed(StepWelcome)
 		}
+	} else {
+		result.skip(StepWelcome)
 	}
 
 	// Step 5: Validate Jekyll site if enabled
 	if p.config.ValidateJekyll {
 		log.Printf("[Pipeline] Validating Jekyll site...")
-		if err := p.validateJekyllSite(ctx); err != nil {
+		validateCtx, span := startStep(ctx, result, StepValidate)
+		err := p.validateJekyllSite(validateCtx)
+		if ctxErr := checkContext(ctx, "Jekyll validation"); ctxErr != nil {
+			span.End(ctxErr)
+			result.fail(StepValidate, ctxErr)
+			return result, ctxErr
+		}
+		span.End(err)
+		if err != nil {
 			log.Printf("[Pipeline] Warning: Jekyll validation failed: %v", err)
+			result.fail(StepValidate, err)
+			if p.failFast() {
+				return result, fmt.Errorf("Jekyll validation failed: %w", err)
+			}
 		} else {
 			log.Printf("[Pipeline] Jekyll site validation successful")
+			result.succeed(StepValidate)
 		}
+	} else {
+		result.skip(StepValidate)
 	}
 
 	// Step 6: Deploy to gh-pages if enabled
 	if p.config.DeployGHPages {
 		log.Printf("[Pipeline] Deploying to gh-pages...")
-		if err := p.deployToGHPages(ctx); err != nil {
-			return fmt.Errorf("gh-pages deployment failed: %w", err)
+		deployCtx, span := startStep(ctx, result, StepDeploy)
+		err := p.deployToGHPages(deployCtx)
+		span.End(err)
+		if err != nil {
+			err = fmt.Errorf("gh-pages deployment failed: %w", err)
+			result.fail(StepDeploy, err)
+			return result, err
 		}
 		log.Printf("[Pipeline] Deployed to gh-pages successfully")
+		result.succeed(StepDeploy)
+	} else {
+		result.skip(StepDeploy)
 	}
 
 	// Step 7: Update incremental cache
 	if p.config.Incremental {
 		log.Printf("[Pipeline] Updating incremental cache...")
-		if err := p.incrementalMgr.UpdateCommit(ctx); err != nil {
-			log.Printf("[Pipeline] Warning: Failed to update cache: %v", err)
+		cacheCtx, span := startStep(ctx, result, StepCache)
+		cacheErr := p.incrementalMgr.UpdateCommit(cacheCtx)
+		if cacheErr != nil {
+			log.Printf("[Pipeline] Warning: Failed to update cache: %v", cacheErr)
 		}
 		if err := p.incrementalMgr.SaveCache(); err != nil {
 			log.Printf("[Pipeline] Warning: Failed to save cache: %v", err)
+			cacheErr = err
+		}
+		span.End(cacheErr)
+		if cacheErr != nil {
+			result.fail(StepCache, cacheErr)
+			if p.failFast() {
+				return result, fmt.Errorf("cache update failed: %w", cacheErr)
+			}
+		} else {
+			result.succeed(StepCache)
 		}
+	} else {
+		result.skip(StepCache)
+	}
+
+	if err := p.incrementalMgr.ClearCheckpoint(); err != nil {
+		log.Printf("[Pipeline] Warning: Failed to clear checkpoint: %v", err)
 	}
 
 	log.Printf("[Pipeline] Documentation pipeline completed successfully")
+	return result, nil
+}
+
+// failFast reports whether the first error should abort the run
+func (p *ExtractorPipeline) failFast() bool {
+	return p.config.ErrorMode == ErrorModeFailFast
+}
+
+// startStep begins the tracing span for a pipeline step; ending it also
+// records the step's wall-clock time in result.Timings
+func startStep(ctx context.Context, result *PipelineResult, step string) (context.Context, tracing.Span) {
+	ctx, span := tracing.Start(ctx, "pipeline."+step)
+	return ctx, newTimedSpan(span, func(d time.Duration) {
+		result.setTiming(step, d)
+	})
+}
+
+// logSkipped summarizes skipped files by reason
+func logSkipped(result *PipelineResult) {
+	counts := result.SkippedCounts()
+	reasons := make([]string, 0, len(counts))
+	for reason := range counts {
+		reasons = append(reasons, string(reason))
+	}
+	sort.Strings(reasons)
+
+	for _, reason := range reasons {
+		log.Printf("[Pipeline] Skipped %d file(s): %s", counts[SkipReason(reason)], reason)
+	}
+}
+
+// checkContext reports whether the run was cancelled or timed out during step
+func checkContext(ctx context.Context, step string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("pipeline aborted during %s: %w", step, err)
+	}
 	return nil
 }
 
-// determineFilesToProcess determines which files need documentation extraction
-func (p *ExtractorPipeline) determineFilesToProcess(ctx context.Context) (map[extractors.Language][]string, error) {
-	files := make(map[extractors.Language][]string)
+// determineFilesToProcess determines which files need documentation extraction,
+// recording the files it passes over in run
+func (p *ExtractorPipeline) determineFilesToProcess(ctx context.Context, run *PipelineResult) (map[extractors.Language][]string, error) {
+	var candidates []string
 
 	if p.config.Incremental {
 		// Load existing cache
@@ -170,33 +521,58 @@ func (p *ExtractorPipeline) determineFilesToProcess(ctx context.Context) (map[ex
 
 		log.Printf("[Pipeline] Incremental mode: %d changed files detected", len(changedFiles))
 
-		// Group by language
-		files = p.groupFilesByLanguage(changedFiles)
+		for _, file := range changedFiles {
+			if p.excluded.ContainsPath(filepath.Dir(file)) {
+				run.addSkipped(SkipExcludedDir, file)
+				continue
+			}
+			if info, err := os.Stat(file); err == nil && p.tooLarge(info.Size()) {
+				run.addSkipped(SkipTooLarge, file)
+				continue
+			}
+			candidates = append(candidates, file)
+		}
 	} else {
 		// Full extraction mode - find all source files
 		log.Printf("[Pipeline] Full extraction mode")
 
-		var allFiles []string
 		err := filepath.Walk(p.config.SourceDir, func(path string, info os.FileInfo, err error) error {
-			if err != nil || info.IsDir() {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if err != nil {
+				return nil
+			}
+
+			// Skip excluded directories below the source root
+			if info.IsDir() {
+				if path != p.config.SourceDir && p.excluded.Contains(info.Name()) {
+					run.addSkipped(SkipExcludedDir, path)
+					return filepath.SkipDir
+				}
 				return nil
 			}
 
-			// Skip common non-source directories
-			if shouldSkipPath(path) {
+			if p.tooLarge(info.Size()) {
+				run.addSkipped(SkipTooLarge, path)
 				return nil
 			}
 
-			allFiles = append(allFiles, path)
+			candidates = append(candidates, path)
 			return nil
 		})
 
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		files = p.groupFilesByLanguage(allFiles)
+	for _, file := range candidates {
+		if p.languageOf(file) == "" {
+			run.addSkipped(SkipUnknownExtension, file)
+		}
 	}
+	files := p.groupFilesByLanguage(candidates)
 
 	// Filter by configured languages if specified
 	if len(p.config.Languages) > 0 {
@@ -207,19 +583,28 @@ func (p *ExtractorPipeline) determineFilesToProcess(ctx context.Context) (map[ex
 				filtered[lang] = fileList
 			}
 		}
+		for _, lang := range sortedLanguages(files) {
+			if _, ok := filtered[lang]; !ok {
+				run.addSkipped(SkipLanguageNotSelected, files[lang]...)
+			}
+		}
 		files = filtered
 	}
 
 	return files, nil
 }
 
+// tooLarge reports whether a file of size bytes exceeds MaxFileBytes
+func (p *ExtractorPipeline) tooLarge(size int64) bool {
+	return p.config.MaxFileBytes > 0 && size > p.config.MaxFileBytes
+}
+
 // groupFilesByLanguage groups files by their programming language
 func (p *ExtractorPipeline) groupFilesByLanguage(files []string) map[extractors.Language][]string {
 	grouped := make(map[extractors.Language][]string)
 
 	for _, file := range files {
-		lang := detectLanguageFromFile(file)
-		if lang != "" {
+		if lang := p.languageOf(file); lang != "" {
 			grouped[lang] = append(grouped[lang], file)
 		}
 	}
@@ -227,88 +612,288 @@ func (p *ExtractorPipeline) groupFilesByLanguage(files []string) map[extractors.
 	return grouped
 }
 
-// extractDocumentation extracts documentation for all files
+// languageOf returns the language of file, honoring extension overrides
+func (p *ExtractorPipeline) languageOf(file string) extractors.Language {
+	if lang, ok := p.extensions[strings.ToLower(filepath.Ext(file))]; ok {
+		return lang
+	}
+	return detectLanguageFromFile(file)
+}
+
+// extractDocumentation extracts documentation for all files,
+// recording per-language doc coverage in run
 func (p *ExtractorPipeline) extractDocumentation(
 	ctx context.Context,
 	filesByLanguage map[extractors.Language][]string,
+	run *PipelineResult,
 ) (extractors.ExtractionStats, []error) {
 
 	totalStats := extractors.ExtractionStats{}
 	var allErrors []error
 
-	for lang, files := range filesByLanguage {
-		log.Printf("[Pipeline] Extracting %s documentation (%d files)...", lang, len(files))
+	total := 0
+	for _, files := range filesByLanguage {
+		total += len(files)
+	}
+	eta := NewETAEstimator(total)
 
-		extractor, err := p.registry.Get(lang)
-		if err != nil {
-			errMsg := fmt.Errorf("no extractor for %s: %w", lang, err)
-			log.Printf("[Pipeline] ⚠️  ERROR: %v", errMsg)
-			allErrors = append(allErrors, errMsg)
-			continue
+	for _, lang := range sortedLanguages(filesByLanguage) {
+		files := filesByLanguage[lang]
+
+		langCtx, span := tracing.Start(ctx, "extractor."+string(lang))
+		span = newTimedSpan(span, func(d time.Duration) {
+			run.setLanguageTiming(lang, d)
+		})
+		result, errs := p.extractLanguage(langCtx, lang, files, run)
+		if result == nil && len(errs) > 0 {
+			span.End(errs[0])
+		} else {
+			span.End(nil)
+		}
+		allErrors = append(allErrors, errs...)
+		if result != nil {
+			totalStats.FilesProcessed += result.Stats.FilesProcessed
+			totalStats.DocsGenerated += result.Stats.DocsGenerated
+			totalStats.DocCoverage.Add(result.Stats.DocCoverage)
 		}
 
-		// Validate extractor is available
-		if err := extractor.Validate(ctx); err != nil {
-			errMsg := fmt.Errorf("%s tools not available: %w", lang, err)
-			log.Printf("[Pipeline] ⚠️  ERROR: %v", errMsg)
-			allErrors = append(allErrors, errMsg)
-			continue
+		p.reportProgress(eta.Advance(len(files)), lang)
+
+		if p.failFast() && len(errs) > 0 {
+			log.Printf("[Pipeline] Fail-fast: stopping extraction after %s error", lang)
+			break
 		}
+	}
+
+	return totalStats, allErrors
+}
+
+// extractLanguage runs the extractor for one language, returning nil and the
+// error when it could not run, or its result and non-fatal errors
+func (p *ExtractorPipeline) extractLanguage(
+	ctx context.Context,
+	lang extractors.Language,
+	files []string,
+	run *PipelineResult,
+) (*extractors.ExtractResult, []error) {
+	if ctx.Err() != nil {
+		return nil, []error{fmt.Errorf("%s extraction skipped: %w", lang, ctx.Err())}
+	}
+
+	log.Printf("[Pipeline] Extracting %s documentation (%d files)...", lang, len(files))
+
+	extractor, err := p.registry.Get(lang)
+	if err != nil {
+		errMsg := fmt.Errorf("no extractor for %s: %w", lang, err)
+		log.Printf("[Pipeline] ⚠️  ERROR: %v", errMsg)
+		run.addSkipped(SkipNoExtractor, files...)
+		return nil, []error{errMsg}
+	}
+
+	// Validate extractor is available
+	if err := validateExtractor(ctx, extractor); err != nil {
+		errMsg := fmt.Errorf("%s tools not available: %w", lang, err)
+		log.Printf("[Pipeline] ⚠️  ERROR: %v", errMsg)
+		run.addSkipped(SkipToolsUnavailable, files...)
+		return nil, []error{errMsg}
+	}
 
-		// Extract documentation
-		request := &extractors.ExtractRequest{
-			Language:  lang,
-			SourceDir: p.config.SourceDir,
-			OutputDir: filepath.Join(p.config.OutputDir, string(lang)),
+	// Extract into a private scratch directory so concurrent runs cannot
+	// clobber each other, then publish the output into place
+	scratch, err := newScratchDir(p.config.OutputDir, lang)
+	if err != nil {
+		errMsg := fmt.Errorf("%s extraction failed: %w", lang, err)
+		log.Printf("[Pipeline] ⚠️  ERROR: %v", errMsg)
+		return nil, []error{errMsg}
+	}
+	defer func() {
+		if err := scratch.Remove(); err != nil {
+			log.Printf("[Pipeline] Warning: Failed to remove scratch directory: %v", err)
 		}
+	}()
+
+	request := &extractors.ExtractRequest{
+		Language:        lang,
+		SourceDir:       p.config.SourceDir,
+		OutputDir:       scratch.output,
+		ScratchDir:      scratch.work,
+		OutputFormat:    p.config.OutputFormat,
+		OutputExtension: p.config.OutputExtension,
+		ExcludedDirs:    p.config.ExcludedDirs,
+	}
 
-		result, err := extractor.Extract(ctx, request)
-		if err != nil {
-			errMsg := fmt.Errorf("%s extraction failed: %w", lang, err)
-			log.Printf("[Pipeline] ⚠️  ERROR: %v", errMsg)
-			allErrors = append(allErrors, errMsg)
+	result, err := runExtractor(ctx, extractor, request)
+	if err == nil {
+		result.Files, err = extractors.NormalizeOutputFiles(request, result.Files)
+	}
+	if err == nil {
+		result.Errors = append(result.Errors, applyTransforms(p.config.Transforms, result.Files)...)
+	}
+	if err == nil {
+		result.Files, err = scratch.Publish(filepath.Join(p.config.OutputDir, string(lang)), result.Files)
+	}
+	if err != nil {
+		errMsg := fmt.Errorf("%s extraction failed: %w", lang, err)
+		log.Printf("[Pipeline] ⚠️  ERROR: %v", errMsg)
+		return nil, []error{errMsg}
+	}
+
+	run.setCoverage(lang, result.Stats.DocCoverage)
+
+	// Register in incremental cache
+	if p.config.Incremental {
+		for _, file := range files {
+			p.incrementalMgr.RegisterDocumentation(file, result.Files...)
+		}
+		p.incrementalMgr.RegisterLanguage(string(lang), files...)
+	}
+
+	// Record progress so an interrupted run can resume after this language
+	p.incrementalMgr.GetCheckpoint().MarkCompleted(string(lang), incremental.LanguageCheckpoint{
+		SourceFiles:       files,
+		DocFiles:          result.Files,
+		FilesProcessed:    result.Stats.FilesProcessed,
+		DocsGenerated:     result.Stats.DocsGenerated,
+		SymbolsDocumented: result.Stats.DocCoverage.Documented,
+		SymbolsTotal:      result.Stats.DocCoverage.Total,
+	})
+	if err := p.incrementalMgr.SaveCheckpoint(); err != nil {
+		log.Printf("[Pipeline] Warning: Failed to save checkpoint: %v", err)
+	}
+
+	log.Printf("[Pipeline] %s: %d files processed, %d docs generated",
+		lang, result.Stats.FilesProcessed, result.Stats.DocsGenerated)
+
+	return result, result.Errors
+}
+
+// reportProgress logs progress after lang and passes it to OnProgress
+func (p *ExtractorPipeline) reportProgress(event ProgressEvent, lang extractors.Language) {
+	event.Language = string(lang)
+	log.Printf("[Pipeline] Progress: %d/%d files, elapsed %s, ETA %s",
+		event.FilesDone, event.FilesTotal, event.Elapsed.Round(time.Second), event.ETA.Round(time.Second))
+
+	if p.config.OnProgress != nil {
+		p.config.OnProgress(event)
+	}
+}
+
+// skipCompletedLanguages removes languages recorded in the checkpoint,
+// returning the remaining work and the stats of the skipped languages
+func (p *ExtractorPipeline) skipCompletedLanguages(
+	filesByLanguage map[extractors.Language][]string,
+	run *PipelineResult,
+) (map[extractors.Language][]string, extractors.ExtractionStats, []string) {
+
+	checkpoint := p.incrementalMgr.GetCheckpoint()
+	remaining := make(map[extractors.Language][]string)
+	stats := extractors.ExtractionStats{}
+	var resumed []string
+
+	for _, lang := range sortedLanguages(filesByLanguage) {
+		entry, ok := checkpoint.Get(string(lang))
+		if !ok {
+			remaining[lang] = filesByLanguage[lang]
 			continue
 		}
 
-		// Aggregate statistics
-		totalStats.FilesProcessed += result.Stats.FilesProcessed
-		totalStats.DocsGenerated += result.Stats.DocsGenerated
+		log.Printf("[Pipeline] %s already extracted in interrupted run, skipping", lang)
+		resumed = append(resumed, string(lang))
+		stats.FilesProcessed += entry.FilesProcessed
+		stats.DocsGenerated += entry.DocsGenerated
 
-		// Track errors
-		allErrors = append(allErrors, result.Errors...)
+		coverage := extractors.DocCoverage{Documented: entry.SymbolsDocumented, Total: entry.SymbolsTotal}
+		stats.DocCoverage.Add(coverage)
+		run.setCoverage(lang, coverage)
 
-		// Register in incremental cache
 		if p.config.Incremental {
-			for _, file := range files {
-				p.incrementalMgr.RegisterDocumentation(file, result.Files...)
+			for _, file := range entry.SourceFiles {
+				p.incrementalMgr.RegisterDocumentation(file, entry.DocFiles...)
 			}
-			p.incrementalMgr.RegisterLanguage(string(lang), files...)
+			p.incrementalMgr.RegisterLanguage(string(lang), entry.SourceFiles...)
 		}
-
-		log.Printf("[Pipeline] %s: %d files processed, %d docs generated",
-			lang, result.Stats.FilesProcessed, result.Stats.DocsGenerated)
 	}
 
-	return totalStats, allErrors
+	return remaining, stats, resumed
+}
+
+// sortedLanguages returns the map's languages in a stable order
+func sortedLanguages(filesByLanguage map[extractors.Language][]string) []extractors.Language {
+	langs := make([]extractors.Language, 0, len(filesByLanguage))
+	for lang := range filesByLanguage {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i] < langs[j] })
+	return langs
 }
 
 // generateWelcomePage generates LLM-powered welcome page from README
 func (p *ExtractorPipeline) generateWelcomePage(ctx context.Context) error {
+	opts, err := p.welcomeOptions()
+	if err != nil {
+		return err
+	}
+
+	_, err = p.welcomeGen.Generate(ctx, opts)
+	return err
+}
+
+// welcomeOptions returns the welcome page options for this pipeline
+func (p *ExtractorPipeline) welcomeOptions() (welcome.GenerateOptions, error) {
 	readmePath := filepath.Join(p.config.SourceDir, "README.md")
 	if _, err := os.Stat(readmePath); os.IsNotExist(err) {
-		return fmt.Errorf("README.md not found")
+		return welcome.GenerateOptions{}, fmt.Errorf("README.md not found")
+	}
+
+	retries := p.config.WelcomeRetries
+	switch {
+	case retries == 0:
+		retries = DefaultWelcomeRetries
+	case retries < 0:
+		retries = 0
+	}
+	retryDelay := p.config.WelcomeRetryDelay
+	if retryDelay == 0 {
+		retryDelay = DefaultWelcomeRetryDelay
+	}
+	timeout := p.config.WelcomeTimeout
+	if timeout == 0 {
+		timeout = DefaultWelcomeTimeout
 	}
 
-	opts := welcome.GenerateOptions{
+	return welcome.GenerateOptions{
 		ReadmePath: readmePath,
 		OutputPath: filepath.Join(p.config.DocsDir, "index.md"),
 		ProjectDir: p.config.SourceDir,
 		Title:      "Home",
 		NavOrder:   1,
+		Timeout:    timeout,
+		Retries:    retries,
+		RetryDelay: retryDelay,
+		Languages:  p.config.WelcomeLanguages,
+	}, nil
+}
+
+// EstimateLLMCost projects the token usage and price of the LLM steps this
+// run would perform. Retries are not included. Steps that are disabled or
+// cannot run contribute nothing.
+func (p *ExtractorPipeline) EstimateLLMCost() (llm.CostEstimate, error) {
+	var estimate llm.CostEstimate
+
+	if p.config.GenerateWelcome && p.llmOrch != nil {
+		opts, err := p.welcomeOptions()
+		if err != nil {
+			return estimate, nil // The welcome step will be reported as failed
+		}
+
+		welcomeEstimate, err := p.welcomeGen.Estimate(opts)
+		if err != nil {
+			return estimate, fmt.Errorf("failed to estimate welcome page cost: %w", err)
+		}
+		estimate.Add(welcomeEstimate)
 	}
 
-	_, err := p.welcomeGen.Generate(ctx, opts)
-	return err
+	return estimate, nil
 }
 
 // validateJekyllSite validates the Jekyll site can be built
@@ -365,35 +950,3 @@ func detectLanguageFromFile(file string) extractors.Language {
 		return ""
 	}
 }
-
-// shouldSkipPath checks if path should be skipped during file discovery
-func shouldSkipPath(path string) bool {
-	skipDirs := map[string]struct{}{
-		"node_modules": {},
-		".git":         {},
-		".github":      {},
-		"vendor":       {},
-		"target":       {},
-		"dist":         {},
-		"build":        {},
-		"_site":        {},
-		".taskmaster":  {},
-		".aurumcode":   {},
-	}
-
-	clean := filepath.Clean(path)
-	for {
-		base := filepath.Base(clean)
-		if _, skip := skipDirs[base]; skip {
-			return true
-		}
-
-		parent := filepath.Dir(clean)
-		if parent == clean || parent == "." || parent == string(filepath.Separator) {
-			break
-		}
-		clean = parent
-	}
-
-	return false
-}
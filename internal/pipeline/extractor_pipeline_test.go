@@ -2,12 +2,26 @@ package pipeline
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/incremental"
 	"github.com/Mpaape/AurumCode/internal/documentation/site"
+	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/internal/llm/httpbase"
+	"github.com/Mpaape/AurumCode/internal/tracing"
+	"github.com/Mpaape/AurumCode/internal/llm/provider/litellm"
+	"github.com/Mpaape/AurumCode/internal/llm/provider/openai"
 )
 
 func TestNewExtractorPipeline(t *testing.T) {
@@ -69,31 +83,6 @@ func TestDetectLanguageFromFile(t *testing.T) {
 	}
 }
 
-func TestShouldSkipPath(t *testing.T) {
-	tests := []struct {
-		path string
-		want bool
-	}{
-		{"src/main.go", false},
-		{"node_modules/pkg/index.js", true},
-		{".git/config", true},
-		{"vendor/lib/code.go", true},
-		{"target/release/app", true},
-		{"dist/bundle.js", true},
-		{".taskmaster/tasks.json", true},
-		{"internal/app/main.go", false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.path, func(t *testing.T) {
-			got := shouldSkipPath(tt.path)
-			if got != tt.want {
-				t.Errorf("shouldSkipPath(%q) = %v, want %v", tt.path, got, tt.want)
-			}
-		})
-	}
-}
-
 func TestGroupFilesByLanguage(t *testing.T) {
 	config := &ExtractorPipelineConfig{
 		SourceDir: ".",
@@ -141,6 +130,84 @@ func TestGroupFilesByLanguage(t *testing.T) {
 	}
 }
 
+func TestGroupFilesByLanguage_ExtensionOverrides(t *testing.T) {
+	config := &ExtractorPipelineConfig{
+		SourceDir:          ".",
+		OutputDir:          "docs",
+		ExtensionOverrides: map[string]string{"DSL": "python", ".js": "typescript"},
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	grouped := pipeline.groupFilesByLanguage([]string{"main.go", "rules.dsl", "app.js"})
+
+	if got := grouped[extractors.LanguagePython]; !reflect.DeepEqual(got, []string{"rules.dsl"}) {
+		t.Errorf("Python files = %v, want [rules.dsl]", got)
+	}
+	if got := grouped[extractors.LanguageTypeScript]; !reflect.DeepEqual(got, []string{"app.js"}) {
+		t.Errorf("TypeScript files = %v, want [app.js]", got)
+	}
+	if _, ok := grouped[extractors.LanguageJavaScript]; ok {
+		t.Error("overridden extension should not route to its default language")
+	}
+
+	detector := pipeline.Detector()
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "rules.dsl"), []byte("rule\n"), 0644)
+	detection, err := detector.Detect(context.Background(), tmpDir)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if stats := detection.Languages[extractors.LanguagePython]; stats == nil || stats.FileCount != 1 {
+		t.Errorf("detector should count rules.dsl as Python, got %+v", detection.Languages)
+	}
+}
+
+func TestExtractorPipeline_Run_ExtensionOverrideRoutesToExtractor(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "rules.dsl"), []byte("rule"), 0644)
+
+	docsDir := filepath.Join(tmpDir, "docs")
+	config := &ExtractorPipelineConfig{
+		SourceDir:          tmpDir,
+		OutputDir:          docsDir,
+		DocsDir:            docsDir,
+		ExtensionOverrides: map[string]string{".dsl": "python"},
+		CheckpointPath:     filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	extractor := &fakeExtractor{lang: extractors.LanguagePython}
+	if err := pipeline.RegisterExtractor(extractor); err != nil {
+		t.Fatalf("RegisterExtractor failed: %v", err)
+	}
+
+	if _, err := pipeline.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if extractor.calls != 1 {
+		t.Errorf("Expected the Python extractor to run once, ran %d times", extractor.calls)
+	}
+}
+
+func TestExtractorPipeline_Run_ExtensionOverrideNeedsExtractor(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := &ExtractorPipelineConfig{
+		SourceDir:          tmpDir,
+		OutputDir:          filepath.Join(tmpDir, "docs"),
+		DocsDir:            filepath.Join(tmpDir, "docs"),
+		ExtensionOverrides: map[string]string{".cob": "cobol"},
+		CheckpointPath:     filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	result, err := NewExtractorPipeline(config, site.NewMockRunner(), nil).Run(context.Background())
+	if !errors.Is(err, extractors.ErrNotRegistered) {
+		t.Fatalf("Expected ErrNotRegistered, got %v", err)
+	}
+	if got := result.FailedStep(); got != StepDiscover {
+		t.Errorf("Expected failed step %q, got %q", StepDiscover, got)
+	}
+}
+
 func TestExtractorPipeline_DetermineFilesToProcess_FullMode(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -167,7 +234,7 @@ func TestExtractorPipeline_DetermineFilesToProcess_FullMode(t *testing.T) {
 	runner := site.NewMockRunner()
 	pipeline := NewExtractorPipeline(config, runner, nil)
 
-	files, err := pipeline.determineFilesToProcess(context.Background())
+	files, err := pipeline.determineFilesToProcess(context.Background(), &PipelineResult{})
 	if err != nil {
 		t.Fatalf("determineFilesToProcess failed: %v", err)
 	}
@@ -210,7 +277,7 @@ func TestExtractorPipeline_LanguageFilter(t *testing.T) {
 	runner := site.NewMockRunner()
 	pipeline := NewExtractorPipeline(config, runner, nil)
 
-	files, err := pipeline.determineFilesToProcess(context.Background())
+	files, err := pipeline.determineFilesToProcess(context.Background(), &PipelineResult{})
 	if err != nil {
 		t.Fatalf("determineFilesToProcess failed: %v", err)
 	}
@@ -284,3 +351,879 @@ func TestExtractorPipeline_Config(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractorPipeline_Run_Timeout(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+
+	config := &ExtractorPipelineConfig{
+		SourceDir:      tmpDir,
+		OutputDir:      filepath.Join(tmpDir, "docs"),
+		DocsDir:        filepath.Join(tmpDir, "docs"),
+		ValidateJekyll: true,
+		Timeout:        50 * time.Millisecond,
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	// Every command hangs well past the pipeline timeout
+	runner := site.NewMockRunner().WithDelay(5 * time.Second)
+	pipeline := NewExtractorPipeline(config, runner, nil)
+	if err := pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguageGo}); err != nil {
+		t.Fatalf("RegisterExtractor failed: %v", err)
+	}
+
+	start := time.Now()
+	result, err := pipeline.Run(context.Background())
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("Expected pipeline to abort on timeout")
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded error, got: %v", err)
+	}
+
+	if elapsed > 2*time.Second {
+		t.Errorf("Pipeline should abort promptly, took %v", elapsed)
+	}
+
+	// The step cut off by the timeout is reported as aborted
+	if got := result.FailedStep(); got != StepValidate {
+		t.Fatalf("Expected failed step %q, got %q (steps %+v)", StepValidate, got, result.Steps)
+	}
+	if step, _ := result.Step(StepValidate); !strings.Contains(step.Error, "pipeline aborted") {
+		t.Errorf("Expected the step error to report the abort, got %q", step.Error)
+	}
+}
+
+// fakeExtractor writes a single markdown page for its language
+type fakeExtractor struct {
+	lang     extractors.Language
+	calls    int
+	hook     func() error // Optional failure injected before writing
+	coverage extractors.DocCoverage
+	lastReq  *extractors.ExtractRequest
+	lastCtx  context.Context
+	fileName string // Generated file name (empty = index.md)
+
+	validateErr error // Optional Validate failure
+}
+
+func (f *fakeExtractor) Extract(ctx context.Context, req *extractors.ExtractRequest) (*extractors.ExtractResult, error) {
+	f.calls++
+	f.lastReq = req
+	f.lastCtx = ctx
+	if f.hook != nil {
+		if err := f.hook(); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(req.OutputDir, 0755); err != nil {
+		return nil, err
+	}
+	name := f.fileName
+	if name == "" {
+		name = "index.md"
+	}
+	path := filepath.Join(req.OutputDir, name)
+	if err := os.WriteFile(path, []byte("# API\n"), 0644); err != nil {
+		return nil, err
+	}
+	return &extractors.ExtractResult{
+		Language: f.lang,
+		Files:    []string{path},
+		Stats:    extractors.ExtractionStats{FilesProcessed: 1, DocsGenerated: 1, DocCoverage: f.coverage},
+	}, nil
+}
+
+func (f *fakeExtractor) Validate(ctx context.Context) error {
+	return f.validateErr
+}
+
+func (f *fakeExtractor) Language() extractors.Language {
+	return f.lang
+}
+
+func TestExtractorPipeline_ExcludedDirsSharedAcrossSteps(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, file := range []string{"main.go", "generated/models.go", "vendor/dep/dep.go"} {
+		path := filepath.Join(tmpDir, file)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte("package main"), 0644)
+	}
+
+	docsDir := filepath.Join(tmpDir, "docs")
+	config := &ExtractorPipelineConfig{
+		SourceDir:      tmpDir,
+		OutputDir:      docsDir,
+		DocsDir:        docsDir,
+		ExcludedDirs:   []string{"generated"},
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	extractor := &fakeExtractor{lang: extractors.LanguageGo}
+	if err := pipeline.RegisterExtractor(extractor); err != nil {
+		t.Fatalf("RegisterExtractor failed: %v", err)
+	}
+
+	// Discovery
+	files, err := pipeline.determineFilesToProcess(context.Background(), &PipelineResult{})
+	if err != nil {
+		t.Fatalf("determineFilesToProcess failed: %v", err)
+	}
+	want := []string{filepath.Join(tmpDir, "main.go")}
+	if !reflect.DeepEqual(files[extractors.LanguageGo], want) {
+		t.Errorf("discovered %v, want %v", files[extractors.LanguageGo], want)
+	}
+
+	// Detection
+	detection, err := pipeline.Detector().Detect(context.Background(), tmpDir)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if got := detection.Languages[extractors.LanguageGo].Files; !reflect.DeepEqual(got, want) {
+		t.Errorf("detected %v, want %v", got, want)
+	}
+
+	// Extraction
+	if _, err := pipeline.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if extractor.lastReq == nil {
+		t.Fatal("extractor was not called")
+	}
+	excluded := extractor.lastReq.Excluded()
+	for _, dir := range []string{"generated", "vendor"} {
+		if !excluded.Contains(dir) {
+			t.Errorf("extract request should exclude %q, got %v", dir, excluded.List())
+		}
+	}
+}
+
+func TestExtractorPipeline_Run_ReportsSkippedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"main.go":           "package main",
+		"huge.go":           "package main\n\n// " + strings.Repeat("x", 100),
+		"vendor/dep/dep.go": "package dep",
+		"notes.txt":         "notes",
+		"deploy.sh":         "#!/bin/sh",
+		"Main.java":         "class Main {}",
+		"app.py":            "print()",
+	}
+	for file, content := range files {
+		path := filepath.Join(tmpDir, file)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+
+	docsDir := filepath.Join(tmpDir, "docs")
+	config := &ExtractorPipelineConfig{
+		SourceDir:      tmpDir,
+		OutputDir:      docsDir,
+		DocsDir:        docsDir,
+		Languages:      []string{"go", "java", "python"},
+		MaxFileBytes:   64,
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguageGo})
+	pipeline.RegisterExtractor(&fakeExtractor{
+		lang:        extractors.LanguagePython,
+		validateErr: errors.New("pydoc not installed"),
+	})
+
+	result, err := pipeline.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	want := map[string]SkipReason{
+		filepath.Join(tmpDir, "huge.go"):   SkipTooLarge,
+		filepath.Join(tmpDir, "vendor"):    SkipExcludedDir,
+		filepath.Join(tmpDir, "notes.txt"): SkipUnknownExtension,
+		filepath.Join(tmpDir, "deploy.sh"): SkipLanguageNotSelected,
+		filepath.Join(tmpDir, "Main.java"): SkipNoExtractor,
+		filepath.Join(tmpDir, "app.py"):    SkipToolsUnavailable,
+	}
+
+	got := make(map[string]SkipReason)
+	for _, skipped := range result.Skipped {
+		got[skipped.Path] = skipped.Reason
+	}
+	for path, reason := range want {
+		if got[path] != reason {
+			t.Errorf("%s: reason = %q, want %q", path, got[path], reason)
+		}
+	}
+	if reason, ok := got[filepath.Join(tmpDir, "main.go")]; ok {
+		t.Errorf("main.go should be documented, was skipped as %q", reason)
+	}
+	if counts := result.SkippedCounts(); counts[SkipTooLarge] != 1 {
+		t.Errorf("SkippedCounts()[%s] = %d, want 1", SkipTooLarge, counts[SkipTooLarge])
+	}
+}
+
+func TestExtractorPipeline_Run_SharesRetryBudget(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+
+	docsDir := filepath.Join(tmpDir, "docs")
+	config := &ExtractorPipelineConfig{
+		SourceDir:      tmpDir,
+		OutputDir:      docsDir,
+		DocsDir:        docsDir,
+		RetryBudget:    5,
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	extractor := &fakeExtractor{lang: extractors.LanguageGo}
+	pipeline.RegisterExtractor(extractor)
+
+	if _, err := pipeline.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	budget := httpbase.RetryBudgetFromContext(extractor.lastCtx)
+	if budget == nil {
+		t.Fatal("Run should attach the retry budget to the context")
+	}
+	if budget.Remaining() != 5 {
+		t.Errorf("Expected a fresh budget of 5, got %d", budget.Remaining())
+	}
+}
+
+func TestExtractorPipeline_Run_RetryBudgetStopsLLMRetries(t *testing.T) {
+	tmpDir := newWelcomeProject(t)
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	// The client alone would retry 10 times
+	provider := openai.NewProviderWithBaseURL("key", server.URL).WithClientOptions(httpbase.ClientOptions{
+		MaxRetries:     10,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+
+	config := &ExtractorPipelineConfig{
+		SourceDir:       tmpDir,
+		OutputDir:       filepath.Join(tmpDir, "docs"),
+		DocsDir:         filepath.Join(tmpDir, "docs"),
+		GenerateWelcome: true,
+		WelcomeRetries:  -1,
+		RetryBudget:     2,
+		CheckpointPath:  filepath.Join(tmpDir, "checkpoint.json"),
+	}
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), llm.NewOrchestrator(provider, nil, nil))
+	pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguageGo})
+
+	pipeline.Run(context.Background())
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected the first attempt and 2 budgeted retries, got %d requests", got)
+	}
+}
+
+func TestExtractorPipeline_Run_RecordsSpans(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+
+	docsDir := filepath.Join(tmpDir, "docs")
+	recorder := tracing.NewRecorder()
+	config := &ExtractorPipelineConfig{
+		SourceDir:      tmpDir,
+		OutputDir:      docsDir,
+		DocsDir:        docsDir,
+		Tracer:         recorder,
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguageGo})
+
+	if _, err := pipeline.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	parents := make(map[string]string)
+	for _, span := range recorder.Spans() {
+		parents[span.Name] = span.Parent
+		if span.Err != nil {
+			t.Errorf("Span %s should succeed, got %v", span.Name, span.Err)
+		}
+	}
+
+	want := map[string]string{
+		"pipeline.run":       "",
+		"pipeline.discover":  "pipeline.run",
+		"pipeline.extract":   "pipeline.run",
+		"extractor.go":       "pipeline.extract",
+		"pipeline.normalize": "pipeline.run",
+	}
+	for name, parent := range want {
+		got, ok := parents[name]
+		if !ok {
+			t.Errorf("Missing span %s, recorded %v", name, recorder.Names())
+			continue
+		}
+		if got != parent {
+			t.Errorf("Span %s parent = %q, want %q", name, got, parent)
+		}
+	}
+}
+
+func TestExtractorPipeline_Run_RecordsTimings(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+
+	docsDir := filepath.Join(tmpDir, "docs")
+	config := &ExtractorPipelineConfig{
+		SourceDir:      tmpDir,
+		OutputDir:      docsDir,
+		DocsDir:        docsDir,
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguageGo})
+
+	result, err := pipeline.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	for _, step := range result.Steps {
+		d, ok := result.Timings[step.Name]
+		if step.Status == StepSkipped {
+			if ok {
+				t.Errorf("Skipped step %s should have no timing", step.Name)
+			}
+			continue
+		}
+		if !ok {
+			t.Errorf("Missing timing for step %s", step.Name)
+		} else if d < 0 {
+			t.Errorf("Timing for step %s is negative: %v", step.Name, d)
+		}
+	}
+
+	d, ok := result.LanguageTimings["go"]
+	if !ok {
+		t.Fatalf("Missing timing for go, got %v", result.LanguageTimings)
+	}
+	if d < 0 {
+		t.Errorf("Timing for go is negative: %v", d)
+	}
+}
+
+func TestExtractorPipeline_Run_ErrorMode(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          ErrorMode
+		wantErr       bool
+		wantPython    int
+		wantExtractOK bool
+	}{
+		{name: "fail fast", mode: ErrorModeFailFast, wantErr: true, wantPython: 0},
+		{name: "best effort", mode: ErrorModeBestEffort, wantPython: 1, wantExtractOK: true},
+		{name: "default", wantPython: 1, wantExtractOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+			os.WriteFile(filepath.Join(tmpDir, "app.py"), []byte("print('hi')"), 0644)
+
+			docsDir := filepath.Join(tmpDir, "docs")
+			config := &ExtractorPipelineConfig{
+				SourceDir:      tmpDir,
+				OutputDir:      docsDir,
+				DocsDir:        docsDir,
+				ErrorMode:      tt.mode,
+				CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+			}
+
+			pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+			failure := errors.New("go extraction broke")
+			goExtractor := &fakeExtractor{lang: extractors.LanguageGo, hook: func() error { return failure }}
+			pyExtractor := &fakeExtractor{lang: extractors.LanguagePython}
+			pipeline.RegisterExtractor(goExtractor)
+			pipeline.RegisterExtractor(pyExtractor)
+
+			result, err := pipeline.Run(context.Background())
+			if tt.wantErr {
+				if !errors.Is(err, failure) {
+					t.Fatalf("Expected Run to fail with %v, got %v", failure, err)
+				}
+				if result.FailedStep() != StepExtract {
+					t.Errorf("Expected extract to fail, got %q", result.FailedStep())
+				}
+			} else if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+
+			if pyExtractor.calls != tt.wantPython {
+				t.Errorf("Python extractor calls = %d, want %d", pyExtractor.calls, tt.wantPython)
+			}
+			if result.Succeeded(StepExtract) != tt.wantExtractOK {
+				t.Errorf("Extract succeeded = %v, want %v", result.Succeeded(StepExtract), tt.wantExtractOK)
+			}
+			if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], failure.Error()) {
+				t.Errorf("Expected the go failure in result errors, got %v", result.Errors)
+			}
+		})
+	}
+}
+
+func TestExtractorPipeline_Run_ExtractsInScratchDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+
+	docsDir := filepath.Join(tmpDir, "docs")
+	config := &ExtractorPipelineConfig{
+		SourceDir:      tmpDir,
+		OutputDir:      docsDir,
+		DocsDir:        docsDir,
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	extractor := &fakeExtractor{lang: extractors.LanguageGo}
+	extractor.hook = func() error {
+		for _, dir := range []string{extractor.lastReq.OutputDir, extractor.lastReq.ScratchDir} {
+			if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+				return fmt.Errorf("scratch directory %s missing during extraction: %v", dir, err)
+			}
+		}
+		return nil
+	}
+	pipeline.RegisterExtractor(extractor)
+
+	if _, err := pipeline.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	req := extractor.lastReq
+	if req.OutputDir == filepath.Join(docsDir, "go") {
+		t.Error("Extractor should write to a scratch directory, not the final output")
+	}
+	if !strings.HasPrefix(filepath.Base(filepath.Dir(req.OutputDir)), scratchPrefix+"go-") {
+		t.Errorf("Unexpected scratch directory %s", req.OutputDir)
+	}
+
+	if _, err := os.Stat(filepath.Dir(req.OutputDir)); !os.IsNotExist(err) {
+		t.Errorf("Scratch directory should be removed after extraction, stat err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(docsDir, "go", "index.md")); err != nil {
+		t.Errorf("Extracted file should be published to the output directory: %v", err)
+	}
+}
+
+func TestExtractorPipeline_Run_NormalizesOutputExtension(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+
+	docsDir := filepath.Join(tmpDir, "docs")
+	config := &ExtractorPipelineConfig{
+		SourceDir:      tmpDir,
+		OutputDir:      docsDir,
+		DocsDir:        docsDir,
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguageGo, fileName: "api.markdown"})
+
+	result, err := pipeline.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(docsDir, "go", "api.markdown")); !os.IsNotExist(err) {
+		t.Error("The .markdown file should have been renamed")
+	}
+	page := filepath.Join(docsDir, "go", "api.md")
+	data, err := os.ReadFile(page)
+	if err != nil {
+		t.Fatalf("Expected %s: %v", page, err)
+	}
+	if !strings.HasPrefix(string(data), "---") {
+		t.Error("The renamed page should be picked up by the normalizer")
+	}
+	if result.Stats.DocsGenerated != 1 {
+		t.Errorf("DocsGenerated = %d, want 1", result.Stats.DocsGenerated)
+	}
+}
+
+func TestExtractorPipeline_Run_StepFailureKeepsPartialOutput(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+
+	docsDir := filepath.Join(tmpDir, "docs")
+	config := &ExtractorPipelineConfig{
+		SourceDir:     tmpDir,
+		OutputDir:     docsDir,
+		DocsDir:       docsDir,
+		ValidateJekyll: true,
+		DeployGHPages:  true,
+		ErrorMode:      ErrorModeFailFast,
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	runner := site.NewMockRunner().WithError("bundle exec", errors.New("jekyll build failed"))
+	pipeline := NewExtractorPipeline(config, runner, nil)
+	if err := pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguageGo}); err != nil {
+		t.Fatalf("RegisterExtractor failed: %v", err)
+	}
+
+	result, err := pipeline.Run(context.Background())
+	if err == nil {
+		t.Fatal("Expected validation failure")
+	}
+
+	if result == nil {
+		t.Fatal("Result should be returned on failure")
+	}
+
+	for _, step := range []string{StepDiscover, StepExtract, StepNormalize} {
+		if !result.Succeeded(step) {
+			t.Errorf("Step %s should be reported successful", step)
+		}
+	}
+
+	if got := result.FailedStep(); got != StepValidate {
+		t.Errorf("Expected failed step %q, got %q", StepValidate, got)
+	}
+
+	for _, step := range []string{StepDeploy, StepCache} {
+		if _, ok := result.Step(step); ok {
+			t.Errorf("Step %s after the failure should not be reported", step)
+		}
+	}
+
+	if result.Stats.DocsGenerated != 1 {
+		t.Errorf("Expected 1 doc generated, got %d", result.Stats.DocsGenerated)
+	}
+
+	// Generated docs are not rolled back
+	if _, err := os.Stat(filepath.Join(docsDir, "go", "index.md")); err != nil {
+		t.Errorf("Generated doc should be preserved: %v", err)
+	}
+}
+
+func TestExtractorPipeline_Run_ReportsSkippedSteps(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+
+	config := &ExtractorPipelineConfig{
+		SourceDir:      tmpDir,
+		OutputDir:      filepath.Join(tmpDir, "docs"),
+		DocsDir:        filepath.Join(tmpDir, "docs"),
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguageGo})
+
+	result, err := pipeline.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.FailedStep() != "" {
+		t.Errorf("Expected no failed steps, got %q", result.FailedStep())
+	}
+
+	for _, step := range []string{StepWelcome, StepValidate, StepDeploy, StepCache} {
+		got, ok := result.Step(step)
+		if !ok || got.Status != StepSkipped {
+			t.Errorf("Step %s should be reported skipped, got %+v", step, got)
+		}
+	}
+}
+
+func TestExtractorPipeline_Run_ResumeSkipsCompletedLanguages(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "app.py"), []byte("def main():"), 0644)
+
+	checkpointPath := filepath.Join(tmpDir, "checkpoint.json")
+	newConfig := func(resume bool) *ExtractorPipelineConfig {
+		return &ExtractorPipelineConfig{
+			SourceDir:      tmpDir,
+			OutputDir:      filepath.Join(tmpDir, "docs"),
+			DocsDir:        filepath.Join(tmpDir, "docs"),
+			Resume:         resume,
+			CheckpointPath: checkpointPath,
+		}
+	}
+
+	// First run: Go completes, then the run is interrupted while extracting Python
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	goExt := &fakeExtractor{lang: extractors.LanguageGo}
+	pyExt := &fakeExtractor{lang: extractors.LanguagePython, hook: func() error {
+		cancel()
+		return context.Canceled
+	}}
+
+	first := NewExtractorPipeline(newConfig(false), site.NewMockRunner(), nil)
+	first.RegisterExtractor(goExt)
+	first.RegisterExtractor(pyExt)
+
+	if _, err := first.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected interrupted run, got: %v", err)
+	}
+
+	if _, err := os.Stat(checkpointPath); err != nil {
+		t.Fatalf("Checkpoint should survive an interrupted run: %v", err)
+	}
+
+	// Second run resumes: Go is skipped, Python is extracted
+	goExt2 := &fakeExtractor{lang: extractors.LanguageGo}
+	pyExt2 := &fakeExtractor{lang: extractors.LanguagePython}
+
+	second := NewExtractorPipeline(newConfig(true), site.NewMockRunner(), nil)
+	second.RegisterExtractor(goExt2)
+	second.RegisterExtractor(pyExt2)
+
+	result, err := second.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Resumed run failed: %v", err)
+	}
+
+	if goExt2.calls != 0 {
+		t.Errorf("Go should be skipped on resume, extracted %d times", goExt2.calls)
+	}
+
+	if pyExt2.calls != 1 {
+		t.Errorf("Python should be extracted once, got %d", pyExt2.calls)
+	}
+
+	if len(result.Resumed) != 1 || result.Resumed[0] != "go" {
+		t.Errorf("Expected resumed [go], got %v", result.Resumed)
+	}
+
+	if result.Stats.DocsGenerated != 2 {
+		t.Errorf("Expected stats to include resumed work (2 docs), got %d", result.Stats.DocsGenerated)
+	}
+
+	if _, err := os.Stat(checkpointPath); !os.IsNotExist(err) {
+		t.Error("Checkpoint should be removed after a successful run")
+	}
+}
+
+func TestExtractorPipeline_Run_WithoutResumeIgnoresCheckpoint(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+
+	checkpointPath := filepath.Join(tmpDir, "checkpoint.json")
+	checkpoint := incremental.NewCheckpoint()
+	checkpoint.MarkCompleted("go", incremental.LanguageCheckpoint{DocsGenerated: 1})
+	if err := checkpoint.Save(checkpointPath); err != nil {
+		t.Fatal(err)
+	}
+
+	goExt := &fakeExtractor{lang: extractors.LanguageGo}
+	config := &ExtractorPipelineConfig{
+		SourceDir:      tmpDir,
+		OutputDir:      filepath.Join(tmpDir, "docs"),
+		DocsDir:        filepath.Join(tmpDir, "docs"),
+		CheckpointPath: checkpointPath,
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	pipeline.RegisterExtractor(goExt)
+
+	if _, err := pipeline.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if goExt.calls != 1 {
+		t.Errorf("Fresh run should re-extract Go, got %d calls", goExt.calls)
+	}
+}
+
+func TestExtractorPipeline_Run_AggregatesDocCoverage(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "app.py"), []byte("def main():"), 0644)
+
+	config := &ExtractorPipelineConfig{
+		SourceDir:      tmpDir,
+		OutputDir:      filepath.Join(tmpDir, "docs"),
+		DocsDir:        filepath.Join(tmpDir, "docs"),
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	pipeline.RegisterExtractor(&fakeExtractor{
+		lang:     extractors.LanguageGo,
+		coverage: extractors.DocCoverage{Documented: 3, Total: 4},
+	})
+	pipeline.RegisterExtractor(&fakeExtractor{
+		lang:     extractors.LanguagePython,
+		coverage: extractors.DocCoverage{Documented: 1, Total: 4},
+	})
+
+	result, err := pipeline.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if got := result.Stats.DocCoverage; got.Documented != 4 || got.Total != 8 {
+		t.Errorf("Expected aggregate coverage 4/8, got %+v", got)
+	}
+
+	if got := result.Stats.DocCoverage.Ratio(); got != 0.5 {
+		t.Errorf("Expected aggregate ratio 0.5, got %v", got)
+	}
+
+	if got := result.DocCoverage["go"]; got.Ratio() != 0.75 {
+		t.Errorf("Expected Go ratio 0.75, got %v", got.Ratio())
+	}
+
+	if got := result.DocCoverage["python"]; got.Ratio() != 0.25 {
+		t.Errorf("Expected Python ratio 0.25, got %v", got.Ratio())
+	}
+}
+
+func TestExtractorPipeline_Run_UnreachableLLMSkipsWelcome(t *testing.T) {
+	tmpDir := newWelcomeProject(t)
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+
+	// A closed server stands in for an LLM endpoint that is configured but down
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	baseURL := server.URL
+	server.Close()
+
+	orch := llm.NewOrchestrator(litellm.NewProvider("key", baseURL, "model"), nil, nil)
+
+	config := &ExtractorPipelineConfig{
+		SourceDir:       tmpDir,
+		OutputDir:       filepath.Join(tmpDir, "docs"),
+		DocsDir:         filepath.Join(tmpDir, "docs"),
+		GenerateWelcome: true,
+		CheckpointPath:  filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), orch)
+	pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguageGo})
+
+	result, err := pipeline.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run should degrade gracefully, got: %v", err)
+	}
+
+	for _, step := range []string{StepDiscover, StepExtract, StepNormalize} {
+		if !result.Succeeded(step) {
+			t.Errorf("Step %s should succeed without the LLM", step)
+		}
+	}
+
+	if got, _ := result.Step(StepWelcome); got.Status != StepSkipped {
+		t.Errorf("Welcome step should be skipped, got %+v", got)
+	}
+
+	if len(result.Errors) == 0 {
+		t.Error("Expected the unreachable endpoint to be reported")
+	}
+}
+
+func TestExtractorPipeline_WelcomeOptions(t *testing.T) {
+	tmpDir := newWelcomeProject(t)
+
+	config := &ExtractorPipelineConfig{SourceDir: tmpDir, DocsDir: filepath.Join(tmpDir, "docs")}
+	opts, err := NewExtractorPipeline(config, site.NewMockRunner(), nil).welcomeOptions()
+	if err != nil {
+		t.Fatalf("welcomeOptions failed: %v", err)
+	}
+	if opts.Retries != DefaultWelcomeRetries || opts.RetryDelay != DefaultWelcomeRetryDelay || opts.Timeout != DefaultWelcomeTimeout {
+		t.Errorf("Expected the defaults, got %+v", opts)
+	}
+
+	config.WelcomeRetries = -1
+	config.WelcomeRetryDelay = time.Second
+	config.WelcomeTimeout = 10 * time.Second
+	opts, err = NewExtractorPipeline(config, site.NewMockRunner(), nil).welcomeOptions()
+	if err != nil {
+		t.Fatalf("welcomeOptions failed: %v", err)
+	}
+	if opts.Retries != 0 || opts.RetryDelay != time.Second || opts.Timeout != 10*time.Second {
+		t.Errorf("Expected the configured settings, got %+v", opts)
+	}
+}
+
+// hangingProvider never answers, like a hung model endpoint
+type hangingProvider struct {
+	calls atomic.Int32
+}
+
+func (h *hangingProvider) Complete(prompt string, opts llm.Options) (llm.Response, error) {
+	h.calls.Add(1)
+	time.Sleep(time.Minute)
+	return llm.Response{}, errors.New("unreachable")
+}
+func (h *hangingProvider) Tokens(input string) (int, error) { return len(input) / 4, nil }
+func (h *hangingProvider) Name() string                     { return "hanging" }
+
+func TestExtractorPipeline_Run_WelcomeTimeoutRetriesHungCall(t *testing.T) {
+	tmpDir := newWelcomeProject(t)
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+	provider := &hangingProvider{}
+
+	config := &ExtractorPipelineConfig{
+		SourceDir:         tmpDir,
+		OutputDir:         filepath.Join(tmpDir, "docs"),
+		DocsDir:           filepath.Join(tmpDir, "docs"),
+		GenerateWelcome:   true,
+		WelcomeRetries:    1,
+		WelcomeRetryDelay: time.Millisecond,
+		WelcomeTimeout:    20 * time.Millisecond,
+		CheckpointPath:    filepath.Join(tmpDir, "checkpoint.json"),
+	}
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), llm.NewOrchestrator(provider, nil, nil))
+	pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguageGo})
+
+	start := time.Now()
+	pipeline.Run(context.Background())
+
+	if calls := provider.calls.Load(); calls != 2 {
+		t.Errorf("Expected the hung call to be retried once, got %d attempts", calls)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected each attempt to be bounded, run took %v", elapsed)
+	}
+}
+
+func TestNewExtractorPipeline_RegistersBuiltinExtractors(t *testing.T) {
+	config := &ExtractorPipelineConfig{SourceDir: ".", OutputDir: "docs", DocsDir: "docs"}
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+
+	for _, lang := range extractors.BuiltinLanguages() {
+		if !pipeline.registry.Has(lang) {
+			t.Errorf("built-in %s extractor should be registered", lang)
+		}
+	}
+
+	// Explicit registration takes the place of the built-in extractor
+	fake := &fakeExtractor{lang: extractors.LanguageGo}
+	if err := pipeline.RegisterExtractor(fake); err != nil {
+		t.Fatalf("RegisterExtractor failed: %v", err)
+	}
+	if got, _ := pipeline.registry.Get(extractors.LanguageGo); got != fake {
+		t.Error("RegisterExtractor should replace the built-in Go extractor")
+	}
+}
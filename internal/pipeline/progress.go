@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"sync"
+	"time"
+)
+
+// ProgressEvent reports extraction progress after each language finishes
+type ProgressEvent struct {
+	Language   string        `json:"language"`
+	FilesDone  int           `json:"files_done"`
+	FilesTotal int           `json:"files_total"`
+	Elapsed    time.Duration `json:"elapsed"`
+
+	// ETA is the estimated time remaining; 0 once every file is done or
+	// before any file has completed
+	ETA time.Duration `json:"eta"`
+}
+
+// ETAEstimator estimates remaining time by extrapolating linearly from the
+// files completed so far. It is safe for concurrent use.
+type ETAEstimator struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	start time.Time
+	done  int
+	total int
+}
+
+// NewETAEstimator starts timing a run over total files
+func NewETAEstimator(total int) *ETAEstimator {
+	return &ETAEstimator{
+		now:   time.Now,
+		start: time.Now(),
+		total: total,
+	}
+}
+
+// WithClock sets the time source and restarts timing from its current time
+func (e *ETAEstimator) WithClock(now func() time.Time) *ETAEstimator {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.now = now
+	e.start = now()
+	return e
+}
+
+// Advance records n more completed files and returns the resulting progress
+func (e *ETAEstimator) Advance(n int) ProgressEvent {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.done += n
+	if e.done > e.total {
+		e.done = e.total
+	}
+	return e.event()
+}
+
+// Progress returns the current progress without recording any work
+func (e *ETAEstimator) Progress() ProgressEvent {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.event()
+}
+
+// event builds a progress snapshot; callers must hold e.mu
+func (e *ETAEstimator) event() ProgressEvent {
+	elapsed := e.now().Sub(e.start)
+
+	var eta time.Duration
+	if e.done > 0 && e.done < e.total {
+		eta = time.Duration(float64(elapsed) * float64(e.total-e.done) / float64(e.done))
+	}
+
+	return ProgressEvent{
+		FilesDone:  e.done,
+		FilesTotal: e.total,
+		Elapsed:    elapsed,
+		ETA:        eta,
+	}
+}
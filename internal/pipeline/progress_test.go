@@ -0,0 +1,121 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+// steppingClock advances by step every time it is read
+type steppingClock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+func (c *steppingClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(c.step)
+	return c.now
+}
+
+func TestETAEstimatorDecreasesTowardZero(t *testing.T) {
+	clock := &steppingClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), step: 10 * time.Second}
+	eta := NewETAEstimator(100).WithClock(clock.Now)
+
+	previous := time.Duration(-1)
+	for done := 10; done <= 100; done += 10 {
+		event := eta.Advance(10)
+		if event.FilesDone != done || event.FilesTotal != 100 {
+			t.Fatalf("progress = %d/%d, want %d/100", event.FilesDone, event.FilesTotal, done)
+		}
+		if previous >= 0 && event.ETA >= previous {
+			t.Errorf("ETA at %d files = %v, should be below %v", done, event.ETA, previous)
+		}
+		previous = event.ETA
+	}
+
+	if previous != 0 {
+		t.Errorf("ETA when done = %v, want 0", previous)
+	}
+}
+
+func TestETAEstimatorLinearExtrapolation(t *testing.T) {
+	clock := &steppingClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), step: 30 * time.Second}
+	eta := NewETAEstimator(4).WithClock(clock.Now)
+
+	// One of four files done after 30s leaves three more at the same rate
+	if got := eta.Advance(1).ETA; got != 90*time.Second {
+		t.Errorf("ETA = %v, want 1m30s", got)
+	}
+}
+
+func TestETAEstimatorUnknownBeforeProgress(t *testing.T) {
+	eta := NewETAEstimator(10)
+	if got := eta.Progress().ETA; got != 0 {
+		t.Errorf("ETA before progress = %v, want 0", got)
+	}
+	if got := eta.Advance(25); got.FilesDone != 10 || got.ETA != 0 {
+		t.Errorf("overshooting Advance = %+v, want 10/10 with no ETA", got)
+	}
+}
+
+func TestETAEstimatorConcurrentAdvance(t *testing.T) {
+	eta := NewETAEstimator(200)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			eta.Advance(1)
+		}()
+	}
+	wg.Wait()
+
+	if got := eta.Progress(); got.FilesDone != 200 || got.ETA != 0 {
+		t.Errorf("after concurrent Advance, progress = %+v, want 200/200 with no ETA", got)
+	}
+}
+
+func TestExtractorPipeline_Run_EmitsProgress(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, file := range []string{"main.go", "util.go", "app.py"} {
+		os.WriteFile(filepath.Join(tmpDir, file), []byte("x"), 0644)
+	}
+
+	var events []ProgressEvent
+	docsDir := filepath.Join(tmpDir, "docs")
+	config := &ExtractorPipelineConfig{
+		SourceDir:      tmpDir,
+		OutputDir:      docsDir,
+		DocsDir:        docsDir,
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+		OnProgress:     func(e ProgressEvent) { events = append(events, e) },
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguageGo})
+	pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguagePython})
+
+	if _, err := pipeline.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected a progress event per language, got %+v", events)
+	}
+	if events[0].Language != "go" || events[0].FilesDone != 2 || events[0].FilesTotal != 3 {
+		t.Errorf("first event = %+v, want go at 2/3", events[0])
+	}
+	if last := events[1]; last.Language != "python" || last.FilesDone != 3 || last.ETA != 0 {
+		t.Errorf("last event = %+v, want python at 3/3 with no ETA", last)
+	}
+}
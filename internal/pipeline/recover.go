@@ -0,0 +1,39 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+)
+
+// ErrExtractorPanic is returned in place of a panic raised by an extractor
+var ErrExtractorPanic = errors.New("extractor panicked")
+
+// validateExtractor runs extractor.Validate, converting a panic into an error
+func validateExtractor(ctx context.Context, extractor extractors.Extractor) (err error) {
+	defer recoverExtractor(extractor.Language(), &err)
+	return extractor.Validate(ctx)
+}
+
+// runExtractor runs extractor.Extract, converting a panic into an error so
+// one faulty extractor cannot abort the whole run
+func runExtractor(ctx context.Context, extractor extractors.Extractor, req *extractors.ExtractRequest) (result *extractors.ExtractResult, err error) {
+	defer recoverExtractor(req.Language, &err)
+	return extractor.Extract(ctx, req)
+}
+
+// recoverExtractor must be deferred; it logs the stack of a panic in lang's
+// extractor and stores it in err as ErrExtractorPanic
+func recoverExtractor(lang extractors.Language, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	log.Printf("[Pipeline] ⚠️  PANIC in %s extractor: %v\n%s", lang, r, debug.Stack())
+	*err = fmt.Errorf("%w: %s: %v", ErrExtractorPanic, lang, r)
+}
@@ -0,0 +1,91 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+// panickingExtractor panics from Extract, or from Validate when inValidate is set
+type panickingExtractor struct {
+	lang       extractors.Language
+	inValidate bool
+}
+
+func (p *panickingExtractor) Extract(ctx context.Context, req *extractors.ExtractRequest) (*extractors.ExtractResult, error) {
+	panic("nil map write")
+}
+
+func (p *panickingExtractor) Validate(ctx context.Context) error {
+	if p.inValidate {
+		panic("tool lookup failed")
+	}
+	return nil
+}
+
+func (p *panickingExtractor) Language() extractors.Language {
+	return p.lang
+}
+
+func TestExtractorPipeline_Run_RecoversExtractorPanic(t *testing.T) {
+	for _, inValidate := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+		os.WriteFile(filepath.Join(tmpDir, "app.py"), []byte("def main():"), 0644)
+
+		config := &ExtractorPipelineConfig{
+			SourceDir:      tmpDir,
+			OutputDir:      filepath.Join(tmpDir, "docs"),
+			DocsDir:        filepath.Join(tmpDir, "docs"),
+			CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+		}
+
+		pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+		pipeline.RegisterExtractor(&panickingExtractor{lang: extractors.LanguageGo, inValidate: inValidate})
+		pyExt := &fakeExtractor{lang: extractors.LanguagePython}
+		pipeline.RegisterExtractor(pyExt)
+
+		result, err := pipeline.Run(context.Background())
+		if err != nil {
+			t.Fatalf("Run should survive an extractor panic, got %v", err)
+		}
+
+		if pyExt.calls != 1 {
+			t.Errorf("Other languages should still be extracted, got %d calls", pyExt.calls)
+		}
+		if result.Stats.DocsGenerated != 1 {
+			t.Errorf("Expected 1 doc generated, got %d", result.Stats.DocsGenerated)
+		}
+
+		found := false
+		for _, msg := range result.Errors {
+			if strings.Contains(msg, "extractor panicked: go") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected the panic to be reported as an error, got %v", result.Errors)
+		}
+	}
+}
+
+func TestRunExtractor_ConvertsPanic(t *testing.T) {
+	ext := &panickingExtractor{lang: extractors.LanguageRust}
+
+	result, err := runExtractor(context.Background(), ext, &extractors.ExtractRequest{Language: extractors.LanguageRust})
+	if !errors.Is(err, ErrExtractorPanic) {
+		t.Fatalf("Expected ErrExtractorPanic, got %v", err)
+	}
+	if result != nil {
+		t.Error("Expected no result after a panic")
+	}
+	if !strings.Contains(err.Error(), "nil map write") {
+		t.Errorf("Error should include the panic value, got %v", err)
+	}
+}
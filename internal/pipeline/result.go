@@ -0,0 +1,182 @@
+package pipeline
+
+import (
+	"time"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/internal/tracing"
+)
+
+// Pipeline step names, in execution order
+const (
+	StepDiscover  = "discover"
+	StepExtract   = "extract"
+	StepNormalize = "normalize"
+	StepWelcome   = "welcome"
+	StepValidate  = "validate"
+	StepDeploy    = "deploy"
+	StepCache     = "cache"
+)
+
+// StepStatus is the outcome of a single pipeline step
+type StepStatus string
+
+const (
+	StepSucceeded StepStatus = "succeeded"
+	StepFailed    StepStatus = "failed"
+	StepSkipped   StepStatus = "skipped" // Disabled by config or nothing to do
+)
+
+// SkipReason explains why a file was not documented
+type SkipReason string
+
+const (
+	SkipExcludedDir         SkipReason = "excluded_dir"          // Inside an excluded directory
+	SkipUnknownExtension    SkipReason = "unknown_extension"     // No language maps to the extension
+	SkipLanguageNotSelected SkipReason = "language_not_selected" // Language missing from config.Languages
+	SkipTooLarge            SkipReason = "too_large"             // Larger than config.MaxFileBytes
+	SkipNoExtractor         SkipReason = "no_extractor"          // No extractor registered for the language
+	SkipToolsUnavailable    SkipReason = "tools_unavailable"     // The extractor's tools failed validation
+)
+
+// SkippedFile is a file the pipeline found but did not document. For
+// excluded directories Path is the directory itself rather than each file.
+type SkippedFile struct {
+	Path   string     `json:"path"`
+	Reason SkipReason `json:"reason"`
+}
+
+// StepResult records the outcome of one pipeline step
+type StepResult struct {
+	Name   string     `json:"name"`
+	Status StepStatus `json:"status"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// PipelineResult reports which steps completed during a run.
+// It is returned even when Run fails so operators can inspect partial output.
+type PipelineResult struct {
+	Steps []StepResult               `json:"steps"`
+	Stats extractors.ExtractionStats `json:"stats"`
+
+	// DocCoverage holds per-language documented/total public symbols;
+	// Stats.DocCoverage is the aggregate across languages
+	DocCoverage map[string]extractors.DocCoverage `json:"doc_coverage,omitempty"`
+
+	// Resumed lists languages skipped because a previous run completed them
+	Resumed []string `json:"resumed,omitempty"`
+
+	// Skipped lists files that were not documented and why
+	Skipped []SkippedFile `json:"skipped,omitempty"`
+
+	// Errors holds non-fatal errors (extraction, normalization, warnings)
+	Errors []string `json:"errors,omitempty"`
+
+	// Timings holds the wall-clock time of each executed step, keyed by step
+	// name; LanguageTimings breaks the extract step down per language
+	Timings         map[string]time.Duration `json:"timings,omitempty"`
+	LanguageTimings map[string]time.Duration `json:"language_timings,omitempty"`
+
+	// LLMEstimate is the projected cost of the LLM steps, computed before they run
+	LLMEstimate llm.CostEstimate `json:"llm_estimate"`
+}
+
+// Step returns the result for the named step, if it ran
+func (r *PipelineResult) Step(name string) (StepResult, bool) {
+	for _, step := range r.Steps {
+		if step.Name == name {
+			return step, true
+		}
+	}
+	return StepResult{}, false
+}
+
+// Succeeded reports whether the named step completed successfully
+func (r *PipelineResult) Succeeded(name string) bool {
+	step, ok := r.Step(name)
+	return ok && step.Status == StepSucceeded
+}
+
+// FailedStep returns the name of the first failed step, or "" if none failed
+func (r *PipelineResult) FailedStep() string {
+	for _, step := range r.Steps {
+		if step.Status == StepFailed {
+			return step.Name
+		}
+	}
+	return ""
+}
+
+func (r *PipelineResult) succeed(name string) {
+	r.Steps = append(r.Steps, StepResult{Name: name, Status: StepSucceeded})
+}
+
+func (r *PipelineResult) skip(name string) {
+	r.Steps = append(r.Steps, StepResult{Name: name, Status: StepSkipped})
+}
+
+func (r *PipelineResult) fail(name string, err error) {
+	r.Steps = append(r.Steps, StepResult{Name: name, Status: StepFailed, Error: err.Error()})
+}
+
+func (r *PipelineResult) setTiming(step string, d time.Duration) {
+	if r.Timings == nil {
+		r.Timings = make(map[string]time.Duration)
+	}
+	r.Timings[step] = d
+}
+
+func (r *PipelineResult) setLanguageTiming(lang extractors.Language, d time.Duration) {
+	if r.LanguageTimings == nil {
+		r.LanguageTimings = make(map[string]time.Duration)
+	}
+	r.LanguageTimings[string(lang)] = d
+}
+
+func (r *PipelineResult) setCoverage(lang extractors.Language, coverage extractors.DocCoverage) {
+	if coverage.Total == 0 {
+		return
+	}
+	if r.DocCoverage == nil {
+		r.DocCoverage = make(map[string]extractors.DocCoverage)
+	}
+	r.DocCoverage[string(lang)] = coverage
+}
+
+// SkippedCounts returns how many files were skipped for each reason
+func (r *PipelineResult) SkippedCounts() map[SkipReason]int {
+	counts := make(map[SkipReason]int)
+	for _, skipped := range r.Skipped {
+		counts[skipped.Reason]++
+	}
+	return counts
+}
+
+func (r *PipelineResult) addSkipped(reason SkipReason, paths ...string) {
+	for _, path := range paths {
+		r.Skipped = append(r.Skipped, SkippedFile{Path: path, Reason: reason})
+	}
+}
+
+func (r *PipelineResult) addErrors(errs ...error) {
+	for _, err := range errs {
+		r.Errors = append(r.Errors, err.Error())
+	}
+}
+
+// timedSpan wraps a tracing span and reports its wall-clock duration on End
+type timedSpan struct {
+	tracing.Span
+	start  time.Time
+	record func(time.Duration)
+}
+
+func newTimedSpan(span tracing.Span, record func(time.Duration)) tracing.Span {
+	return &timedSpan{Span: span, start: time.Now(), record: record}
+}
+
+func (s *timedSpan) End(err error) {
+	s.record(time.Since(s.start))
+	s.Span.End(err)
+}
@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+)
+
+// scratchPrefix starts the name of every per-extractor scratch directory
+const scratchPrefix = ".scratch-"
+
+// scratchDir is a uniquely named working directory for one extractor run.
+// Output is staged under it and published into place once extraction ends.
+type scratchDir struct {
+	root   string
+	output string // Staged documentation, passed as ExtractRequest.OutputDir
+	work   string // Intermediate tool files, passed as ExtractRequest.ScratchDir
+}
+
+// newScratchDir creates a scratch directory for lang inside parent, so staged
+// files can be renamed into place without crossing filesystems
+func newScratchDir(parent string, lang extractors.Language) (*scratchDir, error) {
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	root, err := os.MkdirTemp(parent, scratchPrefix+string(lang)+"-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+
+	scratch := &scratchDir{
+		root:   root,
+		output: filepath.Join(root, "output"),
+		work:   filepath.Join(root, "work"),
+	}
+	for _, dir := range []string{scratch.output, scratch.work} {
+		if err := os.Mkdir(dir, 0755); err != nil {
+			scratch.Remove()
+			return nil, fmt.Errorf("failed to create scratch directory: %w", err)
+		}
+	}
+	return scratch, nil
+}
+
+// Publish moves every staged file into outputDir, each with an atomic rename,
+// and returns files with staged paths rewritten to their published location
+func (s *scratchDir) Publish(outputDir string, files []string) ([]string, error) {
+	err := filepath.Walk(s.output, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		target := filepath.Join(outputDir, s.rel(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.Rename(path, target)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish extracted files: %w", err)
+	}
+
+	published := make([]string, len(files))
+	for i, file := range files {
+		if rel := s.rel(file); rel != "" {
+			published[i] = filepath.Join(outputDir, rel)
+		} else {
+			published[i] = file
+		}
+	}
+	return published, nil
+}
+
+// rel returns path relative to the staged output, or "" if it lies outside
+func (s *scratchDir) rel(path string) string {
+	rel, err := filepath.Rel(s.output, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	return rel
+}
+
+// Remove deletes the scratch directory and anything left in it
+func (s *scratchDir) Remove() error {
+	return os.RemoveAll(s.root)
+}
@@ -0,0 +1,72 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+)
+
+func TestScratchDir_PublishAndRemove(t *testing.T) {
+	parent := t.TempDir()
+	outputDir := filepath.Join(parent, "go")
+
+	scratch, err := newScratchDir(parent, extractors.LanguageGo)
+	if err != nil {
+		t.Fatalf("newScratchDir failed: %v", err)
+	}
+
+	staged := filepath.Join(scratch.output, "pkg", "api.md")
+	os.MkdirAll(filepath.Dir(staged), 0755)
+	os.WriteFile(staged, []byte("# API\n"), 0644)
+	os.WriteFile(filepath.Join(scratch.work, "tool.json"), []byte("{}"), 0644)
+
+	// Existing output is overwritten in place
+	os.MkdirAll(filepath.Join(outputDir, "pkg"), 0755)
+	os.WriteFile(filepath.Join(outputDir, "pkg", "api.md"), []byte("stale"), 0644)
+
+	external := filepath.Join(parent, "elsewhere.md")
+	files, err := scratch.Publish(outputDir, []string{staged, external})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	published := filepath.Join(outputDir, "pkg", "api.md")
+	if files[0] != published || files[1] != external {
+		t.Errorf("Publish returned %v, want [%s %s]", files, published, external)
+	}
+	if data, err := os.ReadFile(published); err != nil || string(data) != "# API\n" {
+		t.Errorf("Published file = %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "tool.json")); !os.IsNotExist(err) {
+		t.Error("Work files should not be published")
+	}
+
+	if err := scratch.Remove(); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := os.Stat(scratch.root); !os.IsNotExist(err) {
+		t.Errorf("Scratch directory should be removed, stat err = %v", err)
+	}
+}
+
+func TestNewScratchDir_Unique(t *testing.T) {
+	parent := t.TempDir()
+
+	first, err := newScratchDir(parent, extractors.LanguageGo)
+	if err != nil {
+		t.Fatalf("newScratchDir failed: %v", err)
+	}
+	defer first.Remove()
+
+	second, err := newScratchDir(parent, extractors.LanguageGo)
+	if err != nil {
+		t.Fatalf("newScratchDir failed: %v", err)
+	}
+	defer second.Remove()
+
+	if first.root == second.root {
+		t.Errorf("Scratch directories should be unique, both are %s", first.root)
+	}
+}
@@ -0,0 +1,65 @@
+package pipeline
+
+import (
+	"fmt"
+	"os"
+)
+
+// DocTransform rewrites a generated documentation file before normalization,
+// e.g. to inject a copyright header or rewrite links
+type DocTransform interface {
+	Transform(path string, content []byte) ([]byte, error)
+}
+
+// DocTransformFunc adapts a function to the DocTransform interface
+type DocTransformFunc func(path string, content []byte) ([]byte, error)
+
+// Transform calls f(path, content)
+func (f DocTransformFunc) Transform(path string, content []byte) ([]byte, error) {
+	return f(path, content)
+}
+
+// applyTransforms runs transforms in order over each file, rewriting files
+// whose content changed. A failing transform leaves that file untouched;
+// errors are returned rather than stopping the remaining files.
+func applyTransforms(transforms []DocTransform, files []string) []error {
+	if len(transforms) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for _, file := range files {
+		if err := transformFile(transforms, file); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func transformFile(transforms []DocTransform, file string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fmt.Errorf("failed to transform %s: %w", file, err)
+	}
+
+	original, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to transform %s: %w", file, err)
+	}
+
+	content := original
+	for _, transform := range transforms {
+		content, err = transform.Transform(file, content)
+		if err != nil {
+			return fmt.Errorf("failed to transform %s: %w", file, err)
+		}
+	}
+
+	if string(content) == string(original) {
+		return nil
+	}
+	if err := os.WriteFile(file, content, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to transform %s: %w", file, err)
+	}
+	return nil
+}
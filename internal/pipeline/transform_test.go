@@ -0,0 +1,104 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+)
+
+// upperHeadings uppercases markdown heading lines
+var upperHeadings = DocTransformFunc(func(path string, content []byte) ([]byte, error) {
+	lines := bytes.Split(content, []byte("\n"))
+	for i, line := range lines {
+		if bytes.HasPrefix(line, []byte("#")) {
+			lines[i] = bytes.ToUpper(line)
+		}
+	}
+	return bytes.Join(lines, []byte("\n")), nil
+})
+
+func TestExtractorPipeline_Run_AppliesTransforms(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "app.py"), []byte("print('hi')"), 0644)
+
+	docsDir := filepath.Join(tmpDir, "docs")
+	var seen []string
+	config := &ExtractorPipelineConfig{
+		SourceDir: tmpDir,
+		OutputDir: docsDir,
+		DocsDir:   docsDir,
+		Transforms: []DocTransform{
+			upperHeadings,
+			DocTransformFunc(func(path string, content []byte) ([]byte, error) {
+				seen = append(seen, filepath.Base(filepath.Dir(path)))
+				return content, nil
+			}),
+		},
+		CheckpointPath: filepath.Join(tmpDir, "checkpoint.json"),
+	}
+
+	pipeline := NewExtractorPipeline(config, site.NewMockRunner(), nil)
+	pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguageGo})
+	pipeline.RegisterExtractor(&fakeExtractor{lang: extractors.LanguagePython})
+
+	result, err := pipeline.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("Unexpected errors: %v", result.Errors)
+	}
+
+	for _, lang := range []string{"go", "python"} {
+		data, err := os.ReadFile(filepath.Join(docsDir, lang, "index.md"))
+		if err != nil {
+			t.Fatalf("Expected %s docs: %v", lang, err)
+		}
+		if !strings.Contains(string(data), "\n# API\n") {
+			t.Errorf("%s headings should be uppercased, got:\n%s", lang, data)
+		}
+	}
+
+	if len(seen) != 2 {
+		t.Errorf("Later transforms should run on every file, saw %v", seen)
+	}
+}
+
+func TestApplyTransforms_AggregatesErrors(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.md")
+	bad := filepath.Join(dir, "bad.md")
+	os.WriteFile(good, []byte("# title\n"), 0644)
+	os.WriteFile(bad, []byte("# title\n"), 0644)
+
+	failure := errors.New("rewrite failed")
+	failBad := DocTransformFunc(func(path string, content []byte) ([]byte, error) {
+		if path == bad {
+			return nil, failure
+		}
+		return content, nil
+	})
+
+	errs := applyTransforms([]DocTransform{upperHeadings, failBad}, []string{bad, good, filepath.Join(dir, "missing.md")})
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+	if !errors.Is(errs[0], failure) {
+		t.Errorf("Expected the transform failure, got %v", errs[0])
+	}
+
+	if data, _ := os.ReadFile(bad); string(data) != "# title\n" {
+		t.Errorf("A failed transform should leave the file untouched, got %q", data)
+	}
+	if data, _ := os.ReadFile(good); string(data) != "# TITLE\n" {
+		t.Errorf("Other files should still be transformed, got %q", data)
+	}
+}
@@ -0,0 +1,78 @@
+package plaintext
+
+import (
+	"io"
+	"strings"
+)
+
+// replacer swaps emojis that carry meaning for ASCII equivalents
+var replacer = strings.NewReplacer(
+	"✅", "[OK]",
+	"✓", "[OK]",
+	"❌", "[FAIL]",
+	"⚠️", "[WARN]",
+	"⚠", "[WARN]",
+	"ℹ️", "[INFO]",
+	"ℹ", "[INFO]",
+	"💡", "Tip:",
+	"→", "->",
+)
+
+// Strip replaces meaningful emojis in s with ASCII equivalents and drops
+// the rest, along with the space left behind at the start of a line
+func Strip(s string) string {
+	s = replacer.Replace(s)
+
+	var b strings.Builder
+	b.Grow(len(s))
+	dropped := false
+	for _, r := range s {
+		if isEmoji(r) {
+			dropped = true
+			continue
+		}
+		// Skip the separator after a dropped emoji, e.g. "📝 Running"
+		if dropped && r == ' ' {
+			dropped = false
+			continue
+		}
+		dropped = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isEmoji reports whether r is an emoji, pictograph or emoji modifier
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // Pictographs, emoticons, transport, symbols
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // Miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // Arrows and stars such as ⭐
+		return true
+	case r >= 0x231A && r <= 0x23FF: // Watches, hourglasses and media controls
+		return true
+	case r == 0xFE0F || r == 0x200D: // Variation selector and zero-width joiner
+		return true
+	}
+	return false
+}
+
+// writer strips emojis from everything written through it
+type writer struct {
+	w io.Writer
+}
+
+// NewWriter returns a writer that strips emojis before writing to w, for
+// log output and terminal messages
+func NewWriter(w io.Writer) io.Writer {
+	return &writer{w: w}
+}
+
+func (pw *writer) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(pw.w, Strip(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
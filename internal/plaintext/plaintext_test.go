@@ -0,0 +1,51 @@
+package plaintext
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"no emojis", "Reviewed 3 files", "Reviewed 3 files"},
+		{"ok", "✅ No blocking issues", "[OK] No blocking issues"},
+		{"fail", "❌ review failed", "[FAIL] review failed"},
+		{"warn with variation selector", "⚠️  Budget exhausted", "[WARN]  Budget exhausted"},
+		{"info", "ℹ️  LLM_API_KEY not set", "[INFO]  LLM_API_KEY not set"},
+		{"arrow", "3 issues → report.html", "3 issues -> report.html"},
+		{"decorative emoji dropped", "🚀 Regenerating docs", "Regenerating docs"},
+		{"heading emoji dropped", "<h3>🔴 Issues Found (2)</h3>", "<h3>Issues Found (2)</h3>"},
+		{"zwj sequence", "👩‍💻 Author", "Author"},
+		{"keeps other symbols", "AurumCode™ © 2024 – 50%", "AurumCode™ © 2024 – 50%"},
+		{"keeps non-latin text", "Revisão concluída", "Revisão concluída"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Strip(tt.in); got != tt.want {
+				t.Errorf("Strip(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	msg := "✅ Report written to out.html\n"
+	n, err := w.Write([]byte(msg))
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if n != len(msg) {
+		t.Errorf("Expected Write to report %d bytes, got %d", len(msg), n)
+	}
+	if got := buf.String(); got != "[OK] Report written to out.html\n" {
+		t.Errorf("Unexpected output %q", got)
+	}
+}
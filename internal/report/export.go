@@ -0,0 +1,132 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// Supported export formats
+const (
+	FormatHTML  = "html"
+	FormatPDF   = "pdf" // Print-optimized HTML, ready for "Save as PDF"
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// ErrUnsupportedFormat indicates an unknown export format was requested
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Export renders a review result as a shareable artifact in the given format
+func Export(result types.ReviewResult, format string) ([]byte, error) {
+	return ExportWithOptions(result, format, RenderOptions{})
+}
+
+// ExportWithOptions renders a review result like Export, with a custom title,
+// score thresholds or plain-text output for the html, pdf and text formats
+func ExportWithOptions(result types.ReviewResult, format string, opts RenderOptions) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case FormatText:
+		return []byte(opts.finish(renderText(result))), nil
+	case FormatJSON:
+		return json.MarshalIndent(result, "", "  ")
+	case FormatSARIF:
+		return result.ToSARIF()
+	}
+
+	p := page{
+		Title:    defaultTitle,
+		Overview: overviewFromResult(result),
+		Analyses: FromReviewResult(result),
+	}
+
+	switch strings.ToLower(format) {
+	case FormatHTML:
+	case FormatPDF:
+		p.Print = true
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	if err := opts.apply(&p); err != nil {
+		return nil, err
+	}
+
+	html, err := renderPage(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(opts.finish(html)), nil
+}
+
+// FromReviewResult groups review issues into one analysis per file
+func FromReviewResult(result types.ReviewResult) []CodeAnalysis {
+	var analyses []CodeAnalysis
+	index := make(map[string]int)
+
+	for _, issue := range result.Issues {
+		i, ok := index[issue.File]
+		if !ok {
+			i = len(analyses)
+			index[issue.File] = i
+			analyses = append(analyses, CodeAnalysis{
+				FileName: issue.File,
+				CodeType: "BAD",
+			})
+		}
+
+		analyses[i].Issues = append(analyses[i].Issues, Issue{
+			Line:        issue.Line,
+			Severity:    string(issue.Severity),
+			Category:    categoryFromRule(issue.RuleID),
+			Description: issue.Message,
+			Suggestion:  issue.Suggestion,
+		})
+	}
+
+	return analyses
+}
+
+// overviewFromResult builds the result-wide overview section
+func overviewFromResult(result types.ReviewResult) *Overview {
+	return &Overview{
+		ISOScores:    ISOScoreMap(result.ISOScores),
+		Summary:      result.Summary,
+		OverallScore: result.OverallScore,
+	}
+}
+
+// ISOScoreMap converts ISO scores into the label → score map used by the report
+func ISOScoreMap(scores *types.ISOScores) map[string]int {
+	if scores == nil {
+		return nil
+	}
+
+	return map[string]int{
+		"Functionality":   scores.Functionality,
+		"Reliability":     scores.Reliability,
+		"Usability":       scores.Usability,
+		"Efficiency":      scores.Efficiency,
+		"Maintainability": scores.Maintainability,
+		"Portability":     scores.Portability,
+		"Security":        scores.Security,
+		"Compatibility":   scores.Compatibility,
+	}
+}
+
+// categoryFromRule derives a display category from a rule ID like "security/sql-injection"
+func categoryFromRule(ruleID string) string {
+	category := ruleID
+	if i := strings.Index(ruleID, "/"); i >= 0 {
+		category = ruleID[:i]
+	}
+	if category == "" {
+		return "General"
+	}
+	return strings.ToUpper(category[:1]) + category[1:]
+}
@@ -0,0 +1,203 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+func sampleResult() types.ReviewResult {
+	return types.ReviewResult{
+		Issues: []types.ReviewIssue{
+			{
+				File:       "api/users.go",
+				Line:       58,
+				Severity:   "error",
+				RuleID:     "security/sql-injection",
+				Message:    "SQL injection vulnerability",
+				Suggestion: "Use parameterized queries",
+			},
+			{
+				File:     "api/users.go",
+				Line:     112,
+				Severity: "warning",
+				RuleID:   "quality/validation",
+				Message:  "Missing input validation",
+			},
+			{
+				File:     "db/store.go",
+				Line:     7,
+				Severity: "info",
+				Message:  "Consider a repository pattern",
+			},
+		},
+		ISOScores: &types.ISOScores{
+			Security:        5,
+			Maintainability: 8,
+		},
+		Summary:      "Security concerns must be addressed",
+		OverallScore: 7.5,
+	}
+}
+
+func TestExport_HTML(t *testing.T) {
+	data, err := Export(sampleResult(), FormatHTML)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	html := string(data)
+	expected := []string{
+		"api/users.go",
+		"db/store.go",
+		"SQL injection vulnerability",
+		"Missing input validation",
+		"Consider a repository pattern",
+		"Security concerns must be addressed",
+		"<strong>Security:</strong> 5/10",
+		"Score: 7.5",
+	}
+	for _, want := range expected {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected HTML to contain %q", want)
+		}
+	}
+
+	if strings.Contains(html, "@page") {
+		t.Error("html export should not include print stylesheet")
+	}
+}
+
+func TestExport_PDF(t *testing.T) {
+	data, err := Export(sampleResult(), "PDF")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	html := string(data)
+	if !strings.Contains(html, "@page") {
+		t.Error("pdf export should include print stylesheet")
+	}
+	if !strings.Contains(html, "SQL injection vulnerability") {
+		t.Error("pdf export should contain issues")
+	}
+}
+
+func TestExportWithOptions_PlainText(t *testing.T) {
+	for _, format := range []string{FormatHTML, FormatPDF, FormatText} {
+		t.Run(format, func(t *testing.T) {
+			data, err := ExportWithOptions(sampleResult(), format, RenderOptions{PlainText: true})
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+
+			for _, r := range string(data) {
+				if r >= 0x2600 && r <= 0x27BF || r >= 0x1F000 && r <= 0x1FAFF || r == 0xFE0F {
+					t.Fatalf("expected no emojis in plain-text report, found %q", r)
+				}
+			}
+			if format != FormatText && !strings.Contains(string(data), "[FAIL] BLOCKED") {
+				t.Error("expected the status badge to use an ASCII marker")
+			}
+		})
+	}
+}
+
+func TestExport_KeepsEmojisByDefault(t *testing.T) {
+	data, err := Export(sampleResult(), FormatHTML)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !strings.Contains(string(data), "❌ BLOCKED") {
+		t.Error("expected emojis in the default report")
+	}
+}
+
+func TestExport_UnsupportedFormat(t *testing.T) {
+	_, err := Export(sampleResult(), "docx")
+	if err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+	if !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
+
+func TestFromReviewResult(t *testing.T) {
+	analyses := FromReviewResult(sampleResult())
+
+	if len(analyses) != 2 {
+		t.Fatalf("expected 2 file analyses, got %d", len(analyses))
+	}
+
+	if analyses[0].FileName != "api/users.go" || len(analyses[0].Issues) != 2 {
+		t.Errorf("unexpected first analysis: %+v", analyses[0])
+	}
+
+	if analyses[0].Issues[0].Category != "Security" {
+		t.Errorf("expected category Security, got %q", analyses[0].Issues[0].Category)
+	}
+
+	if analyses[1].Issues[0].Category != "General" {
+		t.Errorf("expected category General, got %q", analyses[1].Issues[0].Category)
+	}
+}
+
+func TestExport_MachineReadableFormats(t *testing.T) {
+	result := sampleResult()
+
+	t.Run("text", func(t *testing.T) {
+		data, err := Export(result, FormatText)
+		if err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+		text := string(data)
+		for _, want := range []string{
+			"api/users.go:58: [error] security/sql-injection: SQL injection vulnerability\n",
+			"    suggestion: Use parameterized queries\n",
+			"db/store.go:7: [info] Consider a repository pattern\n",
+			"3 issue(s): 1 error, 1 warning, 1 info\n",
+			"Security concerns must be addressed",
+		} {
+			if !strings.Contains(text, want) {
+				t.Errorf("expected text to contain %q, got:\n%s", want, text)
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := Export(result, FormatJSON)
+		if err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+		var decoded types.ReviewResult
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("json export is not valid JSON: %v", err)
+		}
+		if len(decoded.Issues) != 3 || decoded.Summary != result.Summary {
+			t.Errorf("json export did not round-trip: %+v", decoded)
+		}
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		data, err := Export(result, "SARIF")
+		if err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+		var log struct {
+			Version string `json:"version"`
+			Runs    []struct {
+				Results []json.RawMessage `json:"results"`
+			} `json:"runs"`
+		}
+		if err := json.Unmarshal(data, &log); err != nil {
+			t.Fatalf("sarif export is not valid JSON: %v", err)
+		}
+		if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) != 3 {
+			t.Errorf("unexpected SARIF log: %s", data)
+		}
+	})
+}
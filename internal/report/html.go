@@ -0,0 +1,197 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/plaintext"
+)
+
+const defaultTitle = "AurumCode Analysis Report"
+
+// Issue represents a code quality issue
+type Issue struct {
+	Line        int
+	Severity    string
+	Category    string
+	Description string
+	Suggestion  string
+}
+
+// CodeAnalysis represents the analysis of a file
+type CodeAnalysis struct {
+	FileName    string
+	CodeType    string // "GOOD" or "BAD"; derived from Issues when empty
+	ISOScores   map[string]int
+	Issues      []Issue
+	Summary     string
+	CodeSnippet string
+	Highlights  []string // Optional list of positive notes shown on approved files
+}
+
+// Approved reports whether the analysis should be shown as passing
+func (a CodeAnalysis) Approved() bool {
+	if a.CodeType != "" {
+		return strings.EqualFold(a.CodeType, "GOOD")
+	}
+	return len(a.Issues) == 0
+}
+
+// ScoreThresholds sets the color bands for 0-10 ISO scores:
+// below Medium is "low", below High is "medium", anything else is "high"
+type ScoreThresholds struct {
+	Medium int
+	High   int
+}
+
+// DefaultScoreThresholds returns the standard bands (<5 low, <7 medium)
+func DefaultScoreThresholds() ScoreThresholds {
+	return ScoreThresholds{Medium: 5, High: 7}
+}
+
+// Class maps a score to its color band
+func (t ScoreThresholds) Class(score int) string {
+	switch {
+	case score < t.Medium:
+		return "low"
+	case score < t.High:
+		return "medium"
+	default:
+		return "high"
+	}
+}
+
+// RenderOptions customizes RenderHTMLWithOptions
+type RenderOptions struct {
+	Title      string           // Page title; defaults to "AurumCode Analysis Report"
+	Thresholds *ScoreThresholds // Score color bands; nil uses DefaultScoreThresholds
+	PlainText  bool             // Replace emojis with ASCII for terminals and log parsers
+}
+
+// RenderHTML renders a standalone HTML report for the given analyses
+func RenderHTML(analyses []CodeAnalysis) (string, error) {
+	return RenderHTMLWithOptions(analyses, RenderOptions{})
+}
+
+// RenderHTMLWithOptions renders a standalone HTML report with a custom title or score thresholds
+func RenderHTMLWithOptions(analyses []CodeAnalysis, opts RenderOptions) (string, error) {
+	p := page{
+		Title:      defaultTitle,
+		Analyses:   analyses,
+		Thresholds: DefaultScoreThresholds(),
+	}
+
+	if err := opts.apply(&p); err != nil {
+		return "", err
+	}
+
+	html, err := renderPage(p)
+	if err != nil {
+		return "", err
+	}
+	return opts.finish(html), nil
+}
+
+// apply sets the title and thresholds chosen in opts on p
+func (opts RenderOptions) apply(p *page) error {
+	if opts.Title != "" {
+		p.Title = opts.Title
+	}
+
+	if opts.Thresholds != nil {
+		if opts.Thresholds.Medium > opts.Thresholds.High {
+			return fmt.Errorf("invalid score thresholds: medium (%d) must not exceed high (%d)",
+				opts.Thresholds.Medium, opts.Thresholds.High)
+		}
+		p.Thresholds = *opts.Thresholds
+	}
+	return nil
+}
+
+// finish post-processes rendered output, stripping emojis in plain-text mode
+func (opts RenderOptions) finish(out string) string {
+	if opts.PlainText {
+		return plaintext.Strip(out)
+	}
+	return out
+}
+
+// Overview holds result-wide scores and summary shown above the file cards
+type Overview struct {
+	ISOScores    map[string]int
+	Summary      string
+	OverallScore float64
+}
+
+// page is the data passed to htmlTemplate
+type page struct {
+	Title      string
+	Overview   *Overview
+	Analyses   []CodeAnalysis
+	Print      bool // Include print stylesheet
+	Thresholds ScoreThresholds
+}
+
+// renderPage executes htmlTemplate for the given page
+func renderPage(p page) (string, error) {
+	if p.Thresholds == (ScoreThresholds{}) {
+		p.Thresholds = DefaultScoreThresholds()
+	}
+
+	t, err := template.New("analysis").Funcs(template.FuncMap{
+		"mul": func(a, b int) int {
+			return a * b
+		},
+		"lower":      strings.ToLower,
+		"scoreClass": p.Thresholds.Class,
+		"status": func(a CodeAnalysis) string {
+			if a.Approved() {
+				return "good"
+			}
+			return "bad"
+		},
+	}).Parse(htmlTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse report template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, p); err != nil {
+		return "", fmt.Errorf("failed to render report: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// WriteHTML renders the analyses and writes the report to outputPath,
+// creating parent directories as needed. It returns the written path.
+func WriteHTML(analyses []CodeAnalysis, outputPath string) (string, error) {
+	html, err := RenderHTML(analyses)
+	if err != nil {
+		return "", err
+	}
+
+	return writeFile(outputPath, []byte(html))
+}
+
+// writeFile writes data to path, creating parent directories as needed
+func writeFile(path string, data []byte) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("output path is required")
+	}
+
+	path = filepath.Clean(path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory for %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write report %s: %w", path, err)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,232 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sampleAnalysis() CodeAnalysis {
+	return CodeAnalysis{
+		FileName: "internal/api/users.go",
+		ISOScores: map[string]int{
+			"Security":        3,
+			"Maintainability": 8,
+		},
+		Issues: []Issue{
+			{
+				Line:        21,
+				Severity:    "CRITICAL",
+				Category:    "Security",
+				Description: "SQL injection vulnerability",
+				Suggestion:  "Use parameterized queries",
+			},
+		},
+		Summary: "Critical security issues found",
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	html, err := RenderHTML([]CodeAnalysis{sampleAnalysis()})
+	if err != nil {
+		t.Fatalf("RenderHTML failed: %v", err)
+	}
+
+	expected := []string{
+		"<!DOCTYPE html>",
+		"internal/api/users.go",
+		"<strong>Security:</strong> 3/10",
+		"<strong>Maintainability:</strong> 8/10",
+		"SQL injection vulnerability",
+		"Use parameterized queries",
+		"Line 21",
+		"Issues Found (1)",
+		"Critical security issues found",
+		"BLOCKED",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected HTML to contain %q", want)
+		}
+	}
+}
+
+func TestRenderHTML_SeverityClassIsLowercase(t *testing.T) {
+	html, err := RenderHTML([]CodeAnalysis{sampleAnalysis()})
+	if err != nil {
+		t.Fatalf("RenderHTML failed: %v", err)
+	}
+
+	if !strings.Contains(html, `class="issue critical"`) {
+		t.Error("expected lowercase severity class on issue")
+	}
+}
+
+func TestRenderHTML_ApprovedWithoutIssues(t *testing.T) {
+	analysis := CodeAnalysis{
+		FileName:   "good.go",
+		ISOScores:  map[string]int{"Security": 9},
+		Highlights: []string{"Parameterized queries"},
+	}
+
+	html, err := RenderHTML([]CodeAnalysis{analysis})
+	if err != nil {
+		t.Fatalf("RenderHTML failed: %v", err)
+	}
+
+	if !strings.Contains(html, "APPROVED") {
+		t.Error("expected approved badge")
+	}
+	if !strings.Contains(html, "No issues found!") {
+		t.Error("expected no-issues message")
+	}
+	if !strings.Contains(html, "<li>Parameterized queries</li>") {
+		t.Error("expected highlights to be rendered")
+	}
+	if strings.Contains(html, "Issues Found") {
+		t.Error("did not expect issues section")
+	}
+}
+
+func TestRenderHTML_EscapesContent(t *testing.T) {
+	analysis := sampleAnalysis()
+	analysis.Issues[0].Description = "<script>alert(1)</script>"
+
+	html, err := RenderHTML([]CodeAnalysis{analysis})
+	if err != nil {
+		t.Fatalf("RenderHTML failed: %v", err)
+	}
+
+	if strings.Contains(html, "<script>alert(1)</script>") {
+		t.Error("expected issue description to be escaped")
+	}
+}
+
+func TestScoreThresholdsClass(t *testing.T) {
+	strict := ScoreThresholds{Medium: 7, High: 9}
+
+	tests := []struct {
+		thresholds ScoreThresholds
+		score      int
+		want       string
+	}{
+		{DefaultScoreThresholds(), 0, "low"},
+		{DefaultScoreThresholds(), 4, "low"},
+		{DefaultScoreThresholds(), 5, "medium"},
+		{DefaultScoreThresholds(), 6, "medium"},
+		{DefaultScoreThresholds(), 7, "high"},
+		{DefaultScoreThresholds(), 10, "high"},
+		{strict, 6, "low"},
+		{strict, 7, "medium"},
+		{strict, 8, "medium"},
+		{strict, 9, "high"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.thresholds.Class(tt.score); got != tt.want {
+			t.Errorf("%+v.Class(%d) = %q, want %q", tt.thresholds, tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestWriteHTML_CreatesNestedDirectories(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "reports", "2024", "analysis.html")
+
+	written, err := WriteHTML([]CodeAnalysis{sampleAnalysis()}, outputPath)
+	if err != nil {
+		t.Fatalf("WriteHTML failed: %v", err)
+	}
+
+	if written != outputPath {
+		t.Errorf("Expected written path %s, got %s", outputPath, written)
+	}
+
+	data, err := os.ReadFile(written)
+	if err != nil {
+		t.Fatalf("Report was not written: %v", err)
+	}
+
+	if !strings.Contains(string(data), "internal/api/users.go") {
+		t.Error("Written report should contain the analysis")
+	}
+}
+
+func TestWriteHTML_EmptyPath(t *testing.T) {
+	if _, err := WriteHTML([]CodeAnalysis{sampleAnalysis()}, ""); err == nil {
+		t.Error("Expected error for empty output path")
+	}
+}
+
+func TestWriteHTML_WriteErrorHasContext(t *testing.T) {
+	// A regular file where a parent directory is expected makes MkdirAll fail
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := WriteHTML([]CodeAnalysis{sampleAnalysis()}, filepath.Join(blocker, "out", "report.html"))
+	if err == nil {
+		t.Fatal("Expected error when parent path is a file")
+	}
+
+	if !strings.Contains(err.Error(), "report.html") {
+		t.Errorf("Error should mention the output path, got: %v", err)
+	}
+}
+
+func TestRenderHTMLWithOptions_ScoreThresholds(t *testing.T) {
+	analysis := CodeAnalysis{
+		FileName:  "main.go",
+		ISOScores: map[string]int{"Security": 6},
+	}
+
+	html, err := RenderHTML([]CodeAnalysis{analysis})
+	if err != nil {
+		t.Fatalf("RenderHTML failed: %v", err)
+	}
+	if !strings.Contains(html, `score-bar-fill medium`) {
+		t.Error("Score 6 should render medium with default thresholds")
+	}
+
+	strict := ScoreThresholds{Medium: 7, High: 9}
+	html, err = RenderHTMLWithOptions([]CodeAnalysis{analysis}, RenderOptions{Thresholds: &strict})
+	if err != nil {
+		t.Fatalf("RenderHTMLWithOptions failed: %v", err)
+	}
+	if !strings.Contains(html, `score-bar-fill low`) {
+		t.Error("Score 6 should render low under stricter thresholds")
+	}
+	if strings.Contains(html, `score-bar-fill medium`) {
+		t.Error("Score 6 should not render medium under stricter thresholds")
+	}
+
+	analysis.ISOScores["Security"] = 8
+	html, err = RenderHTMLWithOptions([]CodeAnalysis{analysis}, RenderOptions{Thresholds: &strict})
+	if err != nil {
+		t.Fatalf("RenderHTMLWithOptions failed: %v", err)
+	}
+	if !strings.Contains(html, `score-bar-fill medium`) {
+		t.Error("Score 8 should render medium under stricter thresholds")
+	}
+}
+
+func TestRenderHTMLWithOptions_InvalidThresholds(t *testing.T) {
+	_, err := RenderHTMLWithOptions([]CodeAnalysis{sampleAnalysis()}, RenderOptions{
+		Thresholds: &ScoreThresholds{Medium: 8, High: 6},
+	})
+	if err == nil {
+		t.Error("Expected error when medium exceeds high")
+	}
+}
+
+func TestRenderHTMLWithOptions_Title(t *testing.T) {
+	html, err := RenderHTMLWithOptions([]CodeAnalysis{sampleAnalysis()}, RenderOptions{Title: "Nightly Review"})
+	if err != nil {
+		t.Fatalf("RenderHTMLWithOptions failed: %v", err)
+	}
+	if !strings.Contains(html, "<title>Nightly Review</title>") {
+		t.Error("Custom title should be rendered")
+	}
+}
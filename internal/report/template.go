@@ -0,0 +1,399 @@
+package report
+
+// htmlTemplate is the page layout used by RenderHTML
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+    <style>
+        * {
+            margin: 0;
+            padding: 0;
+            box-sizing: border-box;
+        }
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            min-height: 100vh;
+            padding: 20px;
+        }
+        .container {
+            max-width: 1400px;
+            margin: 0 auto;
+        }
+        header {
+            background: rgba(255, 255, 255, 0.95);
+            backdrop-filter: blur(10px);
+            padding: 40px;
+            border-radius: 15px;
+            margin-bottom: 30px;
+            box-shadow: 0 20px 60px rgba(0, 0, 0, 0.3);
+            text-align: center;
+        }
+        header h1 {
+            font-size: 2.5em;
+            color: #667eea;
+            margin-bottom: 10px;
+        }
+        header p {
+            font-size: 1.2em;
+            color: #666;
+        }
+        .comparison-grid {
+            display: grid;
+            grid-template-columns: 1fr 1fr;
+            gap: 30px;
+            margin-bottom: 30px;
+        }
+        @media (max-width: 1200px) {
+            .comparison-grid {
+                grid-template-columns: 1fr;
+            }
+        }
+        .analysis-card {
+            background: white;
+            padding: 30px;
+            border-radius: 15px;
+            box-shadow: 0 10px 30px rgba(0, 0, 0, 0.2);
+        }
+        .analysis-card.bad {
+            border-left: 6px solid #ef4444;
+        }
+        .analysis-card.good {
+            border-left: 6px solid #10b981;
+        }
+        .card-header {
+            display: flex;
+            justify-content: space-between;
+            align-items: center;
+            margin-bottom: 20px;
+            padding-bottom: 15px;
+            border-bottom: 2px solid #f0f0f0;
+        }
+        .card-header h2 {
+            font-size: 1.8em;
+            color: #333;
+        }
+        .badge {
+            padding: 8px 16px;
+            border-radius: 20px;
+            font-weight: 600;
+            font-size: 0.9em;
+        }
+        .badge.bad {
+            background: #fecaca;
+            color: #dc2626;
+        }
+        .badge.good {
+            background: #d1fae5;
+            color: #059669;
+        }
+        .iso-scores {
+            background: #f9fafb;
+            padding: 20px;
+            border-radius: 10px;
+            margin-bottom: 20px;
+        }
+        .iso-scores h3 {
+            margin-bottom: 15px;
+            color: #667eea;
+        }
+        .score-item {
+            display: flex;
+            justify-content: space-between;
+            align-items: center;
+            padding: 10px 0;
+            border-bottom: 1px solid #e5e7eb;
+        }
+        .score-item:last-child {
+            border-bottom: none;
+        }
+        .score-bar {
+            width: 60%;
+            height: 8px;
+            background: #e5e7eb;
+            border-radius: 4px;
+            overflow: hidden;
+        }
+        .score-bar-fill {
+            height: 100%;
+            transition: width 0.3s ease;
+        }
+        .score-bar-fill.low {
+            background: #ef4444;
+        }
+        .score-bar-fill.medium {
+            background: #f59e0b;
+        }
+        .score-bar-fill.high {
+            background: #10b981;
+        }
+        .issues-section {
+            margin: 20px 0;
+        }
+        .issues-section h3 {
+            margin-bottom: 15px;
+            color: #667eea;
+        }
+        .issue {
+            background: #f9fafb;
+            padding: 15px;
+            border-radius: 8px;
+            margin-bottom: 12px;
+            border-left: 4px solid;
+        }
+        .issue.critical, .issue.error {
+            border-left-color: #dc2626;
+        }
+        .issue.high, .issue.warning {
+            border-left-color: #f59e0b;
+        }
+        .issue.medium, .issue.info {
+            border-left-color: #3b82f6;
+        }
+        .issue-header {
+            display: flex;
+            justify-content: space-between;
+            align-items: center;
+            margin-bottom: 8px;
+        }
+        .issue-line {
+            font-family: 'Courier New', monospace;
+            font-size: 0.85em;
+            background: #e5e7eb;
+            padding: 4px 8px;
+            border-radius: 4px;
+        }
+        .severity-badge {
+            padding: 4px 12px;
+            border-radius: 12px;
+            font-size: 0.75em;
+            font-weight: 700;
+            text-transform: uppercase;
+        }
+        .severity-badge.critical, .severity-badge.error {
+            background: #fecaca;
+            color: #dc2626;
+        }
+        .severity-badge.high, .severity-badge.warning {
+            background: #fed7aa;
+            color: #ea580c;
+        }
+        .severity-badge.medium, .severity-badge.info {
+            background: #dbeafe;
+            color: #2563eb;
+        }
+        .issue-description {
+            color: #374151;
+            margin-bottom: 8px;
+        }
+        .issue-suggestion {
+            color: #059669;
+            font-style: italic;
+            font-size: 0.9em;
+        }
+        .code-snippet {
+            background: #1e293b;
+            color: #e2e8f0;
+            padding: 20px;
+            border-radius: 8px;
+            font-family: 'Courier New', monospace;
+            font-size: 0.9em;
+            overflow-x: auto;
+            margin: 15px 0;
+        }
+        .summary {
+            background: #f0f9ff;
+            border: 2px solid #bae6fd;
+            padding: 20px;
+            border-radius: 8px;
+            margin: 20px 0;
+        }
+        .summary.good {
+            background: #f0fdf4;
+            border-color: #bbf7d0;
+        }
+        footer {
+            background: rgba(255, 255, 255, 0.95);
+            backdrop-filter: blur(10px);
+            padding: 30px;
+            border-radius: 15px;
+            text-align: center;
+            color: #666;
+            box-shadow: 0 10px 30px rgba(0, 0, 0, 0.2);
+        }
+        footer a {
+            color: #667eea;
+            text-decoration: none;
+            font-weight: 600;
+        }
+        footer a:hover {
+            text-decoration: underline;
+        }
+        .success-message {
+            background: #d1fae5;
+            border: 2px solid #10b981;
+            color: #065f46;
+            padding: 15px;
+            border-radius: 8px;
+            margin-bottom: 20px;
+            font-weight: 600;
+        }
+    </style>
+    {{if .Print}}
+    <style>
+        @page {
+            size: A4;
+            margin: 15mm;
+        }
+        body {
+            background: white;
+            padding: 0;
+        }
+        header, footer, .analysis-card {
+            box-shadow: none;
+            backdrop-filter: none;
+        }
+        .comparison-grid {
+            grid-template-columns: 1fr;
+        }
+        .analysis-card, .issue {
+            break-inside: avoid;
+        }
+        .score-bar-fill, .badge, .severity-badge {
+            -webkit-print-color-adjust: exact;
+            print-color-adjust: exact;
+        }
+    </style>
+    {{end}}
+</head>
+<body>
+    <div class="container">
+        <header>
+            <h1>🤖 {{.Title}}</h1>
+            <p>AI-Powered Code Review</p>
+            <p style="margin-top: 10px; font-size: 0.95em; color: #888;">ISO/IEC 25010 Quality Analysis</p>
+        </header>
+
+        {{with .Overview}}
+        <div class="analysis-card">
+            <div class="card-header">
+                <h2>Overview</h2>
+                {{if .OverallScore}}<span class="badge">Score: {{printf "%.1f" .OverallScore}}</span>{{end}}
+            </div>
+
+            {{if .ISOScores}}
+            <div class="iso-scores">
+                <h3>ISO/IEC 25010 Quality Scores</h3>
+                {{range $key, $value := .ISOScores}}
+                <div class="score-item">
+                    <span><strong>{{$key}}:</strong> {{$value}}/10</span>
+                    <div class="score-bar">
+                        <div class="score-bar-fill {{scoreClass $value}}"
+                             style="width: {{mul $value 10}}%"></div>
+                    </div>
+                </div>
+                {{end}}
+            </div>
+            {{end}}
+
+            {{if .Summary}}
+            <div class="summary">
+                <strong>Summary:</strong> {{.Summary}}
+            </div>
+            {{end}}
+        </div>
+        <br>
+        {{end}}
+
+        <div class="comparison-grid">
+            {{range .Analyses}}
+            {{$status := status .}}
+            <div class="analysis-card {{$status}}">
+                <div class="card-header">
+                    <h2>{{.FileName}}</h2>
+                    <span class="badge {{$status}}">{{if eq $status "good"}}✅ APPROVED{{else}}❌ BLOCKED{{end}}</span>
+                </div>
+
+                {{if .ISOScores}}
+                <div class="iso-scores">
+                    <h3>ISO/IEC 25010 Quality Scores</h3>
+                    {{range $key, $value := .ISOScores}}
+                    <div class="score-item">
+                        <span><strong>{{$key}}:</strong> {{$value}}/10</span>
+                        <div class="score-bar">
+                            <div class="score-bar-fill {{scoreClass $value}}"
+                                 style="width: {{mul $value 10}}%"></div>
+                        </div>
+                    </div>
+                    {{end}}
+                </div>
+                {{end}}
+
+                {{if .Summary}}
+                <div class="summary {{$status}}">
+                    <strong>Summary:</strong> {{.Summary}}
+                </div>
+                {{end}}
+
+                {{if .Issues}}
+                <div class="issues-section">
+                    <h3>🔴 Issues Found ({{len .Issues}})</h3>
+                    {{range .Issues}}
+                    <div class="issue {{lower .Severity}}">
+                        <div class="issue-header">
+                            <span class="issue-line">Line {{.Line}}</span>
+                            <span class="severity-badge {{lower .Severity}}">{{.Severity}}</span>
+                        </div>
+                        <div class="issue-description">
+                            <strong>{{.Category}}:</strong> {{.Description}}
+                        </div>
+                        {{if .Suggestion}}
+                        <div class="issue-suggestion">
+                            💡 {{.Suggestion}}
+                        </div>
+                        {{end}}
+                    </div>
+                    {{end}}
+                </div>
+                {{else}}
+                <div class="success-message">
+                    🎉 <strong>No issues found!</strong> This code follows all security and quality best practices.
+                </div>
+                {{end}}
+
+                {{if .CodeSnippet}}
+                <div class="code-snippet">{{.CodeSnippet}}</div>
+                {{end}}
+
+                {{if .Highlights}}
+                <div style="margin-top: 20px; padding: 15px; background: #f0fdf4; border-radius: 8px;">
+                    <h4 style="color: #059669; margin-bottom: 10px;">✅ Improvements Made:</h4>
+                    <ul style="color: #065f46; padding-left: 20px;">
+                        {{range .Highlights}}
+                        <li>{{.}}</li>
+                        {{end}}
+                    </ul>
+                </div>
+                {{end}}
+            </div>
+            {{end}}
+        </div>
+
+        <footer>
+            <p><strong>🤖 Generated by AurumCode</strong></p>
+            <p style="margin: 10px 0;">Automated AI-Powered Code Quality Platform</p>
+            <p>
+                <a href="https://github.com/Mpaape/AurumCode">View on GitHub</a>
+            </p>
+            <p style="margin-top: 15px; font-size: 0.9em;">
+                The analysis uses ISO/IEC 25010 quality standards for comprehensive code review.
+            </p>
+        </footer>
+    </div>
+</body>
+</html>`
@@ -0,0 +1,53 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// renderText formats a review result for a terminal, one issue per line in
+// the file:line form editors and CI logs link to
+func renderText(result types.ReviewResult) string {
+	var b strings.Builder
+
+	counts := make(map[types.Severity]int)
+	for _, issue := range result.Issues {
+		location := issue.File
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", issue.File, issue.Line)
+		}
+
+		rule := ""
+		if issue.RuleID != "" {
+			rule = " " + issue.RuleID + ":"
+		}
+
+		fmt.Fprintf(&b, "%s: [%s]%s %s\n", location, issue.Severity, rule, issue.Message)
+		if issue.Suggestion != "" {
+			fmt.Fprintf(&b, "    suggestion: %s\n", issue.Suggestion)
+		}
+
+		severity, err := types.ParseSeverity(string(issue.Severity))
+		if err != nil {
+			severity = issue.Severity
+		}
+		counts[severity]++
+	}
+
+	if len(result.Issues) > 0 {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%d issue(s): %d error, %d warning, %d info\n",
+		len(result.Issues), counts[types.SeverityError], counts[types.SeverityWarning], counts[types.SeverityInfo])
+
+	if result.OverallScore > 0 {
+		fmt.Fprintf(&b, "Overall score: %.1f\n", result.OverallScore)
+	}
+	if summary := strings.TrimSpace(result.Summary); summary != "" {
+		fmt.Fprintf(&b, "\n%s\n", summary)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,118 @@
+package review
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// DefaultDedupLines is how close repeated findings must be to collapse
+const DefaultDedupLines = 10
+
+// dedupKey identifies findings that say the same thing about the same file
+type dedupKey struct {
+	file    string
+	rule    string
+	message string
+}
+
+// dedupGroup is a kept issue and the lines of the duplicates merged into it
+type dedupGroup struct {
+	issue types.ReviewIssue
+	lines []int
+}
+
+// Deduplicate collapses issues with the same file, rule and normalized
+// message reported within window lines of each other into the first one,
+// noting the other lines in its message. The most severe duplicate's
+// severity is kept. A negative window disables deduplication.
+func Deduplicate(issues []types.ReviewIssue, window int) []types.ReviewIssue {
+	if window < 0 {
+		return issues
+	}
+
+	var groups []*dedupGroup
+	byKey := make(map[dedupKey][]*dedupGroup)
+
+	for _, issue := range issues {
+		key := dedupKey{
+			file:    issue.File,
+			rule:    issue.RuleID,
+			message: normalizeMessage(issue.Message),
+		}
+
+		if group := nearbyGroup(byKey[key], issue.Line, window); group != nil {
+			group.lines = append(group.lines, issue.Line)
+			if !group.issue.Severity.AtLeast(issue.Severity) {
+				group.issue.Severity = issue.Severity
+			}
+			continue
+		}
+
+		group := &dedupGroup{issue: issue, lines: []int{issue.Line}}
+		groups = append(groups, group)
+		byKey[key] = append(byKey[key], group)
+	}
+
+	deduped := make([]types.ReviewIssue, 0, len(groups))
+	for _, group := range groups {
+		if len(group.lines) > 1 {
+			group.issue.Message += alsoAt(group.issue.Line, group.lines)
+		}
+		deduped = append(deduped, group.issue)
+	}
+	return deduped
+}
+
+// nearbyGroup returns the group with a line within window of line, if any
+func nearbyGroup(groups []*dedupGroup, line, window int) *dedupGroup {
+	for _, group := range groups {
+		for _, l := range group.lines {
+			if abs(l-line) <= window {
+				return group
+			}
+		}
+	}
+	return nil
+}
+
+// alsoAt describes the lines other than first, e.g. " (also at lines 12, 15)"
+func alsoAt(first int, lines []int) string {
+	others := make([]int, 0, len(lines)-1)
+	seen := map[int]bool{first: true}
+	for _, l := range lines {
+		if !seen[l] {
+			seen[l] = true
+			others = append(others, l)
+		}
+	}
+	if len(others) == 0 {
+		return fmt.Sprintf(" (reported %d times)", len(lines))
+	}
+	sort.Ints(others)
+
+	strs := make([]string, len(others))
+	for i, l := range others {
+		strs[i] = strconv.Itoa(l)
+	}
+	if len(others) == 1 {
+		return " (also at line " + strs[0] + ")"
+	}
+	return " (also at lines " + strings.Join(strs, ", ") + ")"
+}
+
+// normalizeMessage ignores case, spacing and trailing punctuation
+func normalizeMessage(message string) string {
+	message = strings.Join(strings.Fields(strings.ToLower(message)), " ")
+	return strings.TrimRight(message, ".!;:")
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,70 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+func TestDeduplicate(t *testing.T) {
+	issues := []types.ReviewIssue{
+		{File: "a.go", Line: 10, Severity: "warning", RuleID: "errors/unchecked", Message: "Error is not checked."},
+		{File: "a.go", Line: 14, Severity: "error", RuleID: "errors/unchecked", Message: "error is  not checked"},
+		{File: "a.go", Line: 18, Severity: "warning", RuleID: "errors/unchecked", Message: "Error is not checked"},
+		// Same finding, too far away
+		{File: "a.go", Line: 200, Severity: "warning", RuleID: "errors/unchecked", Message: "Error is not checked"},
+		// Near-duplicates: another file, rule or message
+		{File: "b.go", Line: 11, Severity: "warning", RuleID: "errors/unchecked", Message: "Error is not checked"},
+		{File: "a.go", Line: 12, Severity: "warning", RuleID: "errors/wrap", Message: "Error is not checked"},
+		{File: "a.go", Line: 13, Severity: "warning", RuleID: "errors/unchecked", Message: "Error from Close is not checked"},
+	}
+
+	got := Deduplicate(issues, 5)
+	if len(got) != 5 {
+		t.Fatalf("Expected 5 issues, got %d: %+v", len(got), got)
+	}
+
+	merged := got[0]
+	if merged.Line != 10 || merged.Message != "Error is not checked. (also at lines 14, 18)" {
+		t.Errorf("Unexpected merged issue: line %d, %q", merged.Line, merged.Message)
+	}
+	if merged.Severity != "error" {
+		t.Errorf("Merged issue should keep the highest severity, got %q", merged.Severity)
+	}
+
+	for i, want := range []struct {
+		file string
+		line int
+		rule string
+	}{
+		{"a.go", 200, "errors/unchecked"},
+		{"b.go", 11, "errors/unchecked"},
+		{"a.go", 12, "errors/wrap"},
+		{"a.go", 13, "errors/unchecked"},
+	} {
+		issue := got[i+1]
+		if issue.File != want.file || issue.Line != want.line || issue.RuleID != want.rule {
+			t.Errorf("issue %d = %s:%d %s, want %s:%d %s", i+1, issue.File, issue.Line, issue.RuleID, want.file, want.line, want.rule)
+		}
+		if issue.Message != issues[i+3].Message {
+			t.Errorf("Distinct issue %d should be untouched, got %q", i+1, issue.Message)
+		}
+	}
+}
+
+func TestDeduplicate_SameLine(t *testing.T) {
+	issue := types.ReviewIssue{File: "a.go", Line: 3, RuleID: "r", Message: "m"}
+
+	got := Deduplicate([]types.ReviewIssue{issue, issue}, DefaultDedupLines)
+	if len(got) != 1 || got[0].Message != "m (reported 2 times)" {
+		t.Errorf("Unexpected result: %+v", got)
+	}
+}
+
+func TestDeduplicate_Disabled(t *testing.T) {
+	issue := types.ReviewIssue{File: "a.go", Line: 3, RuleID: "r", Message: "m"}
+
+	if got := Deduplicate([]types.ReviewIssue{issue, issue}, -1); len(got) != 2 {
+		t.Errorf("A negative window should disable deduplication, got %d issues", len(got))
+	}
+}
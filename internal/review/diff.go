@@ -0,0 +1,138 @@
+package review
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// hunkHeader matches "@@ -old[,count] +new[,count] @@"
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// ParseDiff parses unified diff output from git into files and hunks.
+// Hunk lines keep their leading ' ', '+' or '-' marker. Renames, deletions
+// and binary changes are recorded from git's extended header lines.
+func ParseDiff(text string) (*types.Diff, error) {
+	diff := &types.Diff{Files: []types.DiffFile{}}
+	detector := extractors.NewDetector()
+
+	var file *types.DiffFile
+	var hunk *types.DiffHunk
+
+	flush := func() {
+		if file == nil {
+			return
+		}
+		if hunk != nil {
+			file.Hunks = append(file.Hunks, *hunk)
+			hunk = nil
+		}
+		file.Lang = string(detector.LanguageOf(file.Path))
+		if isNotebook(file.Path) {
+			file.Lang = NotebookLanguage
+		}
+		diff.Files = append(diff.Files, *file)
+		file = nil
+	}
+
+	for n, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+			file = &types.DiffFile{Path: gitHeaderPath(line), Hunks: []types.DiffHunk{}}
+
+		case file == nil:
+			// Preamble before the first file header
+
+		case hunk == nil && strings.HasPrefix(line, "rename from "):
+			file.OldPath = strings.TrimPrefix(line, "rename from ")
+			file.IsRename = true
+
+		case hunk == nil && strings.HasPrefix(line, "rename to "):
+			file.Path = strings.TrimPrefix(line, "rename to ")
+			file.IsRename = true
+
+		case hunk == nil && strings.HasPrefix(line, "deleted file mode"):
+			file.IsDelete = true
+
+		case hunk == nil && (strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch"):
+			file.IsBinary = true
+
+		case hunk == nil && strings.HasPrefix(line, "--- "):
+			if path := diffPath(line[4:]); path != "" && !file.IsRename {
+				file.Path = path
+			}
+
+		case hunk == nil && strings.HasPrefix(line, "+++ "):
+			if path := diffPath(line[4:]); path != "" {
+				file.Path = path
+			}
+
+		case strings.HasPrefix(line, "@@"):
+			m := hunkHeader.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("invalid hunk header at line %d: %q", n+1, line)
+			}
+			if hunk != nil {
+				file.Hunks = append(file.Hunks, *hunk)
+			}
+			hunk = &types.DiffHunk{
+				OldStart: atoi(m[1]),
+				OldLines: count(m[2]),
+				NewStart: atoi(m[3]),
+				NewLines: count(m[4]),
+				Lines:    []string{},
+			}
+
+		case hunk != nil && strings.HasPrefix(line, "\\"):
+			hunk.NoNewlineAtEOF = true
+
+		case hunk != nil && (line == "" || strings.ContainsAny(line[:1], " +-")):
+			hunk.Lines = append(hunk.Lines, line)
+		}
+	}
+	flush()
+
+	return diff, nil
+}
+
+// gitHeaderPath returns the new path from "diff --git a/x b/x"
+func gitHeaderPath(line string) string {
+	header := strings.TrimPrefix(line, "diff --git ")
+	if i := strings.LastIndex(header, " b/"); i != -1 {
+		return header[i+3:]
+	}
+	return header
+}
+
+// diffPath strips the a/ or b/ prefix from a ---/+++ path; /dev/null is ""
+func diffPath(path string) string {
+	path = strings.TrimSpace(path)
+	if i := strings.Index(path, "\t"); i != -1 {
+		path = path[:i]
+	}
+	if path == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(path, "a/") || strings.HasPrefix(path, "b/") {
+		return path[2:]
+	}
+	return path
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// count parses an optional hunk line count, which defaults to 1
+func count(s string) int {
+	if s == "" {
+		return 1
+	}
+	return atoi(s)
+}
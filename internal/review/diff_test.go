@@ -0,0 +1,198 @@
+package review
+
+import (
+	"reflect"
+	"testing"
+)
+
+const stagedDiff = `diff --git a/main.go b/main.go
+index 3b18e51..a9c7d0f 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,5 @@
+ package main
+ 
+-func main() {}
++func main() {
++	println("hi")
++}
+@@ -10 +12,2 @@ func helper() {
+-	return
++	// done
++	return
+diff --git a/scripts/old.py b/scripts/old.py
+deleted file mode 100644
+index 1111111..0000000
+--- a/scripts/old.py
++++ /dev/null
+@@ -1,2 +0,0 @@
+-print("a")
+---- not a header
+`
+
+func TestParseDiff(t *testing.T) {
+	diff, err := ParseDiff(stagedDiff)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+
+	if len(diff.Files) != 2 {
+		t.Fatalf("Expected 2 files, got %d", len(diff.Files))
+	}
+
+	main := diff.Files[0]
+	if main.Path != "main.go" || main.Lang != "go" {
+		t.Errorf("Unexpected file: path=%q lang=%q", main.Path, main.Lang)
+	}
+	if len(main.Hunks) != 2 {
+		t.Fatalf("Expected 2 hunks, got %d", len(main.Hunks))
+	}
+
+	first := main.Hunks[0]
+	if first.OldStart != 1 || first.OldLines != 3 || first.NewStart != 1 || first.NewLines != 5 {
+		t.Errorf("Unexpected hunk range: %+v", first)
+	}
+	wantLines := []string{" package main", " ", "-func main() {}", "+func main() {", "+\tprintln(\"hi\")", "+}"}
+	if !reflect.DeepEqual(first.Lines, wantLines) {
+		t.Errorf("Lines = %q, want %q", first.Lines, wantLines)
+	}
+
+	second := main.Hunks[1]
+	if second.OldStart != 10 || second.OldLines != 1 || second.NewStart != 12 || second.NewLines != 2 {
+		t.Errorf("Omitted counts should default to 1: %+v", second)
+	}
+
+	deleted := diff.Files[1]
+	if deleted.Path != "scripts/old.py" || deleted.Lang != "python" {
+		t.Errorf("Deleted file should keep its old path, got %q (%q)", deleted.Path, deleted.Lang)
+	}
+	if got := deleted.Hunks[0].Lines; len(got) != 2 || got[1] != "---- not a header" {
+		t.Errorf("Removed lines starting with --- should stay in the hunk, got %q", got)
+	}
+}
+
+func TestParseDiff_RenamesDeletesAndBinaries(t *testing.T) {
+	text := `diff --git a/old/name.go b/new/name.go
+similarity index 100%
+rename from old/name.go
+rename to new/name.go
+diff --git a/pkg/a.go b/pkg/b.go
+similarity index 90%
+rename from pkg/a.go
+rename to pkg/b.go
+index 1111111..2222222 100644
+--- a/pkg/a.go
++++ b/pkg/b.go
+@@ -1 +1 @@
+-package a
++package b
+diff --git a/logo.png b/logo.png
+index 3333333..4444444 100644
+Binary files a/logo.png and b/logo.png differ
+diff --git a/main.go b/main.go
+index 3b18e51..a9c7d0f 100644
+--- a/main.go
++++ b/main.go
+@@ -1 +1 @@
+-package old
++package main
+`
+
+	diff, err := ParseDiff(text)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+	if len(diff.Files) != 4 {
+		t.Fatalf("Expected 4 files, got %d", len(diff.Files))
+	}
+
+	pure := diff.Files[0]
+	if !pure.IsRename || pure.OldPath != "old/name.go" || pure.Path != "new/name.go" || len(pure.Hunks) != 0 {
+		t.Errorf("Unexpected pure rename: %+v", pure)
+	}
+
+	edited := diff.Files[1]
+	if !edited.IsRename || edited.OldPath != "pkg/a.go" || edited.Path != "pkg/b.go" || len(edited.Hunks) != 1 {
+		t.Errorf("Unexpected rename with edits: %+v", edited)
+	}
+
+	binary := diff.Files[2]
+	if !binary.IsBinary || binary.Path != "logo.png" || len(binary.Hunks) != 0 {
+		t.Errorf("Unexpected binary change: %+v", binary)
+	}
+
+	modified := diff.Files[3]
+	if modified.IsRename || modified.IsDelete || modified.IsBinary || modified.OldPath != "" || len(modified.Hunks) != 1 {
+		t.Errorf("Modified file should carry no rename, delete or binary info: %+v", modified)
+	}
+
+	staged, err := ParseDiff(stagedDiff)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+	if staged.Files[0].IsDelete || !staged.Files[1].IsDelete {
+		t.Errorf("Only scripts/old.py should be a deletion: %+v", staged.Files)
+	}
+}
+
+func TestParseDiff_NoNewlineAtEOF(t *testing.T) {
+	// Ends without a trailing newline, as git output captured verbatim can
+	text := "diff --git a/version.txt b/version.txt\n" +
+		"--- a/version.txt\n" +
+		"+++ b/version.txt\n" +
+		"@@ -1,2 +1,2 @@\n" +
+		" name\n" +
+		"-1.0.0\n" +
+		"\\ No newline at end of file\n" +
+		"+1.1.0\n" +
+		"\\ No newline at end of file"
+
+	diff, err := ParseDiff(text)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+
+	hunk := diff.Files[0].Hunks[0]
+	want := []string{" name", "-1.0.0", "+1.1.0"}
+	if !reflect.DeepEqual(hunk.Lines, want) {
+		t.Errorf("Lines = %q, want %q", hunk.Lines, want)
+	}
+	if !hunk.NoNewlineAtEOF {
+		t.Error("Expected NoNewlineAtEOF to be set")
+	}
+
+	var oldCount, newCount int
+	for _, line := range hunk.Lines {
+		if line[0] != '+' {
+			oldCount++
+		}
+		if line[0] != '-' {
+			newCount++
+		}
+	}
+	if oldCount != hunk.OldLines || newCount != hunk.NewLines {
+		t.Errorf("Line counts %d/%d do not match header %d/%d", oldCount, newCount, hunk.OldLines, hunk.NewLines)
+	}
+
+	staged, _ := ParseDiff(stagedDiff)
+	if staged.Files[0].Hunks[0].NoNewlineAtEOF {
+		t.Error("Hunks without the marker should not be flagged")
+	}
+}
+
+func TestParseDiff_Empty(t *testing.T) {
+	diff, err := ParseDiff("")
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+	if len(diff.Files) != 0 {
+		t.Errorf("Expected no files, got %d", len(diff.Files))
+	}
+}
+
+func TestParseDiff_InvalidHunkHeader(t *testing.T) {
+	_, err := ParseDiff("diff --git a/x.go b/x.go\n--- a/x.go\n+++ b/x.go\n@@ bogus @@\n")
+	if err == nil {
+		t.Error("Expected an error for a malformed hunk header")
+	}
+}
@@ -0,0 +1,97 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// DefaultSimilarityThreshold is the cosine similarity at which two changed
+// files are considered related
+const DefaultSimilarityThreshold = 0.8
+
+// GroupBySimilarity groups files whose embeddings have a cosine similarity
+// of at least threshold with the first file of a group. embeddings[i] is the
+// vector for files[i]. Groups and their members keep the order of files.
+func GroupBySimilarity(files []types.DiffFile, embeddings [][]float64, threshold float64) ([][]types.DiffFile, error) {
+	if len(files) != len(embeddings) {
+		return nil, fmt.Errorf("got %d embeddings for %d files", len(embeddings), len(files))
+	}
+
+	var groups [][]types.DiffFile
+	var seeds [][]float64
+
+	for i, file := range files {
+		placed := false
+		for g, seed := range seeds {
+			if CosineSimilarity(seed, embeddings[i]) >= threshold {
+				groups[g] = append(groups[g], file)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			groups = append(groups, []types.DiffFile{file})
+			seeds = append(seeds, embeddings[i])
+		}
+	}
+	return groups, nil
+}
+
+// GroupFiles embeds each file's changes with embedder and groups related
+// files with GroupBySimilarity
+func GroupFiles(ctx context.Context, embedder llm.Embeddings, files []types.DiffFile, threshold float64) ([][]types.DiffFile, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	inputs := make([]string, len(files))
+	for i, file := range files {
+		inputs[i] = embeddingText(file)
+	}
+
+	embeddings, err := embedder.Embed(ctx, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed diff: %w", err)
+	}
+	return GroupBySimilarity(files, embeddings, threshold)
+}
+
+// CosineSimilarity returns the cosine of the angle between a and b, or 0 if
+// either is empty, zero or they differ in length
+func CosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// embeddingText is the text embedded for a file: its path and changed lines
+func embeddingText(file types.DiffFile) string {
+	var b strings.Builder
+	b.WriteString(file.Path)
+	b.WriteString("\n")
+	for _, h := range file.Hunks {
+		for _, line := range h.Lines {
+			if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+				b.WriteString(line)
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
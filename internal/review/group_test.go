@@ -0,0 +1,115 @@
+package review
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// fakeEmbeddings returns a fixed vector per input, keyed by file path
+type fakeEmbeddings struct {
+	vectors map[string][]float64
+	inputs  []string
+}
+
+func (f *fakeEmbeddings) Embed(ctx context.Context, inputs []string) ([][]float64, error) {
+	f.inputs = inputs
+	out := make([][]float64, len(inputs))
+	for i, input := range inputs {
+		path, _, _ := strings.Cut(input, "\n")
+		out[i] = f.vectors[path]
+	}
+	return out, nil
+}
+
+func TestGroupBySimilarity(t *testing.T) {
+	files := []types.DiffFile{
+		{Path: "auth/login.go"},
+		{Path: "docs/readme.md"},
+		{Path: "auth/session.go"},
+		{Path: "docs/guide.md"},
+		{Path: "build/Makefile"},
+	}
+	embeddings := [][]float64{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0.9, 0.1, 0},
+		{0.1, 0.95, 0},
+		{0, 0, 1},
+	}
+
+	groups, err := GroupBySimilarity(files, embeddings, DefaultSimilarityThreshold)
+	if err != nil {
+		t.Fatalf("GroupBySimilarity() failed: %v", err)
+	}
+
+	want := [][]string{
+		{"auth/login.go", "auth/session.go"},
+		{"docs/readme.md", "docs/guide.md"},
+		{"build/Makefile"},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("Expected %d groups, got %d: %+v", len(want), len(groups), groups)
+	}
+	for i, group := range groups {
+		var paths []string
+		for _, f := range group {
+			paths = append(paths, f.Path)
+		}
+		if strings.Join(paths, ",") != strings.Join(want[i], ",") {
+			t.Errorf("Group %d: expected %v, got %v", i, want[i], paths)
+		}
+	}
+}
+
+func TestGroupBySimilarity_LengthMismatch(t *testing.T) {
+	if _, err := GroupBySimilarity([]types.DiffFile{{Path: "a.go"}}, nil, 0.8); err == nil {
+		t.Error("Expected error when embeddings and files differ in length")
+	}
+}
+
+func TestGroupFiles(t *testing.T) {
+	diff, err := ParseDiff(stagedDiff)
+	if err != nil {
+		t.Fatalf("ParseDiff() failed: %v", err)
+	}
+
+	vectors := map[string][]float64{}
+	for _, f := range diff.Files {
+		vectors[f.Path] = []float64{1, 0}
+	}
+	embedder := &fakeEmbeddings{vectors: vectors}
+
+	groups, err := GroupFiles(context.Background(), embedder, diff.Files, DefaultSimilarityThreshold)
+	if err != nil {
+		t.Fatalf("GroupFiles() failed: %v", err)
+	}
+	if len(groups) != 1 || len(groups[0]) != len(diff.Files) {
+		t.Errorf("Expected all files in one group, got %+v", groups)
+	}
+	if len(embedder.inputs) != len(diff.Files) || !strings.Contains(embedder.inputs[0], "+") {
+		t.Errorf("Expected one input per file with changed lines, got %q", embedder.inputs)
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b []float64
+		want float64
+	}{
+		{[]float64{1, 0}, []float64{2, 0}, 1},
+		{[]float64{1, 0}, []float64{0, 1}, 0},
+		{[]float64{1, 0}, []float64{-1, 0}, -1},
+		{[]float64{0, 0}, []float64{1, 0}, 0},
+		{[]float64{1}, []float64{1, 0}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := CosineSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("CosineSimilarity(%v, %v) = %g, want %g", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
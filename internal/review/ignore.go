@@ -0,0 +1,68 @@
+package review
+
+import (
+	"path"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// IgnorePaths returns diff without the files matching any of patterns
+func IgnorePaths(diff *types.Diff, patterns []string) *types.Diff {
+	if len(patterns) == 0 {
+		return diff
+	}
+
+	kept := &types.Diff{Files: []types.DiffFile{}}
+	for _, file := range diff.Files {
+		if !MatchAny(patterns, file.Path) {
+			kept.Files = append(kept.Files, file)
+		}
+	}
+	return kept
+}
+
+// MatchAny reports whether file matches any of patterns. Patterns without a
+// slash match the file name in any directory; "**" matches any number of
+// directories.
+func MatchAny(patterns []string, file string) bool {
+	file = strings.TrimPrefix(path.Clean(strings.ReplaceAll(file, "\\", "/")), "./")
+
+	for _, pattern := range patterns {
+		if !strings.Contains(pattern, "/") {
+			if ok, _ := path.Match(pattern, path.Base(file)); ok {
+				return true
+			}
+			continue
+		}
+		if matchSegments(strings.Split(strings.TrimPrefix(pattern, "/"), "/"), strings.Split(file, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments against pattern segments, where a "**"
+// segment matches zero or more path segments
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,92 @@
+package review
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+func TestMatchAny(t *testing.T) {
+	tests := []struct {
+		pattern string
+		file    string
+		want    bool
+	}{
+		{"*.lock", "Cargo.lock", true},
+		{"*.lock", "web/yarn.lock", true},
+		{"package-lock.json", "web/package-lock.json", true},
+		{"*.lock", "lock.go", false},
+		{"gen/*.go", "gen/api.go", true},
+		{"gen/*.go", "gen/v1/api.go", false},
+		{"gen/**", "gen/v1/api.go", true},
+		{"**/__snapshots__/**", "ui/button/__snapshots__/button.snap", true},
+		{"**/*.pb.go", "api.pb.go", true},
+		{"**/*.pb.go", "internal/api/api.pb.go", true},
+		{"/vendor/**", "vendor/lib/a.go", true},
+		{"vendor/**", "internal/vendor/a.go", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchAny([]string{tt.pattern}, tt.file); got != tt.want {
+			t.Errorf("MatchAny(%q, %q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestReviewer_IgnorePaths(t *testing.T) {
+	diff, err := ParseDiff(stagedDiff)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+
+	provider := &mockProvider{text: `{"issues":[` +
+		`{"file":"main.go","line":4,"severity":"warning","rule_id":"a","message":"kept"},` +
+		`{"file":"scripts/old.py","line":1,"severity":"error","rule_id":"b","message":"ignored"}` +
+		`]}`}
+
+	reviewer := NewReviewer(newTestOrchestrator(provider)).
+		WithConfig(types.ReviewConfig{IgnorePaths: []string{"scripts/**"}})
+
+	result, err := reviewer.Review(context.Background(), diff)
+	if err != nil {
+		t.Fatalf("Review failed: %v", err)
+	}
+
+	if len(provider.prompts) != 1 {
+		t.Fatalf("Expected one LLM call, got %d", len(provider.prompts))
+	}
+	if strings.Contains(provider.prompts[0], "scripts/old.py") {
+		t.Error("Ignored files should be stripped from the prompt")
+	}
+	if !strings.Contains(provider.prompts[0], "## File: main.go") {
+		t.Error("Files not ignored should still be reviewed")
+	}
+
+	if len(result.Issues) != 1 || result.Issues[0].File != "main.go" {
+		t.Errorf("Issues on ignored files should be dropped, got %+v", result.Issues)
+	}
+}
+
+func TestReviewer_IgnorePathsSkipsLLMWhenNothingLeft(t *testing.T) {
+	diff, err := ParseDiff(stagedDiff)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+
+	provider := &mockProvider{text: `{"issues":[]}`}
+	reviewer := NewReviewer(newTestOrchestrator(provider)).
+		WithConfig(types.ReviewConfig{IgnorePaths: []string{"*.go", "*.py"}})
+
+	result, err := reviewer.Review(context.Background(), diff)
+	if err != nil {
+		t.Fatalf("Review failed: %v", err)
+	}
+	if len(provider.prompts) != 0 {
+		t.Errorf("Expected no LLM call, got %d", len(provider.prompts))
+	}
+	if len(result.Issues) != 0 || result.Summary != "No changes to review." {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
@@ -0,0 +1,35 @@
+package review
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// LimitIssues keeps at most max of the most severe issues in result, in
+// severity order, and notes how many were omitted in its summary. Issues of
+// equal severity keep their original order. A max of 0 or less is no limit.
+func LimitIssues(result *types.ReviewResult, max int) {
+	if max <= 0 || len(result.Issues) <= max {
+		return
+	}
+
+	sort.SliceStable(result.Issues, func(i, j int) bool {
+		a, b := result.Issues[i].Severity, result.Issues[j].Severity
+		return a.AtLeast(b) && !b.AtLeast(a)
+	})
+
+	omitted := len(result.Issues) - max
+	result.Issues = result.Issues[:max]
+
+	note := fmt.Sprintf("+%d lower-severity findings omitted", omitted)
+	if omitted == 1 {
+		note = "+1 lower-severity finding omitted"
+	}
+	if result.Summary == "" {
+		result.Summary = note
+	} else {
+		result.Summary += "\n\n" + note
+	}
+}
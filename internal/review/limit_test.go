@@ -0,0 +1,62 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+func TestLimitIssues(t *testing.T) {
+	result := &types.ReviewResult{
+		Summary: "Looks mostly fine.",
+		Issues: []types.ReviewIssue{
+			{RuleID: "info-1", Severity: "info"},
+			{RuleID: "warn-1", Severity: "warning"},
+			{RuleID: "err-1", Severity: "error"},
+			{RuleID: "info-2", Severity: "info"},
+			{RuleID: "crit-1", Severity: "critical"},
+			{RuleID: "warn-2", Severity: "warning"},
+		},
+	}
+
+	LimitIssues(result, 3)
+
+	want := []string{"err-1", "crit-1", "warn-1"}
+	if len(result.Issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %d", len(want), len(result.Issues))
+	}
+	for i, rule := range want {
+		if result.Issues[i].RuleID != rule {
+			t.Errorf("issue %d = %s, want %s", i, result.Issues[i].RuleID, rule)
+		}
+	}
+
+	if result.Summary != "Looks mostly fine.\n\n+3 lower-severity findings omitted" {
+		t.Errorf("Unexpected summary: %q", result.Summary)
+	}
+}
+
+func TestLimitIssues_UnderCap(t *testing.T) {
+	result := &types.ReviewResult{
+		Issues: []types.ReviewIssue{{RuleID: "b", Severity: "info"}, {RuleID: "a", Severity: "error"}},
+	}
+
+	LimitIssues(result, 2)
+	LimitIssues(result, 0)
+
+	if len(result.Issues) != 2 || result.Issues[0].RuleID != "b" || result.Summary != "" {
+		t.Errorf("Results within the cap should be untouched, got %+v", result)
+	}
+}
+
+func TestLimitIssues_SingleOmitted(t *testing.T) {
+	result := &types.ReviewResult{
+		Issues: []types.ReviewIssue{{Severity: "info"}, {Severity: "error"}},
+	}
+
+	LimitIssues(result, 1)
+
+	if result.Issues[0].Severity != "error" || result.Summary != "+1 lower-severity finding omitted" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
@@ -0,0 +1,53 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultLocale is the language reviews are written in unless configured
+const DefaultLocale = "en"
+
+// languageNames maps locale codes to the language named in the prompt
+var languageNames = map[string]string{
+	"de": "German",
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"it": "Italian",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"nl": "Dutch",
+	"pl": "Polish",
+	"pt": "Portuguese",
+	"ru": "Russian",
+	"tr": "Turkish",
+	"uk": "Ukrainian",
+	"zh": "Chinese",
+}
+
+// LanguageName returns the language for a locale such as "pt-BR" or "fr".
+// Values that are not a known locale code, such as "Portuguese", are
+// returned unchanged.
+func LanguageName(locale string) string {
+	locale = strings.TrimSpace(locale)
+	code := strings.ToLower(locale)
+	if i := strings.IndexAny(code, "-_"); i != -1 {
+		code = code[:i]
+	}
+	if name, ok := languageNames[code]; ok {
+		return name
+	}
+	return locale
+}
+
+// localeInstruction tells the model which language to write findings in, or
+// returns "" for English
+func localeInstruction(locale string) string {
+	language := LanguageName(locale)
+	if language == "" || strings.EqualFold(language, "English") {
+		return ""
+	}
+	return fmt.Sprintf("Respond in %s: write issue messages, suggestions and the summary in %s. "+
+		"Keep JSON keys, rule_id values, severities and file paths exactly as specified, in English.", language, language)
+}
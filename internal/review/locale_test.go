@@ -0,0 +1,72 @@
+package review
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+func TestLanguageName(t *testing.T) {
+	tests := map[string]string{
+		"pt-BR":      "Portuguese",
+		"pt_br":      "Portuguese",
+		"FR":         "French",
+		"Portuguese": "Portuguese",
+		"Klingon":    "Klingon",
+		"":           "",
+	}
+
+	for locale, want := range tests {
+		if got := LanguageName(locale); got != want {
+			t.Errorf("LanguageName(%q) = %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestReviewer_Locale(t *testing.T) {
+	diff, err := ParseDiff(stagedDiff)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+
+	provider := &mockProvider{text: `{"issues":[]}`}
+	reviewer := NewReviewer(newTestOrchestrator(provider)).
+		WithConfig(types.ReviewConfig{Locale: "pt-BR"})
+
+	if _, err := reviewer.Review(context.Background(), diff); err != nil {
+		t.Fatalf("Review failed: %v", err)
+	}
+
+	system := provider.options[0].System
+	if !strings.Contains(system, "Respond in Portuguese") {
+		t.Errorf("System prompt missing locale instruction: %q", system)
+	}
+	if !strings.Contains(system, "rule_id values") {
+		t.Errorf("System prompt should keep rule IDs canonical: %q", system)
+	}
+	if !strings.HasPrefix(system, "You are an expert code reviewer") {
+		t.Errorf("Locale should extend the default system prompt, got %q", system)
+	}
+}
+
+func TestReviewer_DefaultLocaleAddsNoInstruction(t *testing.T) {
+	diff, err := ParseDiff(stagedDiff)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+
+	for _, locale := range []string{"", DefaultLocale, "en-US", "English"} {
+		provider := &mockProvider{text: `{"issues":[]}`}
+		reviewer := NewReviewer(newTestOrchestrator(provider)).
+			WithConfig(types.ReviewConfig{Locale: locale})
+
+		if _, err := reviewer.Review(context.Background(), diff); err != nil {
+			t.Fatalf("Review failed: %v", err)
+		}
+		if strings.Contains(provider.options[0].System, "Respond in") {
+			t.Errorf("Locale %q should not add an instruction, got %q", locale, provider.options[0].System)
+		}
+	}
+}
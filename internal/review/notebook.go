@@ -0,0 +1,252 @@
+package review
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// NotebookLanguage is the language ParseDiff reports for Jupyter notebooks
+const NotebookLanguage = "notebook"
+
+// notebookCell is a code cell rendered for review
+type notebookCell struct {
+	index int   // 1-based position among all cells in the notebook
+	lines []int // File line of each source line of the cell
+}
+
+// NotebookMap records the cells rendered for each notebook, so findings on
+// them can be traced back to a cell and line
+type NotebookMap map[string][]notebookCell
+
+// isNotebook reports whether path is a Jupyter notebook
+func isNotebook(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".ipynb")
+}
+
+// PrepareNotebooks replaces the raw JSON hunks of each notebook in diff with
+// one hunk per changed code cell, read from the new version of the notebook.
+// Each hunk holds the whole cell source, numbered by the file lines the
+// source lines sit on. Output and metadata changes are dropped. Notebooks
+// that cannot be read or parsed are left as they are.
+func PrepareNotebooks(ctx context.Context, diff *types.Diff, reader FileReader) (*types.Diff, NotebookMap) {
+	notebooks := NotebookMap{}
+	prepared := &types.Diff{Files: make([]types.DiffFile, 0, len(diff.Files))}
+
+	for _, file := range diff.Files {
+		if !isNotebook(file.Path) || file.IsDelete || file.IsBinary {
+			prepared.Files = append(prepared.Files, file)
+			continue
+		}
+
+		data, err := reader.ReadFile(ctx, file.Path)
+		if err != nil {
+			prepared.Files = append(prepared.Files, file)
+			continue
+		}
+
+		nb, err := parseNotebook(data)
+		if err != nil {
+			prepared.Files = append(prepared.Files, file)
+			continue
+		}
+
+		rendered, cells := nb.render(file, addedLines(file))
+		prepared.Files = append(prepared.Files, rendered)
+		notebooks[file.Path] = cells
+	}
+
+	return prepared, notebooks
+}
+
+// MapIssues rewrites findings on rendered notebook cells to point at the
+// cell's source line in the file and name the cell and line in the message
+func (m NotebookMap) MapIssues(issues []types.ReviewIssue) {
+	for i := range issues {
+		cells, ok := m[issues[i].File]
+		if !ok {
+			continue
+		}
+
+		for _, cell := range cells {
+			start := cell.lines[0]
+			offset := issues[i].Line - start
+			if offset < 0 || offset >= len(cell.lines) {
+				continue
+			}
+			issues[i].Line = cell.lines[offset]
+			issues[i].Message = fmt.Sprintf("Cell %d, line %d: %s", cell.index, offset+1, issues[i].Message)
+			break
+		}
+	}
+}
+
+// notebook is the parsed subset of a .ipynb file needed for review
+type notebook struct {
+	language string
+	cells    []parsedCell
+}
+
+// parsedCell is one notebook cell with the file line of each source line
+type parsedCell struct {
+	cellType string
+	source   []string
+	lines    []int
+}
+
+// parseNotebook reads the cells of a notebook, tracking the file line each
+// source line is stored on
+func parseNotebook(data []byte) (*notebook, error) {
+	nb := &notebook{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	visit := func(path []string, value interface{}, offset int64) {
+		text, ok := value.(string)
+		if !ok {
+			return
+		}
+
+		switch {
+		case len(path) >= 3 && path[0] == "metadata" && path[1] == "kernelspec" && path[2] == "language":
+			nb.language = text
+		case len(path) >= 3 && path[0] == "metadata" && path[1] == "language_info" && path[2] == "name" && nb.language == "":
+			nb.language = text
+		case len(path) >= 3 && path[0] == "cells":
+			index, err := strconv.Atoi(path[1])
+			if err != nil {
+				return
+			}
+			for len(nb.cells) <= index {
+				nb.cells = append(nb.cells, parsedCell{})
+			}
+			cell := &nb.cells[index]
+
+			switch path[2] {
+			case "cell_type":
+				cell.cellType = text
+			case "source":
+				// JSON strings cannot hold raw newlines, so the whole string
+				// sits on the line where it ends
+				line := bytes.Count(data[:offset], []byte("\n")) + 1
+				for _, s := range strings.SplitAfter(strings.TrimSuffix(text, "\n"), "\n") {
+					cell.source = append(cell.source, strings.TrimSuffix(s, "\n"))
+					cell.lines = append(cell.lines, line)
+				}
+			}
+		}
+	}
+
+	if err := walkJSON(decoder, nil, visit); err != nil {
+		return nil, fmt.Errorf("invalid notebook: %w", err)
+	}
+	return nb, nil
+}
+
+// walkJSON reads one JSON value from decoder, calling visit with the path
+// and end offset of every scalar in it
+func walkJSON(decoder *json.Decoder, path []string, visit func(path []string, value interface{}, offset int64)) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			if err := walkJSON(decoder, append(path[:len(path):len(path)], fmt.Sprint(key)), visit); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+		return err
+
+	case json.Delim('['):
+		for i := 0; decoder.More(); i++ {
+			if err := walkJSON(decoder, append(path[:len(path):len(path)], strconv.Itoa(i)), visit); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+		return err
+
+	default:
+		visit(path, token, decoder.InputOffset())
+		return nil
+	}
+}
+
+// render turns the code cells of nb touched by added into hunks of file
+func (nb *notebook) render(file types.DiffFile, added map[int]bool) (types.DiffFile, []notebookCell) {
+	rendered := file
+	rendered.Lang = strings.ToLower(nb.language)
+	if rendered.Lang == "" {
+		rendered.Lang = "python"
+	}
+	rendered.Hunks = []types.DiffHunk{}
+
+	var cells []notebookCell
+	for i, cell := range nb.cells {
+		if cell.cellType != "code" || len(cell.lines) == 0 || !cell.touched(added) {
+			continue
+		}
+
+		hunk := types.DiffHunk{
+			NewStart: cell.lines[0],
+			NewLines: len(cell.lines),
+			Lines:    make([]string, len(cell.source)),
+		}
+		for j, line := range cell.source {
+			if added[cell.lines[j]] {
+				hunk.Lines[j] = "+" + line
+			} else {
+				hunk.Lines[j] = " " + line
+				hunk.OldLines++
+			}
+		}
+		hunk.OldStart = hunk.NewStart
+
+		rendered.Hunks = append(rendered.Hunks, hunk)
+		cells = append(cells, notebookCell{index: i + 1, lines: cell.lines})
+	}
+
+	return rendered, cells
+}
+
+// touched reports whether any source line of the cell was added
+func (c parsedCell) touched(added map[int]bool) bool {
+	for _, line := range c.lines {
+		if added[line] {
+			return true
+		}
+	}
+	return false
+}
+
+// addedLines returns the new-file line numbers of the lines file adds
+func addedLines(file types.DiffFile) map[int]bool {
+	added := map[int]bool{}
+	for _, h := range file.Hunks {
+		line := h.NewStart
+		for _, l := range h.Lines {
+			switch {
+			case strings.HasPrefix(l, "+"):
+				added[line] = true
+				line++
+			case strings.HasPrefix(l, "-"):
+			default:
+				line++
+			}
+		}
+	}
+	return added
+}
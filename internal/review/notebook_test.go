@@ -0,0 +1,202 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// testNotebook is the new version of analysis.ipynb in notebookDiff
+const testNotebook = `{
+ "cells": [
+  {
+   "cell_type": "markdown",
+   "metadata": {},
+   "source": [
+    "# Analysis\n"
+   ]
+  },
+  {
+   "cell_type": "code",
+   "execution_count": 1,
+   "metadata": {},
+   "outputs": [
+    {
+     "name": "stdout",
+     "output_type": "stream",
+     "text": [
+      "loaded 42 rows\n"
+     ]
+    }
+   ],
+   "source": [
+    "import pandas as pd\n",
+    "df = pd.read_csv(\"data.csv\")\n",
+    "print(f\"loaded {len(df)} rows\")"
+   ]
+  },
+  {
+   "cell_type": "code",
+   "execution_count": 2,
+   "metadata": {},
+   "outputs": [],
+   "source": [
+    "df.describe()"
+   ]
+  }
+ ],
+ "metadata": {
+  "kernelspec": {
+   "display_name": "Python 3",
+   "language": "python",
+   "name": "python3"
+  }
+ },
+ "nbformat": 4,
+ "nbformat_minor": 5
+}
+`
+
+// notebookDiff changes a line of the second cell, its output and the
+// execution count of the third cell
+const notebookDiff = `diff --git a/analysis.ipynb b/analysis.ipynb
+index 1111111..2222222 100644
+--- a/analysis.ipynb
++++ b/analysis.ipynb
+@@ -16,13 +16,13 @@
+      "name": "stdout",
+      "output_type": "stream",
+      "text": [
+-      "loaded 40 rows\n"
++      "loaded 42 rows\n"
+      ]
+     }
+    ],
+    "source": [
+     "import pandas as pd\n",
+-    "df = pd.read_csv(\"old.csv\")\n",
++    "df = pd.read_csv(\"data.csv\")\n",
+     "print(f\"loaded {len(df)} rows\")"
+    ]
+   },
+@@ -31 +31 @@
+-   "execution_count": 1,
++   "execution_count": 2,
+`
+
+// fakeReader serves file contents from a map
+type fakeReader map[string]string
+
+func (f fakeReader) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	content, ok := f[path]
+	if !ok {
+		return nil, fmt.Errorf("%s not found", path)
+	}
+	return []byte(content), nil
+}
+
+func TestPrepareNotebooks(t *testing.T) {
+	diff, err := ParseDiff(notebookDiff)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+	if diff.Files[0].Lang != NotebookLanguage {
+		t.Errorf("Expected notebook language, got %q", diff.Files[0].Lang)
+	}
+
+	prepared, notebooks := PrepareNotebooks(context.Background(), diff, fakeReader{"analysis.ipynb": testNotebook})
+
+	file := prepared.Files[0]
+	if file.Lang != "python" {
+		t.Errorf("Expected the kernel language, got %q", file.Lang)
+	}
+	if len(file.Hunks) != 1 {
+		t.Fatalf("Only the edited code cell should be extracted, got %d hunks", len(file.Hunks))
+	}
+
+	hunk := file.Hunks[0]
+	wantLines := []string{` import pandas as pd`, `+df = pd.read_csv("data.csv")`, ` print(f"loaded {len(df)} rows")`}
+	if !reflect.DeepEqual(hunk.Lines, wantLines) {
+		t.Errorf("Lines = %q, want %q", hunk.Lines, wantLines)
+	}
+	if hunk.NewStart != 24 || hunk.NewLines != 3 {
+		t.Errorf("Cell should be numbered by its file lines, got %+v", hunk)
+	}
+
+	issues := []types.ReviewIssue{
+		{File: "analysis.ipynb", Line: 25, Message: "Hardcoded path."},
+		{File: "other.py", Line: 25, Message: "Untouched."},
+	}
+	notebooks.MapIssues(issues)
+
+	if issues[0].Line != 25 || issues[0].Message != "Cell 2, line 2: Hardcoded path." {
+		t.Errorf("Finding should map to its cell, got line %d, %q", issues[0].Line, issues[0].Message)
+	}
+	if issues[1].Message != "Untouched." {
+		t.Errorf("Findings outside notebooks should be unchanged, got %q", issues[1].Message)
+	}
+}
+
+func TestPrepareNotebooks_OutputOnlyChange(t *testing.T) {
+	diff, err := ParseDiff(`diff --git a/analysis.ipynb b/analysis.ipynb
+--- a/analysis.ipynb
++++ b/analysis.ipynb
+@@ -19 +19 @@
+-      "loaded 40 rows\n"
++      "loaded 42 rows\n"
+`)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+
+	prepared, _ := PrepareNotebooks(context.Background(), diff, fakeReader{"analysis.ipynb": testNotebook})
+	if len(prepared.Files[0].Hunks) != 0 {
+		t.Errorf("Output changes should not be reviewed, got %+v", prepared.Files[0].Hunks)
+	}
+	if HasReviewableChanges(prepared) {
+		t.Error("A notebook with only output changes has nothing to review")
+	}
+}
+
+func TestPrepareNotebooks_UnreadableKeepsRawDiff(t *testing.T) {
+	diff, err := ParseDiff(notebookDiff)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+
+	prepared, notebooks := PrepareNotebooks(context.Background(), diff, fakeReader{"analysis.ipynb": "{not json"})
+	if !reflect.DeepEqual(prepared.Files[0], diff.Files[0]) || len(notebooks) != 0 {
+		t.Error("An unparsable notebook should be left as a raw diff")
+	}
+}
+
+func TestRun_ReviewsNotebookCells(t *testing.T) {
+	runner := site.NewMockRunner().
+		WithOutput("git diff --cached", notebookDiff).
+		WithOutput("git show :analysis.ipynb", testNotebook)
+	provider := &mockProvider{text: `{"issues":[` +
+		`{"file":"analysis.ipynb","line":26,"severity":"info","rule_id":"style/print","message":"Use logging."}` +
+		`]}`}
+
+	result, err := Run(context.Background(), NewStagedProvider(runner, "."), NewReviewer(newTestOrchestrator(provider)))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	prompt := provider.prompts[0]
+	if !strings.Contains(prompt, "## File: analysis.ipynb (python)") || !strings.Contains(prompt, `+df = pd.read_csv("data.csv")`) {
+		t.Errorf("Prompt should contain the extracted cell:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "loaded 40 rows") || strings.Contains(prompt, "execution_count") {
+		t.Error("Notebook JSON should not reach the prompt")
+	}
+
+	if len(result.Issues) != 1 || result.Issues[0].Line != 26 || !strings.HasPrefix(result.Issues[0].Message, "Cell 2, line 3: ") {
+		t.Errorf("Finding should map to cell 2, line 3, got %+v", result.Issues)
+	}
+}
@@ -0,0 +1,54 @@
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// ParseResult extracts a review result from raw LLM output.
+// The model may wrap the JSON in a markdown code fence or surround it with prose.
+// Output that does not match the review schema fails with a *ResponseError.
+func ParseResult(text string) (*types.ReviewResult, error) {
+	raw := ExtractJSON(text)
+	if raw == "" {
+		return nil, fmt.Errorf("no JSON object found in response")
+	}
+
+	if err := ValidateResponse(KindReview, []byte(raw)); err != nil {
+		return nil, err
+	}
+
+	var result types.ReviewResult
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse review JSON: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ExtractJSON returns the outermost JSON object contained in text
+func ExtractJSON(text string) string {
+	text = strings.TrimSpace(text)
+
+	// Prefer fenced ```json blocks when present
+	if start := strings.Index(text, "```"); start != -1 {
+		body := text[start+3:]
+		if nl := strings.Index(body, "\n"); nl != -1 {
+			body = body[nl+1:]
+		}
+		if end := strings.Index(body, "```"); end != -1 {
+			text = strings.TrimSpace(body[:end])
+		}
+	}
+
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start == -1 || end < start {
+		return ""
+	}
+
+	return text[start : end+1]
+}
@@ -0,0 +1,60 @@
+package review
+
+import (
+	"testing"
+)
+
+func TestParseResult(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "plain JSON",
+			input: `{"issues":[{"file":"a.go","line":3,"severity":"error","rule_id":"security/x","message":"bad"}],"summary":"ok"}`,
+		},
+		{
+			name:  "fenced JSON",
+			input: "Here is the review:\n```json\n{\"issues\":[{\"file\":\"a.go\",\"line\":3,\"severity\":\"error\",\"rule_id\":\"security/x\",\"message\":\"bad\"}],\"summary\":\"ok\"}\n```\nThanks!",
+		},
+		{
+			name:  "surrounding prose",
+			input: "Review: {\"issues\":[{\"file\":\"a.go\",\"line\":3,\"severity\":\"error\",\"rule_id\":\"security/x\",\"message\":\"bad\"}],\"summary\":\"ok\"} done",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseResult(tt.input)
+			if err != nil {
+				t.Fatalf("ParseResult failed: %v", err)
+			}
+
+			if len(result.Issues) != 1 {
+				t.Fatalf("expected 1 issue, got %d", len(result.Issues))
+			}
+
+			if result.Issues[0].File != "a.go" || result.Issues[0].Line != 3 {
+				t.Errorf("unexpected issue: %+v", result.Issues[0])
+			}
+
+			if result.Summary != "ok" {
+				t.Errorf("expected summary 'ok', got %q", result.Summary)
+			}
+		})
+	}
+}
+
+func TestParseResult_Invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"no json here",
+		"{not valid json}",
+	}
+
+	for _, input := range inputs {
+		if _, err := ParseResult(input); err == nil {
+			t.Errorf("expected error for input %q", input)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// GitProvider supplies the diff to review and publishes the result
+type GitProvider interface {
+	// Diff returns the changes to review
+	Diff(ctx context.Context) (*types.Diff, error)
+
+	// Post publishes a finished review
+	Post(ctx context.Context, result *types.ReviewResult) error
+}
+
+// FileReader is implemented by providers that can read the new version of
+// a changed file, which lets notebooks be reviewed cell by cell
+type FileReader interface {
+	ReadFile(ctx context.Context, path string) ([]byte, error)
+}
+
+// LocalProvider reads diffs from a local git checkout. Posting is a no-op;
+// callers print the result instead.
+type LocalProvider struct {
+	runner site.CommandRunner
+	dir    string
+	args   []string
+	rev    string // Revision holding new file versions; "" for the index
+}
+
+// NewStagedProvider reviews the changes staged in the repository at dir
+func NewStagedProvider(runner site.CommandRunner, dir string) *LocalProvider {
+	return &LocalProvider{
+		runner: runner,
+		dir:    dir,
+		args:   []string{"diff", "--cached"},
+	}
+}
+
+// ErrInvalidRange indicates a commit range not of the form base..head
+var ErrInvalidRange = errors.New("invalid commit range")
+
+// NewRangeProvider reviews the changes between two revisions of the
+// repository at dir, given as "base..head" (or "base...head" to diff
+// against the merge base)
+func NewRangeProvider(runner site.CommandRunner, dir, commitRange string) (*LocalProvider, error) {
+	if err := ValidateRange(commitRange); err != nil {
+		return nil, err
+	}
+
+	sep := ".."
+	if strings.Contains(commitRange, "...") {
+		sep = "..."
+	}
+
+	return &LocalProvider{
+		runner: runner,
+		dir:    dir,
+		args:   []string{"diff", commitRange},
+		rev:    commitRange[strings.Index(commitRange, sep)+len(sep):],
+	}, nil
+}
+
+// ValidateRange checks that commitRange is "base..head" or "base...head"
+// with two non-empty revisions that cannot be mistaken for git flags
+func ValidateRange(commitRange string) error {
+	sep := ".."
+	if strings.Contains(commitRange, "...") {
+		sep = "..."
+	}
+
+	parts := strings.Split(commitRange, sep)
+	if len(parts) != 2 {
+		return fmt.Errorf("%w: %q (want base..head)", ErrInvalidRange, commitRange)
+	}
+	for _, rev := range parts {
+		if !validRevision(rev) {
+			return fmt.Errorf("%w: %q (want base..head)", ErrInvalidRange, commitRange)
+		}
+	}
+	return nil
+}
+
+// validRevision rejects empty revisions, flags, whitespace and stray dots
+func validRevision(rev string) bool {
+	return rev != "" &&
+		!strings.HasPrefix(rev, "-") &&
+		!strings.HasPrefix(rev, ".") &&
+		!strings.HasSuffix(rev, ".") &&
+		!strings.ContainsAny(rev, " \t\n")
+}
+
+// Diff runs git diff and parses its output
+func (p *LocalProvider) Diff(ctx context.Context) (*types.Diff, error) {
+	output, err := p.runner.Run(ctx, "git", p.args, p.dir, nil)
+	if err != nil {
+		return nil, fmt.Errorf("git diff failed: %w", err)
+	}
+
+	diff, err := ParseDiff(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse diff: %w", err)
+	}
+	return diff, nil
+}
+
+// ReadFile returns path as of the reviewed revision, or as staged in the index
+func (p *LocalProvider) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	output, err := p.runner.Run(ctx, "git", []string{"show", p.rev + ":" + path}, p.dir, nil)
+	if err != nil {
+		return nil, fmt.Errorf("git show failed for %s: %w", path, err)
+	}
+	return []byte(output), nil
+}
+
+// Post does nothing; local reviews are not published anywhere
+func (p *LocalProvider) Post(ctx context.Context, result *types.ReviewResult) error {
+	return nil
+}
+
+// Run reviews the diff supplied by provider and posts the result.
+// An empty diff produces an empty result without calling the LLM. Notebooks
+// are reviewed cell by cell when provider is a FileReader.
+func Run(ctx context.Context, provider GitProvider, reviewer *Reviewer) (*types.ReviewResult, error) {
+	diff, err := provider.Diff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(diff.Files) == 0 {
+		return noChangesResult(), nil
+	}
+
+	var notebooks NotebookMap
+	if reader, ok := provider.(FileReader); ok {
+		diff, notebooks = PrepareNotebooks(ctx, diff, reader)
+	}
+
+	result, err := reviewer.Review(ctx, diff)
+	if err != nil {
+		return nil, err
+	}
+	notebooks.MapIssues(result.Issues)
+
+	if err := provider.Post(ctx, result); err != nil {
+		return nil, fmt.Errorf("failed to post review: %w", err)
+	}
+	return result, nil
+}
+
+// noChangesResult is the result of reviewing a diff with nothing to review
+func noChangesResult() *types.ReviewResult {
+	return &types.ReviewResult{
+		Issues:  []types.ReviewIssue{},
+		Summary: "No changes to review.",
+	}
+}
@@ -0,0 +1,216 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/site"
+	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/internal/llm/cost"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// mockProvider is a minimal llm.Provider returning a canned review. Queued
+// responses are returned first, then text.
+type mockProvider struct {
+	text      string
+	responses []string
+	prompts   []string
+	options   []llm.Options
+}
+
+func (m *mockProvider) Complete(prompt string, opts llm.Options) (llm.Response, error) {
+	m.prompts = append(m.prompts, prompt)
+	m.options = append(m.options, opts)
+	text := m.text
+	if len(m.responses) > 0 {
+		text, m.responses = m.responses[0], m.responses[1:]
+	}
+	return llm.Response{Text: text, TokensIn: 100, TokensOut: 100, Model: "test-model"}, nil
+}
+
+func (m *mockProvider) Tokens(input string) (int, error) { return len(input) / 4, nil }
+
+func (m *mockProvider) Name() string { return "mock" }
+
+func newTestOrchestrator(provider *mockProvider) *llm.Orchestrator {
+	return llm.NewOrchestrator(provider, nil, cost.NewTracker(10.0, 100.0, map[string]cost.PriceMap{}))
+}
+
+func TestRun_ReviewsStagedDiff(t *testing.T) {
+	runner := site.NewMockRunner().WithOutput("git diff --cached", stagedDiff)
+	provider := &mockProvider{
+		text: `{"issues":[` +
+			`{"file":"main.go","line":4,"severity":"error","rule_id":"security/print","message":"debug output"},` +
+			`{"file":"main.go","line":12,"severity":"info","rule_id":"style/comment","message":"obvious comment"}` +
+			`],"summary":"two findings","overall_score":6}`,
+	}
+
+	result, err := Run(context.Background(), NewStagedProvider(runner, "/repo"), NewReviewer(newTestOrchestrator(provider)))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	calls := runner.GetCalls()
+	if len(calls) != 1 || calls[0].Workdir != "/repo" || strings.Join(calls[0].Args, " ") != "diff --cached" {
+		t.Errorf("Expected git diff --cached in /repo, got %+v", calls)
+	}
+
+	if len(provider.prompts) != 1 {
+		t.Fatalf("Expected one LLM call, got %d", len(provider.prompts))
+	}
+	for _, want := range []string{"## File: main.go (go)", "+\tprintln(\"hi\")", "## File: scripts/old.py"} {
+		if !strings.Contains(provider.prompts[0], want) {
+			t.Errorf("Prompt missing %q", want)
+		}
+	}
+
+	if len(result.Issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(result.Issues))
+	}
+
+	gated := GatedIssues(result.Issues, types.SeverityWarning)
+	if len(gated) != 1 || gated[0].RuleID != "security/print" {
+		t.Errorf("Only the error should be gated at warning, got %+v", gated)
+	}
+}
+
+func TestRun_EmptyDiffSkipsLLM(t *testing.T) {
+	runner := site.NewMockRunner().WithOutput("git diff --cached", "")
+	provider := &mockProvider{text: `{"issues":[]}`}
+
+	result, err := Run(context.Background(), NewStagedProvider(runner, "."), NewReviewer(newTestOrchestrator(provider)))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(provider.prompts) != 0 {
+		t.Error("An empty diff should not call the LLM")
+	}
+	if len(result.Issues) != 0 {
+		t.Errorf("Expected no issues, got %d", len(result.Issues))
+	}
+}
+
+func TestRun_NonReviewableDiffSkipsLLM(t *testing.T) {
+	tests := map[string]string{
+		"deletions only": `diff --git a/scripts/old.py b/scripts/old.py
+deleted file mode 100644
+index 1111111..0000000
+--- a/scripts/old.py
++++ /dev/null
+@@ -1,2 +0,0 @@
+-print("a")
+-print("b")
+diff --git a/main.go b/main.go
+index 3b18e51..a9c7d0f 100644
+--- a/main.go
++++ b/main.go
+@@ -3,2 +2,0 @@
+-// unused
+-var x = 1
+`,
+		"binary only": `diff --git a/logo.png b/logo.png
+index 1111111..2222222 100644
+Binary files a/logo.png and b/logo.png differ
+`,
+	}
+
+	for name, diff := range tests {
+		t.Run(name, func(t *testing.T) {
+			runner := site.NewMockRunner().WithOutput("git diff --cached", diff)
+			provider := &mockProvider{text: `{"issues":[]}`}
+
+			result, err := Run(context.Background(), NewStagedProvider(runner, "."), NewReviewer(newTestOrchestrator(provider)))
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if len(provider.prompts) != 0 {
+				t.Errorf("A diff without added lines should not call the LLM, got %d calls", len(provider.prompts))
+			}
+			if len(result.Issues) != 0 || !strings.HasPrefix(result.Summary, "No reviewable changes") {
+				t.Errorf("Unexpected result: %+v", result)
+			}
+		})
+	}
+}
+
+func TestRun_GitFailure(t *testing.T) {
+	runner := site.NewMockRunner().WithError("git diff", context.DeadlineExceeded)
+
+	_, err := Run(context.Background(), NewStagedProvider(runner, "."), NewReviewer(nil))
+	if err == nil || !strings.Contains(err.Error(), "git diff failed") {
+		t.Errorf("Expected git diff failure, got %v", err)
+	}
+}
+
+func TestRangeProvider_ReviewsRangeDiff(t *testing.T) {
+	runner := site.NewMockRunner().WithOutput("git diff main..feature", stagedDiff)
+	provider, err := NewRangeProvider(runner, "/repo", "main..feature")
+	if err != nil {
+		t.Fatalf("NewRangeProvider failed: %v", err)
+	}
+
+	llmProvider := &mockProvider{text: `{"issues":[{"file":"main.go","line":4,"severity":"warning","rule_id":"quality/x","message":"m"}]}`}
+	result, err := Run(context.Background(), provider, NewReviewer(newTestOrchestrator(llmProvider)))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	calls := runner.GetCalls()
+	if len(calls) != 1 || strings.Join(calls[0].Args, " ") != "diff main..feature" {
+		t.Errorf("Expected git diff main..feature, got %+v", calls)
+	}
+	if len(llmProvider.prompts) != 1 || !strings.Contains(llmProvider.prompts[0], "## File: main.go") {
+		t.Error("The range diff should be sent for review")
+	}
+	if len(result.Issues) != 1 {
+		t.Errorf("Expected 1 issue, got %d", len(result.Issues))
+	}
+}
+
+func TestValidateRange(t *testing.T) {
+	valid := []string{"main..feature", "v1.0.0..HEAD", "origin/main...HEAD", "abc123..def456", "HEAD~3..HEAD"}
+	for _, r := range valid {
+		if err := ValidateRange(r); err != nil {
+			t.Errorf("ValidateRange(%q) = %v, want nil", r, err)
+		}
+	}
+
+	invalid := []string{"", "main", "..feature", "main..", "a..b..c", "main....feature", "--output=x..HEAD", "main ..HEAD", "main..-p"}
+	for _, r := range invalid {
+		err := ValidateRange(r)
+		if !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("ValidateRange(%q) = %v, want ErrInvalidRange", r, err)
+		}
+	}
+
+	runner := site.NewMockRunner()
+	if _, err := NewRangeProvider(runner, ".", "main"); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("NewRangeProvider should reject an invalid range, got %v", err)
+	}
+	if len(runner.GetCalls()) != 0 {
+		t.Error("git should not run for an invalid range")
+	}
+}
+
+func TestLocalProvider_ReadFile(t *testing.T) {
+	runner := site.NewMockRunner()
+	staged := NewStagedProvider(runner, "/repo")
+	ranged, err := NewRangeProvider(runner, "/repo", "main...feature")
+	if err != nil {
+		t.Fatalf("NewRangeProvider failed: %v", err)
+	}
+
+	for _, p := range []*LocalProvider{staged, ranged} {
+		if _, err := p.ReadFile(context.Background(), "nb.ipynb"); err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+	}
+
+	calls := runner.GetCalls()
+	if len(calls) != 2 || strings.Join(calls[0].Args, " ") != "show :nb.ipynb" || strings.Join(calls[1].Args, " ") != "show feature:nb.ipynb" {
+		t.Errorf("Expected git show from the index and the range head, got %+v", calls)
+	}
+}
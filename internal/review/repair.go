@@ -0,0 +1,47 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// ParseOrRepair parses the review in text. If it is malformed or breaks the
+// review schema, the model is shown the error and asked once to return valid
+// JSON. The repair goes through completer, so it counts against the budget.
+func ParseOrRepair(ctx context.Context, completer Completer, text string, opts llm.Options) (*types.ReviewResult, error) {
+	result, parseErr := ParseResult(text)
+	if parseErr == nil {
+		return result, nil
+	}
+
+	resp, err := completer.Complete(ctx, buildRepairPrompt(text, parseErr), opts)
+	if err != nil {
+		return nil, fmt.Errorf("repair request failed after %v: %w", parseErr, err)
+	}
+
+	result, err = ParseResult(resp.Text)
+	if err != nil {
+		return nil, fmt.Errorf("response still invalid after repair: %w", err)
+	}
+	return result, nil
+}
+
+// buildRepairPrompt asks the model to fix a response that failed to parse
+func buildRepairPrompt(text string, parseErr error) string {
+	var b strings.Builder
+
+	b.WriteString("Your previous response could not be used because it is not valid review JSON.\n\n")
+	fmt.Fprintf(&b, "Error: %v\n\n", parseErr)
+	b.WriteString("Previous response:\n\n```\n")
+	b.WriteString(strings.TrimSpace(text))
+	b.WriteString("\n```\n\n")
+	b.WriteString("Return the same review as JSON only, with no other text, using this structure:\n\n")
+	b.WriteString(types.ReviewResultSchema)
+	b.WriteString("\n")
+
+	return b.String()
+}
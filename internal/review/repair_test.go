@@ -0,0 +1,92 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/internal/llm"
+)
+
+func TestReviewer_RepairsInvalidResponse(t *testing.T) {
+	diff, err := ParseDiff(stagedDiff)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+
+	provider := &mockProvider{responses: []string{
+		`{"issues":[{"file":"main.go","line":"four","severity":"error","message":"debug output"}]}`,
+		`{"issues":[{"file":"main.go","line":4,"severity":"error","rule_id":"security/print","message":"debug output"}],"summary":"repaired"}`,
+	}}
+
+	result, err := NewReviewer(newTestOrchestrator(provider)).Review(context.Background(), diff)
+	if err != nil {
+		t.Fatalf("Review failed: %v", err)
+	}
+
+	if len(provider.prompts) != 2 {
+		t.Fatalf("Expected exactly one repair call, got %d calls", len(provider.prompts))
+	}
+	repair := provider.prompts[1]
+	for _, want := range []string{"issues[0].line: expected integer, got string", `"line":"four"`, "JSON only"} {
+		if !strings.Contains(repair, want) {
+			t.Errorf("Repair prompt missing %q", want)
+		}
+	}
+
+	if result.Summary != "repaired" || len(result.Issues) != 1 || result.Issues[0].Line != 4 {
+		t.Errorf("Expected the repaired result, got %+v", result)
+	}
+}
+
+func TestReviewer_GivesUpAfterOneRepair(t *testing.T) {
+	diff, err := ParseDiff(stagedDiff)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+
+	provider := &mockProvider{text: "I could not review this."}
+
+	_, err = NewReviewer(newTestOrchestrator(provider)).Review(context.Background(), diff)
+	if err == nil {
+		t.Fatal("Expected an error when the repaired response is still invalid")
+	}
+	if len(provider.prompts) != 2 {
+		t.Errorf("Expected two calls, got %d", len(provider.prompts))
+	}
+}
+
+// budgetCompleter fails every call for lack of budget
+type budgetCompleter struct {
+	calls int
+}
+
+func (b *budgetCompleter) Complete(ctx context.Context, prompt string, opts llm.Options) (llm.Response, error) {
+	b.calls++
+	return llm.Response{}, fmt.Errorf("%w: insufficient budget for mock", llm.ErrBudgetExceeded)
+}
+
+func TestParseOrRepair_CountsAgainstBudget(t *testing.T) {
+	completer := &budgetCompleter{}
+
+	_, err := ParseOrRepair(context.Background(), completer, "not json", llm.DefaultOptions())
+	if !errors.Is(err, llm.ErrBudgetExceeded) {
+		t.Errorf("Expected the repair to fail on budget, got %v", err)
+	}
+	if completer.calls != 1 {
+		t.Errorf("Expected one repair attempt, got %d", completer.calls)
+	}
+}
+
+func TestParseOrRepair_ValidSkipsRepair(t *testing.T) {
+	completer := &budgetCompleter{}
+
+	if _, err := ParseOrRepair(context.Background(), completer, `{"issues":[]}`, llm.DefaultOptions()); err != nil {
+		t.Fatalf("ParseOrRepair failed: %v", err)
+	}
+	if completer.calls != 0 {
+		t.Errorf("A valid response should not be repaired, got %d calls", completer.calls)
+	}
+}
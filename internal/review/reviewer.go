@@ -0,0 +1,182 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// Completer is the subset of the LLM orchestrator used by the reviewer
+type Completer interface {
+	Complete(ctx context.Context, prompt string, opts llm.Options) (llm.Response, error)
+}
+
+// Reviewer reviews parsed diffs with an LLM
+type Reviewer struct {
+	completer Completer
+	options   llm.Options
+	config    types.ReviewConfig
+}
+
+// NewReviewer creates a diff reviewer
+func NewReviewer(completer Completer) *Reviewer {
+	opts := llm.DefaultOptions()
+	opts.System = "You are an expert code reviewer applying ISO/IEC 25010 quality standards."
+
+	return &Reviewer{
+		completer: completer,
+		options:   opts,
+	}
+}
+
+// WithOptions overrides the LLM options used for the review
+func (r *Reviewer) WithOptions(opts llm.Options) *Reviewer {
+	r.options = opts
+	return r
+}
+
+// WithConfig sets the review settings applied to the model's findings
+func (r *Reviewer) WithConfig(cfg types.ReviewConfig) *Reviewer {
+	r.config = cfg
+	return r
+}
+
+// Review asks the LLM to review the changes in diff
+func (r *Reviewer) Review(ctx context.Context, diff *types.Diff) (*types.ReviewResult, error) {
+	if r.completer == nil {
+		return nil, fmt.Errorf("review requires an LLM orchestrator")
+	}
+
+	diff = IgnorePaths(diff, r.config.IgnorePaths)
+	if len(diff.Files) == 0 {
+		return noChangesResult(), nil
+	}
+	if !HasReviewableChanges(diff) {
+		return &types.ReviewResult{
+			Issues:  []types.ReviewIssue{},
+			Summary: "No reviewable changes: the diff only deletes lines or changes binary files.",
+		}, nil
+	}
+
+	opts := r.options
+	opts.System = SystemPrompt(opts.System, r.config.Locale)
+
+	resp, err := r.completer.Complete(ctx, buildDiffPrompt(diff), opts)
+	if err != nil {
+		return nil, fmt.Errorf("review request failed: %w", err)
+	}
+
+	result, err := ParseOrRepair(ctx, r.completer, resp.Text, opts)
+	if err != nil {
+		return nil, err
+	}
+	result.Issues = FilterIssues(result.Issues, r.config)
+	LimitIssues(result, r.config.MaxComments)
+	return result, nil
+}
+
+// SystemPrompt appends the instruction for locale to system
+func SystemPrompt(system, locale string) string {
+	if instruction := localeInstruction(locale); instruction != "" {
+		return strings.TrimSpace(system + "\n\n" + instruction)
+	}
+	return system
+}
+
+// FilterIssues drops low-confidence, ignored and duplicate issues as cfg asks
+func FilterIssues(issues []types.ReviewIssue, cfg types.ReviewConfig) []types.ReviewIssue {
+	issues = FilterByConfidence(issues, cfg.MinConfidence)
+	issues = withoutIgnoredFiles(issues, cfg.IgnorePaths)
+
+	window := cfg.DedupLines
+	if window == 0 {
+		window = DefaultDedupLines
+	}
+	return Deduplicate(issues, window)
+}
+
+// buildDiffPrompt renders the review prompt for the changed files in diff
+func buildDiffPrompt(diff *types.Diff) string {
+	var b strings.Builder
+
+	b.WriteString("Review the following changes for quality, security, performance, and maintainability issues.\n")
+	b.WriteString("Only comment on added or modified lines, using line numbers from the new version of each file.\n\n")
+
+	for _, f := range diff.Files {
+		lang := f.Lang
+		if lang == "" {
+			lang = "unknown"
+		}
+		fmt.Fprintf(&b, "## File: %s (%s)\n\n```diff\n", f.Path, lang)
+		for _, h := range f.Hunks {
+			fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", h.OldStart, h.OldLines, h.NewStart, h.NewLines)
+			for _, line := range h.Lines {
+				b.WriteString(line)
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString("```\n\n")
+	}
+
+	b.WriteString("Respond with JSON only, using this structure:\n\n")
+	b.WriteString(types.ReviewResultSchema)
+	b.WriteString("\n\nScores range from 0 to 10. Confidence ranges from 0 to 1. Use the file paths exactly as given.\n")
+
+	return b.String()
+}
+
+// HasReviewableChanges reports whether any file in diff adds lines the
+// model could comment on. Deletions and binary changes have none.
+func HasReviewableChanges(diff *types.Diff) bool {
+	for _, f := range diff.Files {
+		for _, h := range f.Hunks {
+			for _, line := range h.Lines {
+				if strings.HasPrefix(line, "+") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// FilterByConfidence drops issues whose confidence is below min. Issues
+// without a confidence are kept.
+func FilterByConfidence(issues []types.ReviewIssue, min float64) []types.ReviewIssue {
+	kept := []types.ReviewIssue{}
+	for _, issue := range issues {
+		if issue.ConfidenceScore() >= min {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// withoutIgnoredFiles drops issues the model raised on ignored files anyway
+func withoutIgnoredFiles(issues []types.ReviewIssue, patterns []string) []types.ReviewIssue {
+	if len(patterns) == 0 {
+		return issues
+	}
+
+	kept := []types.ReviewIssue{}
+	for _, issue := range issues {
+		if !MatchAny(patterns, issue.File) {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// GatedIssues returns the issues at or above min severity
+func GatedIssues(issues []types.ReviewIssue, min types.Severity) []types.ReviewIssue {
+	var gated []types.ReviewIssue
+	for _, issue := range issues {
+		if issue.Severity.AtLeast(min) {
+			gated = append(gated, issue)
+		}
+	}
+	return gated
+}
@@ -0,0 +1,50 @@
+package review
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+func TestReviewer_MinConfidence(t *testing.T) {
+	diff, err := ParseDiff(stagedDiff)
+	if err != nil {
+		t.Fatalf("ParseDiff failed: %v", err)
+	}
+
+	provider := &mockProvider{text: `{"issues":[` +
+		`{"file":"main.go","line":4,"severity":"error","rule_id":"a","message":"sure","confidence":0.9},` +
+		`{"file":"main.go","line":5,"severity":"warning","rule_id":"b","message":"guess","confidence":0.3},` +
+		`{"file":"main.go","line":6,"severity":"info","rule_id":"c","message":"unstated"},` +
+		`{"file":"main.go","line":7,"severity":"info","rule_id":"d","message":"borderline","confidence":0.5}` +
+		`]}`}
+
+	reviewer := NewReviewer(newTestOrchestrator(provider)).
+		WithConfig(types.ReviewConfig{MinConfidence: 0.5})
+
+	result, err := reviewer.Review(context.Background(), diff)
+	if err != nil {
+		t.Fatalf("Review failed: %v", err)
+	}
+
+	var rules []string
+	for _, issue := range result.Issues {
+		rules = append(rules, issue.RuleID)
+	}
+	if len(rules) != 3 || rules[0] != "a" || rules[1] != "c" || rules[2] != "d" {
+		t.Errorf("Expected issues a, c and d to be kept, got %v", rules)
+	}
+}
+
+func TestFilterByConfidence_ZeroKeepsEverything(t *testing.T) {
+	low := 0.0
+	issues := []types.ReviewIssue{{RuleID: "a", Confidence: &low}, {RuleID: "b"}}
+
+	if got := FilterByConfidence(issues, 0); len(got) != 2 {
+		t.Errorf("A zero threshold should keep every issue, got %d", len(got))
+	}
+	if got := FilterByConfidence(issues, 1); len(got) != 1 || got[0].RuleID != "b" {
+		t.Errorf("Issues without a confidence should count as 1, got %+v", got)
+	}
+}
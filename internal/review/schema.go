@@ -0,0 +1,202 @@
+package review
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// KindReview is the prompt kind for code reviews, including the scores the
+// scanner aggregates
+const KindReview = "review"
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// ErrInvalidResponse indicates model output that does not match the schema
+// of its prompt kind
+var ErrInvalidResponse = errors.New("invalid response")
+
+// ErrUnknownKind indicates a prompt kind without a response schema
+var ErrUnknownKind = errors.New("unknown prompt kind")
+
+// FieldError is a single schema violation at a JSON path such as
+// "issues[2].line"
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) String() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ResponseError lists every schema violation found in a response
+type ResponseError struct {
+	Kind   string
+	Fields []FieldError
+}
+
+func (e *ResponseError) Error() string {
+	problems := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		problems[i] = f.String()
+	}
+	return fmt.Sprintf("%s: %s response: %s", ErrInvalidResponse, e.Kind, strings.Join(problems, "; "))
+}
+
+func (e *ResponseError) Unwrap() error {
+	return ErrInvalidResponse
+}
+
+// jsonSchema is the subset of JSON Schema used by the response contracts:
+// type, required, properties, items, minimum and maximum. Null is
+// accepted for optional properties, since models often emit it for omitted
+// fields.
+type jsonSchema struct {
+	Type       string                 `json:"type"`
+	Required   []string               `json:"required"`
+	Properties map[string]*jsonSchema `json:"properties"`
+	Items      *jsonSchema            `json:"items"`
+	Minimum    *float64               `json:"minimum"`
+	Maximum    *float64               `json:"maximum"`
+}
+
+// loadSchema reads the embedded schema for kind
+func loadSchema(kind string) (*jsonSchema, error) {
+	data, err := schemaFiles.ReadFile("schemas/" + kind + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+	}
+
+	var schema jsonSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid %s schema: %w", kind, err)
+	}
+	return &schema, nil
+}
+
+// ValidateResponse checks raw JSON against the response schema for kind.
+// Violations are returned together as a *ResponseError.
+func ValidateResponse(kind string, raw []byte) error {
+	schema, err := loadSchema(kind)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return &ResponseError{Kind: kind, Fields: []FieldError{{Message: fmt.Sprintf("malformed JSON: %v", err)}}}
+	}
+
+	var fields []FieldError
+	schema.validate("", value, &fields)
+	if len(fields) > 0 {
+		return &ResponseError{Kind: kind, Fields: fields}
+	}
+	return nil
+}
+
+// validate appends every violation of s by value, found at path, to fields
+func (s *jsonSchema) validate(path string, value interface{}, fields *[]FieldError) {
+	fail := func(format string, args ...interface{}) {
+		*fields = append(*fields, FieldError{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if got := jsonType(value); !typeMatches(s.Type, value) {
+		fail("expected %s, got %s", s.Type, got)
+		return
+	}
+
+	switch v := value.(type) {
+	case json.Number:
+		n, _ := v.Float64()
+		if s.Minimum != nil && n < *s.Minimum {
+			fail("must be at least %g, got %s", *s.Minimum, v)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			fail("must be at most %g, got %s", *s.Maximum, v)
+		}
+
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if field, ok := v[name]; !ok || field == nil {
+				*fields = append(*fields, FieldError{Field: joinPath(path, name), Message: "is required"})
+			}
+		}
+
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if field, ok := v[name]; ok && field != nil {
+				s.Properties[name].validate(joinPath(path, name), field, fields)
+			}
+		}
+
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, fields)
+			}
+		}
+	}
+}
+
+// typeMatches reports whether value is of the JSON Schema type want
+func typeMatches(want string, value interface{}) bool {
+	if want == "" {
+		return true
+	}
+	if want == "integer" {
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	}
+	return jsonType(value) == want
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// joinPath appends a property name to a JSON path
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,108 @@
+package review
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateResponse_Conforming(t *testing.T) {
+	tests := []struct {
+		kind string
+		raw  string
+	}{
+		{KindReview, `{"issues":[]}`},
+		{KindReview, `{"issues":[{"file":"a.go","line":3,"severity":"error","rule_id":"security/x","message":"bad","suggestion":null,"confidence":0.8}],` +
+			`"iso_scores":{"security":7,"maintainability":9},"summary":"ok","overall_score":7.5}`},
+		{KindReview, `{"issues":[],"iso_scores":{"functionality":8,"security":10,"portability":0}}`},
+		{KindReview, `{"issues":[],"iso_scores":{},"overall_score":6}`},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateResponse(tt.kind, []byte(tt.raw)); err != nil {
+			t.Errorf("ValidateResponse(%s, %s) failed: %v", tt.kind, tt.raw, err)
+		}
+	}
+}
+
+func TestValidateResponse_NonConforming(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   string
+		raw    string
+		fields []string
+	}{
+		{
+			name:   "missing issues",
+			kind:   KindReview,
+			raw:    `{"summary":"ok"}`,
+			fields: []string{"issues: is required"},
+		},
+		{
+			name: "bad issue fields",
+			kind: KindReview,
+			raw: `{"issues":[{"file":"a.go","line":3,"severity":"error","message":"ok"},` +
+				`{"file":"b.go","line":"12","severity":"warning","confidence":1.5}]}`,
+			fields: []string{
+				"issues[1].message: is required",
+				"issues[1].confidence: must be at most 1, got 1.5",
+				"issues[1].line: expected integer, got string",
+			},
+		},
+		{
+			name:   "issues not an array",
+			kind:   KindReview,
+			raw:    `{"issues":{"file":"a.go"}}`,
+			fields: []string{"issues: expected array, got object"},
+		},
+		{
+			name: "scores out of range",
+			kind: KindReview,
+			raw:  `{"issues":[],"iso_scores":{"security":11,"usability":7.5,"efficiency":-1},"overall_score":"high"}`,
+			fields: []string{
+				"iso_scores.efficiency: must be at least 0, got -1",
+				"iso_scores.security: must be at most 10, got 11",
+				"iso_scores.usability: expected integer, got number",
+				"overall_score: expected number, got string",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResponse(tt.kind, []byte(tt.raw))
+			if !errors.Is(err, ErrInvalidResponse) {
+				t.Fatalf("Expected ErrInvalidResponse, got %v", err)
+			}
+
+			var respErr *ResponseError
+			if !errors.As(err, &respErr) {
+				t.Fatalf("Expected *ResponseError, got %T", err)
+			}
+
+			var got []string
+			for _, f := range respErr.Fields {
+				got = append(got, f.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.fields, "\n") {
+				t.Errorf("Field errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.fields, "\n"))
+			}
+		})
+	}
+}
+
+func TestValidateResponse_UnknownKind(t *testing.T) {
+	if err := ValidateResponse("haiku", []byte(`{}`)); !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("Expected ErrUnknownKind, got %v", err)
+	}
+}
+
+func TestParseResult_RejectsNonConforming(t *testing.T) {
+	_, err := ParseResult(`{"issues":[{"file":"a.go","line":"three","severity":"error","message":"bad"}]}`)
+	if !errors.Is(err, ErrInvalidResponse) {
+		t.Fatalf("Expected ErrInvalidResponse, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "issues[0].line: expected integer, got string") {
+		t.Errorf("Error should name the offending field, got %v", err)
+	}
+}
@@ -0,0 +1,420 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Mpaape/AurumCode/internal/documentation/extractors"
+	"github.com/Mpaape/AurumCode/internal/documentation/incremental"
+	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/internal/review"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+const (
+	defaultBatchSize     = 5
+	defaultMaxBatchBytes = 48 * 1024
+	defaultMaxFileBytes  = 32 * 1024
+)
+
+// Completer is the subset of the LLM orchestrator used by the scanner
+type Completer interface {
+	Complete(ctx context.Context, prompt string, opts llm.Options) (llm.Response, error)
+}
+
+// SourceFile is a file queued for review
+type SourceFile struct {
+	Path     string              // Path relative to the scanned root
+	Language extractors.Language // Detected language
+	Content  string
+	Hash     string // SHA-256 of Content
+}
+
+// Result is the aggregated outcome of a repository scan
+type Result struct {
+	// Review aggregates issues, ISO scores, and summary across all batches
+	Review types.ReviewResult `json:"review"`
+
+	// FilesReviewed lists files that were sent to the LLM
+	FilesReviewed []string `json:"files_reviewed"`
+
+	// FilesCached lists unchanged files whose previous review was reused
+	FilesCached []string `json:"files_cached,omitempty"`
+
+	// FilesSkipped lists files that were not reviewed (too large, budget exhausted)
+	FilesSkipped []string `json:"files_skipped,omitempty"`
+
+	// Batches is the number of LLM calls made
+	Batches int `json:"batches"`
+
+	// BudgetExhausted is true if the scan stopped early because the budget ran out
+	BudgetExhausted bool `json:"budget_exhausted"`
+
+	// Errors holds non-fatal batch failures
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Scanner reviews a whole repository in budget-aware batches
+type Scanner struct {
+	completer     Completer
+	detector      *extractors.Detector
+	batchSize     int
+	maxBatchBytes int
+	maxFileBytes  int64
+	options       llm.Options
+	config        types.ReviewConfig
+	statePath     string
+}
+
+// NewScanner creates a new repository scanner
+func NewScanner(completer Completer) *Scanner {
+	opts := llm.DefaultOptions()
+	opts.System = "You are an expert code reviewer applying ISO/IEC 25010 quality standards."
+
+	return &Scanner{
+		completer:     completer,
+		detector:      extractors.NewDetector(),
+		batchSize:     defaultBatchSize,
+		maxBatchBytes: defaultMaxBatchBytes,
+		maxFileBytes:  defaultMaxFileBytes,
+		options:       opts,
+	}
+}
+
+// WithBatchSize sets the maximum number of files reviewed per LLM call
+func (s *Scanner) WithBatchSize(n int) *Scanner {
+	if n > 0 {
+		s.batchSize = n
+	}
+	return s
+}
+
+// WithMaxFileBytes sets the size above which files are skipped
+func (s *Scanner) WithMaxFileBytes(n int64) *Scanner {
+	if n > 0 {
+		s.maxFileBytes = n
+	}
+	return s
+}
+
+// WithExcludedDirs adds directories left out of the scan
+func (s *Scanner) WithExcludedDirs(dirs ...string) *Scanner {
+	s.detector.WithExcludedDirs(dirs...)
+	return s
+}
+
+// WithExtensionOverrides maps extra file extensions to languages, as in the
+// documentation config
+func (s *Scanner) WithExtensionOverrides(overrides map[string]string) *Scanner {
+	s.detector.WithExtensions(extractors.ExtensionOverrides(overrides))
+	return s
+}
+
+// WithOptions overrides the LLM options used for each batch
+func (s *Scanner) WithOptions(opts llm.Options) *Scanner {
+	s.options = opts
+	return s
+}
+
+// WithConfig sets the review settings applied to files and findings
+func (s *Scanner) WithConfig(cfg types.ReviewConfig) *Scanner {
+	s.config = cfg
+	return s
+}
+
+// WithStatePath enables incremental scanning, persisting per-file results at path.
+// Files whose content hash matches the saved state are not sent to the LLM again.
+func (s *Scanner) WithStatePath(path string) *Scanner {
+	s.statePath = path
+	return s
+}
+
+// Scan walks rootDir, reviews every detected source file, and aggregates the results
+func (s *Scanner) Scan(ctx context.Context, rootDir string) (*Result, error) {
+	if s.completer == nil {
+		return nil, fmt.Errorf("scan requires an LLM orchestrator")
+	}
+
+	files, skipped, err := s.collectFiles(ctx, rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	state := NewState()
+	if s.statePath != "" {
+		if state, err = LoadState(s.statePath); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &Result{
+		FilesReviewed: []string{},
+		FilesSkipped:  skipped,
+	}
+
+	opts := s.options
+	opts.System = review.SystemPrompt(opts.System, s.config.Locale)
+
+	// Split into files needing review and unchanged files with cached results.
+	// Scores are kept per file so cached and fresh files weigh the same.
+	var pending []SourceFile
+	var reviews []*types.ReviewResult
+	var scored []FileState
+	present := make(map[string]bool)
+
+	for _, f := range files {
+		present[f.Path] = true
+		if cached, ok := state.Files[f.Path]; ok && s.statePath != "" && cached.Hash == f.Hash {
+			result.FilesCached = append(result.FilesCached, f.Path)
+			reviews = append(reviews, &types.ReviewResult{Issues: cached.Issues})
+			scored = append(scored, cached)
+			continue
+		}
+		pending = append(pending, f)
+	}
+
+	if len(result.FilesCached) > 0 {
+		log.Printf("[Scan] %d unchanged files reused from previous scan", len(result.FilesCached))
+	}
+
+	batches := s.batch(pending)
+
+	for i, batch := range batches {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		log.Printf("[Scan] Reviewing batch %d/%d (%d files)", i+1, len(batches), len(batch))
+
+		resp, err := s.completer.Complete(ctx, buildPrompt(batch), opts)
+		if err != nil {
+			if errors.Is(err, llm.ErrBudgetExceeded) {
+				log.Printf("[Scan] Budget exhausted after %d batches, stopping", result.Batches)
+				result.BudgetExhausted = true
+				for _, rest := range batches[i:] {
+					for _, f := range rest {
+						result.FilesSkipped = append(result.FilesSkipped, f.Path)
+					}
+				}
+				break
+			}
+			result.Errors = append(result.Errors, fmt.Sprintf("batch %d: %v", i+1, err))
+			continue
+		}
+
+		result.Batches++
+
+		parsed, err := review.ParseOrRepair(ctx, s.completer, resp.Text, opts)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("batch %d: %v", i+1, err))
+			continue
+		}
+
+		for _, f := range batch {
+			result.FilesReviewed = append(result.FilesReviewed, f.Path)
+			fileState := FileState{
+				Hash:         f.Hash,
+				Issues:       issuesForFile(parsed.Issues, f.Path),
+				ISOScores:    parsed.ISOScores,
+				OverallScore: parsed.OverallScore,
+			}
+			state.Files[f.Path] = fileState
+			scored = append(scored, fileState)
+		}
+		reviews = append(reviews, parsed)
+	}
+
+	result.Review = aggregate(reviews, scored, s.config)
+	review.LimitIssues(&result.Review, s.config.MaxComments)
+
+	if s.statePath != "" {
+		// Drop files that no longer exist
+		for path := range state.Files {
+			if !present[path] {
+				delete(state.Files, path)
+			}
+		}
+		state.LastScan = time.Now()
+		if err := state.Save(s.statePath); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// issuesForFile returns the issues that belong to path
+func issuesForFile(issues []types.ReviewIssue, path string) []types.ReviewIssue {
+	matched := []types.ReviewIssue{}
+	for _, issue := range issues {
+		if filepath.ToSlash(issue.File) == path {
+			matched = append(matched, issue)
+		}
+	}
+	return matched
+}
+
+// collectFiles detects source files under rootDir and reads their content
+func (s *Scanner) collectFiles(ctx context.Context, rootDir string) ([]SourceFile, []string, error) {
+	detection, err := s.detector.Detect(ctx, rootDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to detect source files: %w", err)
+	}
+
+	langs := detection.GetLanguages()
+	sort.Slice(langs, func(i, j int) bool { return langs[i] < langs[j] })
+
+	var files []SourceFile
+	var skipped []string
+
+	for _, lang := range langs {
+		stats := detection.Languages[lang]
+		paths := append([]string(nil), stats.Files...)
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			rel, err := filepath.Rel(rootDir, path)
+			if err != nil {
+				rel = path
+			}
+			rel = filepath.ToSlash(rel)
+			if review.MatchAny(s.config.IgnorePaths, rel) {
+				continue
+			}
+
+			info, err := os.Stat(path)
+			if err != nil || info.Size() > s.maxFileBytes {
+				skipped = append(skipped, rel)
+				continue
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				skipped = append(skipped, rel)
+				continue
+			}
+
+			files = append(files, SourceFile{
+				Path:     rel,
+				Language: lang,
+				Content:  string(content),
+				Hash:     incremental.HashContent(content),
+			})
+		}
+	}
+
+	return files, skipped, nil
+}
+
+// batch groups files by count and total size so each prompt stays bounded
+func (s *Scanner) batch(files []SourceFile) [][]SourceFile {
+	var batches [][]SourceFile
+	var current []SourceFile
+	size := 0
+
+	for _, f := range files {
+		if len(current) > 0 && (len(current) >= s.batchSize || size+len(f.Content) > s.maxBatchBytes) {
+			batches = append(batches, current)
+			current = nil
+			size = 0
+		}
+		current = append(current, f)
+		size += len(f.Content)
+	}
+
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+
+	return batches
+}
+
+// buildPrompt renders the review prompt for a batch of files
+func buildPrompt(files []SourceFile) string {
+	var b strings.Builder
+
+	b.WriteString("Review the following source files for quality, security, performance, and maintainability issues.\n\n")
+
+	for _, f := range files {
+		fmt.Fprintf(&b, "## File: %s (%s)\n\n```%s\n%s\n```\n\n", f.Path, f.Language, f.Language, f.Content)
+	}
+
+	b.WriteString("Respond with JSON only, using this structure:\n\n")
+	b.WriteString(types.ReviewResultSchema)
+	b.WriteString("\n\nScores range from 0 to 10. Use the file paths exactly as given.\n")
+
+	return b.String()
+}
+
+// aggregate merges the issues and summaries of reviews into a single result,
+// averaging the scores of files so each file counts once regardless of its batch.
+// Issues are filtered as cfg asks.
+func aggregate(reviews []*types.ReviewResult, files []FileState, cfg types.ReviewConfig) types.ReviewResult {
+	out := types.ReviewResult{
+		Issues: []types.ReviewIssue{},
+	}
+
+	var scoreSum types.ISOScores
+	scored := 0
+	overallSum := 0.0
+	overallCount := 0
+
+	for _, r := range reviews {
+		out.Issues = append(out.Issues, r.Issues...)
+	}
+	out.Issues = review.FilterIssues(out.Issues, cfg)
+
+	for _, r := range files {
+		if r.ISOScores != nil {
+			scored++
+			scoreSum.Functionality += r.ISOScores.Functionality
+			scoreSum.Reliability += r.ISOScores.Reliability
+			scoreSum.Usability += r.ISOScores.Usability
+			scoreSum.Efficiency += r.ISOScores.Efficiency
+			scoreSum.Maintainability += r.ISOScores.Maintainability
+			scoreSum.Portability += r.ISOScores.Portability
+			scoreSum.Security += r.ISOScores.Security
+			scoreSum.Compatibility += r.ISOScores.Compatibility
+		}
+
+		if r.OverallScore > 0 {
+			overallSum += r.OverallScore
+			overallCount++
+		}
+	}
+
+	if scored > 0 {
+		out.ISOScores = &types.ISOScores{
+			Functionality:   scoreSum.Functionality / scored,
+			Reliability:     scoreSum.Reliability / scored,
+			Usability:       scoreSum.Usability / scored,
+			Efficiency:      scoreSum.Efficiency / scored,
+			Maintainability: scoreSum.Maintainability / scored,
+			Portability:     scoreSum.Portability / scored,
+			Security:        scoreSum.Security / scored,
+			Compatibility:   scoreSum.Compatibility / scored,
+		}
+	}
+
+	if overallCount > 0 {
+		out.OverallScore = overallSum / float64(overallCount)
+	}
+
+	out.Summary = fmt.Sprintf("Scanned %d files: %d issues found.", len(files), len(out.Issues))
+	for _, r := range reviews {
+		if r.Summary != "" {
+			out.Summary += "\n\n" + r.Summary
+		}
+	}
+
+	return out
+}
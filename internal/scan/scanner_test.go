@@ -0,0 +1,508 @@
+package scan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mpaape/AurumCode/internal/llm"
+	"github.com/Mpaape/AurumCode/internal/llm/cost"
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// mockCompleter returns canned responses and records prompts
+type mockCompleter struct {
+	responses []string
+	err       error
+	prompts   []string
+	options   []llm.Options
+}
+
+func (m *mockCompleter) Complete(ctx context.Context, prompt string, opts llm.Options) (llm.Response, error) {
+	m.prompts = append(m.prompts, prompt)
+	m.options = append(m.options, opts)
+	if m.err != nil {
+		return llm.Response{}, m.err
+	}
+	i := len(m.prompts) - 1
+	if i >= len(m.responses) {
+		i = len(m.responses) - 1
+	}
+	return llm.Response{Text: m.responses[i]}, nil
+}
+
+// mockProvider is a minimal llm.Provider for orchestrator-backed tests
+type mockProvider struct {
+	text  string
+	model string // Reported model; defaults to test-model
+	calls int
+}
+
+func (m *mockProvider) Complete(prompt string, opts llm.Options) (llm.Response, error) {
+	m.calls++
+	model := m.model
+	if model == "" {
+		model = "test-model"
+	}
+	return llm.Response{Text: m.text, TokensIn: 1000, TokensOut: 1000, Model: model}, nil
+}
+
+func (m *mockProvider) Tokens(input string) (int, error) { return len(input) / 4, nil }
+
+func (m *mockProvider) Name() string { return "mock" }
+
+func createRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for path, content := range files {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestScanner_AggregatesBatches(t *testing.T) {
+	dir := createRepo(t, map[string]string{
+		"main.go":           "package main\n\nfunc main() {}\n",
+		"util/util.go":      "package util\n",
+		"scripts/tool.py":   "print('hi')\n",
+		"node_modules/x.js": "ignored()\n",
+		"README.md":         "# docs\n",
+	})
+
+	completer := &mockCompleter{
+		responses: []string{
+			`{"issues":[{"file":"main.go","line":3,"severity":"warning","rule_id":"quality/empty","message":"empty main"}],"iso_scores":{"security":8,"maintainability":6},"summary":"batch one","overall_score":7}`,
+			"```json\n{\"issues\":[{\"file\":\"scripts/tool.py\",\"line\":1,\"severity\":\"info\",\"rule_id\":\"style/print\",\"message\":\"use logging\"}],\"iso_scores\":{\"security\":5,\"maintainability\":9},\"summary\":\"batch two\",\"overall_score\":10}\n```",
+		},
+	}
+
+	result, err := NewScanner(completer).WithBatchSize(2).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(completer.prompts) != 2 {
+		t.Fatalf("expected 2 batches, got %d", len(completer.prompts))
+	}
+
+	if len(result.FilesReviewed) != 3 {
+		t.Errorf("expected 3 files reviewed, got %v", result.FilesReviewed)
+	}
+
+	for _, f := range result.FilesReviewed {
+		if strings.Contains(f, "node_modules") {
+			t.Errorf("excluded file was reviewed: %s", f)
+		}
+	}
+
+	if len(result.Review.Issues) != 2 {
+		t.Errorf("expected 2 aggregated issues, got %d", len(result.Review.Issues))
+	}
+
+	if result.Review.ISOScores == nil {
+		t.Fatal("expected aggregated ISO scores")
+	}
+	if result.Review.ISOScores.Security != 7 || result.Review.ISOScores.Maintainability != 7 {
+		t.Errorf("expected scores averaged per file 7/7, got %+v", result.Review.ISOScores)
+	}
+
+	if result.Review.OverallScore != 8 {
+		t.Errorf("expected overall score 8, got %f", result.Review.OverallScore)
+	}
+
+	if !strings.Contains(result.Review.Summary, "batch one") || !strings.Contains(result.Review.Summary, "batch two") {
+		t.Errorf("expected batch summaries in aggregate summary, got %q", result.Review.Summary)
+	}
+
+	if result.BudgetExhausted {
+		t.Error("did not expect budget exhaustion")
+	}
+}
+
+func TestScanner_StopsWhenBudgetExhausted(t *testing.T) {
+	dir := createRepo(t, map[string]string{
+		"a.go": "package a\n",
+		"b.go": "package a\n",
+		"c.go": "package a\n",
+	})
+
+	provider := &mockProvider{text: `{"issues":[],"summary":"ok"}`}
+
+	// Each call costs $0.02; the per-run budget only covers one call
+	tracker := cost.NewTracker(0.03, 100.0, map[string]cost.PriceMap{
+		"test-model": {InputPer1K: 0.01, OutputPer1K: 0.01},
+	})
+	orch := llm.NewOrchestrator(provider, nil, tracker)
+
+	opts := llm.DefaultOptions()
+	opts.ModelKey = "test-model"
+	opts.MaxTokens = 1000
+
+	result, err := NewScanner(orch).WithBatchSize(1).WithOptions(opts).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Scan should stop gracefully, got error: %v", err)
+	}
+
+	if !result.BudgetExhausted {
+		t.Error("expected budget exhaustion to be reported")
+	}
+
+	if provider.calls != 1 {
+		t.Errorf("expected 1 provider call before budget ran out, got %d", provider.calls)
+	}
+
+	if len(result.FilesReviewed) != 1 {
+		t.Errorf("expected 1 file reviewed, got %v", result.FilesReviewed)
+	}
+
+	if len(result.FilesSkipped) != 2 {
+		t.Errorf("expected 2 files skipped, got %v", result.FilesSkipped)
+	}
+}
+
+func TestScanner_StopsWhenBudgetExhaustedWithDefaultOptions(t *testing.T) {
+	dir := createRepo(t, map[string]string{
+		"a.go": "package a\n",
+		"b.go": "package a\n",
+		"c.go": "package a\n",
+	})
+
+	// The API reports a dated snapshot, as real providers do
+	provider := &mockProvider{text: `{"issues":[],"summary":"ok"}`, model: "test-model-2024-07-18"}
+
+	// Calls are planned at $0.04 (4000 max tokens) and cost $0.02, so the
+	// budget covers one call
+	tracker := cost.NewTracker(0.05, 100.0, map[string]cost.PriceMap{
+		"test-model": {InputPer1K: 0.01, OutputPer1K: 0.01},
+	})
+	orch := llm.NewOrchestrator(provider, nil, tracker).WithModelKey("test-model")
+
+	// No ModelKey in the options, like the CLI commands
+	result, err := NewScanner(orch).WithBatchSize(1).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Scan should stop gracefully, got error: %v", err)
+	}
+
+	if !result.BudgetExhausted {
+		t.Error("expected budget exhaustion to be reported")
+	}
+	if provider.calls != 1 {
+		t.Errorf("expected 1 provider call before budget ran out, got %d", provider.calls)
+	}
+	if len(result.FilesSkipped) != 2 {
+		t.Errorf("expected 2 files skipped, got %v", result.FilesSkipped)
+	}
+}
+
+func TestScanner_SkipsLargeFiles(t *testing.T) {
+	dir := createRepo(t, map[string]string{
+		"small.go": "package a\n",
+		"big.go":   "package a\n" + strings.Repeat("// filler\n", 100),
+	})
+
+	completer := &mockCompleter{responses: []string{`{"issues":[]}`}}
+
+	result, err := NewScanner(completer).WithMaxFileBytes(50).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(result.FilesSkipped) != 1 || result.FilesSkipped[0] != "big.go" {
+		t.Errorf("expected big.go to be skipped, got %v", result.FilesSkipped)
+	}
+}
+
+func TestScanner_DocumentationDetectorSettings(t *testing.T) {
+	dir := createRepo(t, map[string]string{
+		"main.go":            "package main\n",
+		"third_party/lib.go": "package lib\n",
+		"rules/check.dsl":    "print('rule')\n",
+	})
+
+	completer := &mockCompleter{responses: []string{`{"issues":[]}`}}
+
+	result, err := NewScanner(completer).
+		WithExcludedDirs("third_party").
+		WithExtensionOverrides(map[string]string{"DSL": "Python"}).
+		Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	reviewed := strings.Join(result.FilesReviewed, ",")
+	if strings.Contains(reviewed, "third_party") {
+		t.Errorf("excluded directory was scanned: %v", result.FilesReviewed)
+	}
+	if !strings.Contains(reviewed, "rules/check.dsl") {
+		t.Errorf("expected the overridden extension to be scanned, got %v", result.FilesReviewed)
+	}
+}
+
+func TestScanner_AppliesReviewConfig(t *testing.T) {
+	dir := createRepo(t, map[string]string{
+		"main.go":          "package main\n",
+		"gen/models.pb.go": "package gen\n",
+		"util/util.go":     "package util\n",
+	})
+
+	completer := &mockCompleter{responses: []string{
+		`{"issues":[` +
+			`{"file":"main.go","line":1,"severity":"error","rule_id":"a","message":"kept","confidence":0.9},` +
+			`{"file":"main.go","line":2,"severity":"warning","rule_id":"b","message":"unsure","confidence":0.2},` +
+			`{"file":"util/util.go","line":1,"severity":"info","rule_id":"c","message":"capped"}` +
+			`]}`,
+	}}
+
+	result, err := NewScanner(completer).
+		WithConfig(types.ReviewConfig{
+			MinConfidence: 0.5,
+			MaxComments:   1,
+			IgnorePaths:   []string{"*.pb.go"},
+			Locale:        "pt-BR",
+		}).
+		Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if strings.Contains(completer.prompts[0], "models.pb.go") {
+		t.Error("ignored file was sent for review")
+	}
+	if !strings.Contains(completer.options[0].System, "Respond in Portuguese") {
+		t.Errorf("System prompt missing locale instruction: %q", completer.options[0].System)
+	}
+
+	if len(result.Review.Issues) != 1 || result.Review.Issues[0].Message != "kept" {
+		t.Errorf("expected only the confident error to remain, got %+v", result.Review.Issues)
+	}
+	if !strings.Contains(result.Review.Summary, "+1 lower-severity finding omitted") {
+		t.Errorf("expected the capped finding in the summary, got %q", result.Review.Summary)
+	}
+}
+
+func TestScanner_RecordsBatchErrors(t *testing.T) {
+	dir := createRepo(t, map[string]string{"a.go": "package a\n"})
+
+	completer := &mockCompleter{err: fmt.Errorf("provider down")}
+
+	result, err := NewScanner(completer).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(result.Errors) != 1 {
+		t.Errorf("expected 1 batch error, got %v", result.Errors)
+	}
+
+	if result.BudgetExhausted {
+		t.Error("provider error should not be reported as budget exhaustion")
+	}
+}
+
+func TestScanner_RequiresCompleter(t *testing.T) {
+	_, err := NewScanner(nil).Scan(context.Background(), t.TempDir())
+	if err == nil {
+		t.Error("expected error without completer")
+	}
+	if errors.Is(err, llm.ErrBudgetExceeded) {
+		t.Error("unexpected budget error")
+	}
+}
+
+func TestScanner_IncrementalReviewsOnlyChangedFiles(t *testing.T) {
+	dir := createRepo(t, map[string]string{
+		"a.go": "package a\n",
+		"b.go": "package a\n\nfunc B() {}\n",
+		"c.go": "package a\n\nfunc C() {}\n",
+	})
+	statePath := filepath.Join(t.TempDir(), "scan.json")
+
+	completer := &mockCompleter{
+		responses: []string{
+			`{"issues":[{"file":"b.go","line":3,"severity":"warning","rule_id":"doc/missing","message":"undocumented B"}],"iso_scores":{"security":9},"summary":"first"}`,
+		},
+	}
+
+	first, err := NewScanner(completer).WithBatchSize(1).WithStatePath(statePath).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("first scan failed: %v", err)
+	}
+
+	if len(first.FilesReviewed) != 3 || len(first.FilesCached) != 0 {
+		t.Fatalf("first scan should review all files, got reviewed=%v cached=%v", first.FilesReviewed, first.FilesCached)
+	}
+
+	if _, err := os.Stat(statePath); err != nil {
+		t.Fatalf("state file not written: %v", err)
+	}
+
+	// Change one file and scan again
+	if err := os.WriteFile(filepath.Join(dir, "c.go"), []byte("package a\n\nfunc C() int { return 1 }\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	completer.prompts = nil
+	completer.responses = []string{`{"issues":[],"iso_scores":{"security":9},"summary":"second"}`}
+
+	second, err := NewScanner(completer).WithBatchSize(1).WithStatePath(statePath).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("second scan failed: %v", err)
+	}
+
+	if len(completer.prompts) != 1 {
+		t.Fatalf("expected exactly 1 LLM call, got %d", len(completer.prompts))
+	}
+	if !strings.Contains(completer.prompts[0], "c.go") {
+		t.Error("expected the changed file to be reviewed")
+	}
+
+	if len(second.FilesReviewed) != 1 || second.FilesReviewed[0] != "c.go" {
+		t.Errorf("expected only c.go reviewed, got %v", second.FilesReviewed)
+	}
+
+	if len(second.FilesCached) != 2 {
+		t.Errorf("expected 2 cached files, got %v", second.FilesCached)
+	}
+
+	// Cached issues for unchanged files are merged into the result
+	found := false
+	for _, issue := range second.Review.Issues {
+		if issue.File == "b.go" && issue.Message == "undocumented B" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected cached issue for b.go in merged result, got %+v", second.Review.Issues)
+	}
+}
+
+func TestScanner_RescanWithoutChangesKeepsScores(t *testing.T) {
+	dir := createRepo(t, map[string]string{
+		"a.go": "package a\n",
+		"b.go": "package a\n\nfunc B() {}\n",
+		"c.go": "package a\n\nfunc C() {}\n",
+	})
+	statePath := filepath.Join(t.TempDir(), "scan.json")
+
+	// Uneven batches: a.go and b.go share one review, c.go gets its own
+	completer := &mockCompleter{
+		responses: []string{
+			`{"issues":[],"iso_scores":{"security":9,"maintainability":3},"overall_score":6}`,
+			`{"issues":[],"iso_scores":{"security":3,"maintainability":9},"overall_score":9}`,
+		},
+	}
+
+	first, err := NewScanner(completer).WithBatchSize(2).WithStatePath(statePath).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("first scan failed: %v", err)
+	}
+
+	completer.prompts = nil
+	second, err := NewScanner(completer).WithBatchSize(2).WithStatePath(statePath).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("second scan failed: %v", err)
+	}
+
+	if len(completer.prompts) != 0 || len(second.FilesCached) != 3 {
+		t.Fatalf("expected every file from the cache, got %d calls and cached=%v", len(completer.prompts), second.FilesCached)
+	}
+
+	if first.Review.ISOScores == nil || second.Review.ISOScores == nil {
+		t.Fatal("expected aggregated ISO scores on both scans")
+	}
+	if *second.Review.ISOScores != *first.Review.ISOScores {
+		t.Errorf("expected identical ISO scores, got %+v then %+v", first.Review.ISOScores, second.Review.ISOScores)
+	}
+	if second.Review.OverallScore != first.Review.OverallScore {
+		t.Errorf("expected identical overall score, got %f then %f", first.Review.OverallScore, second.Review.OverallScore)
+	}
+}
+
+func TestScanner_IncrementalDropsDeletedFiles(t *testing.T) {
+	dir := createRepo(t, map[string]string{
+		"a.go": "package a\n",
+		"b.go": "package a\n\nfunc B() {}\n",
+	})
+	statePath := filepath.Join(t.TempDir(), "scan.json")
+
+	completer := &mockCompleter{responses: []string{`{"issues":[]}`}}
+
+	if _, err := NewScanner(completer).WithStatePath(statePath).Scan(context.Background(), dir); err != nil {
+		t.Fatalf("first scan failed: %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "b.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewScanner(completer).WithStatePath(statePath).Scan(context.Background(), dir); err != nil {
+		t.Fatalf("second scan failed: %v", err)
+	}
+
+	state, err := LoadState(statePath)
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+
+	if _, ok := state.Files["b.go"]; ok {
+		t.Error("deleted file should be removed from state")
+	}
+	if _, ok := state.Files["a.go"]; !ok {
+		t.Error("existing file should remain in state")
+	}
+}
+
+func TestScanner_RepairsInvalidBatch(t *testing.T) {
+	dir := createRepo(t, map[string]string{"a.go": "package a\n"})
+
+	completer := &mockCompleter{responses: []string{
+		`{"issues":"none"}`,
+		`{"issues":[{"file":"a.go","line":1,"severity":"info","rule_id":"doc/missing","message":"undocumented"}],"summary":"fixed"}`,
+	}}
+
+	result, err := NewScanner(completer).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(completer.prompts) != 2 {
+		t.Fatalf("expected one repair call, got %d calls", len(completer.prompts))
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("repaired batch should not be an error, got %v", result.Errors)
+	}
+	if len(result.Review.Issues) != 1 || result.Review.Issues[0].File != "a.go" {
+		t.Errorf("expected the repaired issue, got %+v", result.Review.Issues)
+	}
+}
+
+func TestScanner_RejectsOutOfRangeScores(t *testing.T) {
+	dir := createRepo(t, map[string]string{"a.go": "package a\n"})
+
+	completer := &mockCompleter{responses: []string{
+		`{"issues":[],"iso_scores":{"security":42},"overall_score":7}`,
+		`{"issues":[],"iso_scores":{"security":9},"overall_score":7}`,
+	}}
+
+	result, err := NewScanner(completer).Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(completer.prompts) != 2 || !strings.Contains(completer.prompts[1], "iso_scores.security") {
+		t.Fatalf("expected a repair call naming the bad score, got %d calls", len(completer.prompts))
+	}
+	if result.Review.ISOScores == nil || result.Review.ISOScores.Security != 9 {
+		t.Errorf("expected only the repaired score to be aggregated, got %+v", result.Review.ISOScores)
+	}
+}
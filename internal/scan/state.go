@@ -0,0 +1,83 @@
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Mpaape/AurumCode/pkg/types"
+)
+
+// FileState is the cached review of a single file
+type FileState struct {
+	// Hash is the SHA-256 of the file content that was reviewed
+	Hash string `json:"hash"`
+
+	// Issues found in this file
+	Issues []types.ReviewIssue `json:"issues"`
+
+	// ISOScores from the batch the file was reviewed in
+	ISOScores *types.ISOScores `json:"iso_scores,omitempty"`
+
+	// OverallScore from the batch the file was reviewed in
+	OverallScore float64 `json:"overall_score,omitempty"`
+}
+
+// State persists per-file review results between scans
+type State struct {
+	// LastScan is the timestamp of the last scan that updated the state
+	LastScan time.Time `json:"last_scan"`
+
+	// Files maps relative file paths to their cached review
+	Files map[string]FileState `json:"files"`
+}
+
+// NewState creates an empty scan state
+func NewState() *State {
+	return &State{
+		Files: make(map[string]FileState),
+	}
+}
+
+// LoadState reads scan state from path, returning an empty state if it doesn't exist
+func LoadState(path string) (*State, error) {
+	state := NewState()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read scan state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scan state: %w", err)
+	}
+
+	if state.Files == nil {
+		state.Files = make(map[string]FileState)
+	}
+
+	return state, nil
+}
+
+// Save persists the state to a JSON file
+func (s *State) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create scan state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal scan state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write scan state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Tracer starts OpenTelemetry-style spans threaded through a context.
+// Adapters for a tracing backend implement it; the default does nothing.
+type Tracer interface {
+	// Start begins a span named name, returning a context carrying it
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a timed unit of work
+type Span interface {
+	// End finishes the span; a non-nil err marks it failed
+	End(err error)
+}
+
+type tracerKey struct{}
+
+// WithTracer returns a context whose spans are started by tracer
+func WithTracer(ctx context.Context, tracer Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// FromContext returns the tracer carried by ctx, or a no-op tracer
+func FromContext(ctx context.Context) Tracer {
+	if tracer, ok := ctx.Value(tracerKey{}).(Tracer); ok && tracer != nil {
+		return tracer
+	}
+	return Noop
+}
+
+// Start begins a span with the tracer carried by ctx
+func Start(ctx context.Context, name string) (context.Context, Span) {
+	return FromContext(ctx).Start(ctx, name)
+}
+
+// Noop is the default tracer; its spans record nothing
+var Noop Tracer = noopTracer{}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) End(error) {}
+
+// SpanRecord is a finished span captured by a Recorder
+type SpanRecord struct {
+	Name     string
+	Parent   string // Name of the enclosing span, "" for a root span
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+}
+
+// Recorder is an in-memory Tracer that keeps every finished span, for tests
+// and debugging. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	spans []SpanRecord
+}
+
+// NewRecorder creates an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+type activeSpanKey struct{}
+
+// Start begins a span whose parent is the span active in ctx
+func (r *Recorder) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &recordedSpan{recorder: r, record: SpanRecord{Name: name, Start: time.Now()}}
+	if parent, ok := ctx.Value(activeSpanKey{}).(*recordedSpan); ok {
+		span.record.Parent = parent.record.Name
+	}
+	return context.WithValue(ctx, activeSpanKey{}, span), span
+}
+
+// Spans returns the finished spans in the order they ended
+func (r *Recorder) Spans() []SpanRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]SpanRecord(nil), r.spans...)
+}
+
+// Names returns the names of the finished spans in the order they ended
+func (r *Recorder) Names() []string {
+	spans := r.Spans()
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = span.Name
+	}
+	return names
+}
+
+type recordedSpan struct {
+	recorder *Recorder
+	record   SpanRecord
+	once     sync.Once
+}
+
+func (s *recordedSpan) End(err error) {
+	s.once.Do(func() {
+		s.record.Duration = time.Since(s.record.Start)
+		s.record.Err = err
+
+		s.recorder.mu.Lock()
+		s.recorder.spans = append(s.recorder.spans, s.record)
+		s.recorder.mu.Unlock()
+	})
+}
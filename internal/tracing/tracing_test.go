@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFromContext_DefaultsToNoop(t *testing.T) {
+	if FromContext(context.Background()) != Noop {
+		t.Error("Expected the no-op tracer without one in the context")
+	}
+
+	ctx, span := Start(context.Background(), "work")
+	if ctx == nil {
+		t.Fatal("Start should return a context")
+	}
+	span.End(errors.New("ignored"))
+}
+
+func TestRecorder_TracksParentsAndErrors(t *testing.T) {
+	recorder := NewRecorder()
+	ctx := WithTracer(context.Background(), recorder)
+
+	ctx, root := Start(ctx, "root")
+	_, child := Start(ctx, "child")
+
+	failure := errors.New("boom")
+	child.End(failure)
+	child.End(nil)
+	root.End(nil)
+
+	spans := recorder.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+
+	if spans[0].Name != "child" || spans[0].Parent != "root" {
+		t.Errorf("Unexpected child span: %+v", spans[0])
+	}
+	if !errors.Is(spans[0].Err, failure) {
+		t.Errorf("Expected child error %v, got %v", failure, spans[0].Err)
+	}
+
+	if spans[1].Name != "root" || spans[1].Parent != "" || spans[1].Err != nil {
+		t.Errorf("Unexpected root span: %+v", spans[1])
+	}
+}
@@ -1,5 +1,10 @@
 package types
 
+import (
+	"fmt"
+	"time"
+)
+
 // Config represents the complete AurumCode configuration
 type Config struct {
 	Version       string                 `json:"version" yaml:"version"`
@@ -9,6 +14,8 @@ type Config struct {
 	Outputs       OutputConfig           `json:"outputs" yaml:"outputs"`
 	Features      FeaturesConfig         `json:"features" yaml:"features"`
 	Documentation DocumentationConfig    `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+	Review        ReviewConfig           `json:"review,omitempty" yaml:"review,omitempty"`
+	PlainText     bool                   `json:"plain_text,omitempty" yaml:"plain_text,omitempty"` // Replace emojis in reports and CLI output with ASCII
 }
 
 // LLMConfig configures the LLM provider and parameters
@@ -35,6 +42,31 @@ type FeaturesConfig struct {
 	QATesting        bool `json:"qa_testing" yaml:"qa_testing"`
 }
 
+// ReviewConfig tunes which code review findings are reported
+type ReviewConfig struct {
+	// MinConfidence drops issues the model is less confident in than this,
+	// from 0 to 1 (0 = keep everything). Issues without a confidence count as 1.
+	MinConfidence float64 `json:"min_confidence,omitempty" yaml:"min_confidence,omitempty"`
+
+	// DedupLines is how many lines apart identical findings in a file may be
+	// and still collapse into one (0 = 10, negative disables deduplication)
+	DedupLines int `json:"dedup_lines,omitempty" yaml:"dedup_lines,omitempty"`
+
+	// MaxComments caps the findings posted as comments, keeping the most
+	// severe; the rest are counted in the summary (0 = no limit)
+	MaxComments int `json:"max_comments,omitempty" yaml:"max_comments,omitempty"`
+
+	// IgnorePaths lists globs for files left out of reviews, such as lockfiles
+	// and generated code. Patterns without a slash match the file name in any
+	// directory; "**" matches any number of directories.
+	IgnorePaths []string `json:"ignore_paths,omitempty" yaml:"ignore_paths,omitempty"`
+
+	// Locale is the language findings and summaries are written in, as a
+	// locale code such as "pt-BR" or a language name (empty = English).
+	// Rule IDs and severities are never translated.
+	Locale string `json:"locale,omitempty" yaml:"locale,omitempty"`
+}
+
 // DocumentationConfig configures documentation generation behavior
 type DocumentationConfig struct {
 	// Enabled controls whether documentation generation is active
@@ -66,6 +98,70 @@ type DocumentationConfig struct {
 
 	// Cache configuration for incremental builds
 	Cache CacheConfig `json:"cache" yaml:"cache"`
+
+	// Timeout bounds the whole documentation run as a Go duration (e.g., "30m").
+	// Empty or "0" disables the limit.
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// GlossaryTerms lists terms indexed in the generated glossary, in addition
+	// to terms declared in pages with <!-- term:X --> markers
+	GlossaryTerms []string `json:"glossary_terms,omitempty" yaml:"glossary_terms,omitempty"`
+
+	// ExcludedDirs lists directory names skipped while scanning sources, in
+	// addition to the built-in defaults (vendor, node_modules, dist, ...)
+	ExcludedDirs []string `json:"excluded_dirs,omitempty" yaml:"excluded_dirs,omitempty"`
+
+	// ExtensionOverrides maps file extensions to the language that documents
+	// them (e.g. ".tsx": "typescript"), taking precedence over the defaults
+	ExtensionOverrides map[string]string `json:"extension_overrides,omitempty" yaml:"extension_overrides,omitempty"`
+
+	// Welcome tunes the LLM calls that write the welcome page
+	Welcome WelcomeConfig `json:"welcome,omitempty" yaml:"welcome,omitempty"`
+}
+
+// WelcomeConfig tunes retries and timeouts of welcome page generation.
+// Empty fields use the pipeline defaults.
+type WelcomeConfig struct {
+	// Retries is how many times a failed LLM call is retried (-1 disables retries)
+	Retries int `json:"retries,omitempty" yaml:"retries,omitempty"`
+
+	// RetryDelay is the pause between attempts as a Go duration (e.g., "2s")
+	RetryDelay string `json:"retry_delay,omitempty" yaml:"retry_delay,omitempty"`
+
+	// Timeout bounds each LLM attempt as a Go duration (e.g., "2m")
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// Durations parses RetryDelay and Timeout, returning 0 for empty fields
+func (w WelcomeConfig) Durations() (retryDelay, timeout time.Duration, err error) {
+	if retryDelay, err = parseOptionalDuration("welcome retry delay", w.RetryDelay); err != nil {
+		return 0, 0, err
+	}
+	if timeout, err = parseOptionalDuration("welcome timeout", w.Timeout); err != nil {
+		return 0, 0, err
+	}
+	return retryDelay, timeout, nil
+}
+
+// parseOptionalDuration parses a non-negative Go duration; empty means 0
+func parseOptionalDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must not be negative", name, value)
+	}
+	return d, nil
+}
+
+// TimeoutDuration parses Timeout, returning 0 when no limit is configured
+func (d DocumentationConfig) TimeoutDuration() (time.Duration, error) {
+	return parseOptionalDuration("documentation timeout", d.Timeout)
 }
 
 // DeployConfig configures documentation deployment
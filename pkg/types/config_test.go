@@ -2,6 +2,7 @@ package types
 
 import (
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -24,6 +25,10 @@ func TestNewDefaultConfig(t *testing.T) {
 	if cfg.LLM.MaxTokens != 4000 {
 		t.Errorf("Expected max_tokens 4000, got %d", cfg.LLM.MaxTokens)
 	}
+
+	if timeout, err := cfg.Documentation.TimeoutDuration(); err != nil || timeout != 0 {
+		t.Errorf("Expected no documentation timeout by default, got %v (%v)", timeout, err)
+	}
 }
 
 func TestConfigYAMLRoundTrip(t *testing.T) {
@@ -310,3 +315,51 @@ func TestDocumentationConfigRoundTrip(t *testing.T) {
 	}
 }
 
+
+func TestDocumentationConfig_TimeoutDuration(t *testing.T) {
+	tests := []struct {
+		timeout string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"0", 0, false},
+		{"30m", 30 * time.Minute, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"soon", 0, true},
+		{"-5m", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timeout, func(t *testing.T) {
+			cfg := DocumentationConfig{Timeout: tt.timeout}
+			got, err := cfg.TimeoutDuration()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TimeoutDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TimeoutDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWelcomeConfig_Durations(t *testing.T) {
+	delay, timeout, err := WelcomeConfig{RetryDelay: "500ms", Timeout: "90s"}.Durations()
+	if err != nil {
+		t.Fatalf("Durations() error = %v", err)
+	}
+	if delay != 500*time.Millisecond || timeout != 90*time.Second {
+		t.Errorf("Durations() = %v, %v, want 500ms, 90s", delay, timeout)
+	}
+
+	if delay, timeout, err := (WelcomeConfig{}).Durations(); err != nil || delay != 0 || timeout != 0 {
+		t.Errorf("empty config should give zero durations, got %v, %v, %v", delay, timeout, err)
+	}
+
+	for _, cfg := range []WelcomeConfig{{RetryDelay: "soon"}, {Timeout: "-1s"}} {
+		if _, _, err := cfg.Durations(); err == nil {
+			t.Errorf("expected an error for %+v", cfg)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SARIF 2.1.0 document structure, limited to the fields AurumCode emits
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId,omitempty"`
+	RuleIndex  *int              `json:"ruleIndex,omitempty"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// ToSARIF renders the review issues as a SARIF 2.1.0 log, suitable for the
+// GitHub code scanning upload API. Each distinct rule ID becomes a rule.
+func (r ReviewResult) ToSARIF() ([]byte, error) {
+	driver := sarifDriver{
+		Name:           "AurumCode",
+		InformationURI: "https://github.com/Mpaape/AurumCode",
+	}
+	results := make([]sarifResult, 0, len(r.Issues))
+	ruleIndex := make(map[string]int)
+
+	for _, issue := range r.Issues {
+		result := sarifResult{
+			Level:   sarifLevel(issue.Severity),
+			Message: sarifMessage{Text: issue.Message},
+		}
+
+		if issue.RuleID != "" {
+			idx, ok := ruleIndex[issue.RuleID]
+			if !ok {
+				idx = len(driver.Rules)
+				ruleIndex[issue.RuleID] = idx
+				driver.Rules = append(driver.Rules, sarifRule{
+					ID:               issue.RuleID,
+					ShortDescription: sarifMessage{Text: issue.RuleID},
+				})
+			}
+			result.RuleID = issue.RuleID
+			result.RuleIndex = &idx
+		}
+
+		if issue.File != "" {
+			location := sarifPhysicalLocation{
+				// Paths from the model may use either separator; URIs use "/"
+				ArtifactLocation: sarifArtifactLocation{URI: strings.ReplaceAll(issue.File, `\`, "/")},
+			}
+			// SARIF lines are 1-based; 0 means the whole file
+			if issue.Line > 0 {
+				location.Region = &sarifRegion{StartLine: issue.Line}
+			}
+			result.Locations = []sarifLocation{{PhysicalLocation: location}}
+		}
+
+		if issue.Suggestion != "" {
+			result.Properties = map[string]string{"suggestion": issue.Suggestion}
+		}
+
+		results = append(results, result)
+	}
+
+	return json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: driver},
+			Results: results,
+		}},
+	}, "", "  ")
+}
+
+// sarifLevel maps a severity onto a SARIF result level
+func sarifLevel(severity Severity) string {
+	parsed, err := ParseSeverity(string(severity))
+	if err != nil {
+		// SARIF's default level
+		return "warning"
+	}
+
+	switch parsed {
+	case SeverityError:
+		return "error"
+	case SeverityInfo:
+		return "note"
+	default:
+		return "warning"
+	}
+}
@@ -0,0 +1,143 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func sampleReviewResult() ReviewResult {
+	return ReviewResult{
+		Issues: []ReviewIssue{
+			{File: "internal/db/query.go", Line: 42, Severity: "error", RuleID: "security/sql-injection",
+				Message: "Query built from user input", Suggestion: "Use a prepared statement"},
+			{File: "cmd/main.go", Line: 7, Severity: "CRITICAL", RuleID: "security/sql-injection",
+				Message: "Query built from flag value"},
+			{File: `pkg\util\strings.go`, Line: 3, Severity: "warning", RuleID: "style/naming",
+				Message: "Exported name stutters"},
+			{File: "README.md", Severity: "info", Message: "Missing usage section"},
+		},
+		Summary: "Two injection risks",
+	}
+}
+
+func TestReviewResultToSARIF(t *testing.T) {
+	data, err := sampleReviewResult().ToSARIF()
+	if err != nil {
+		t.Fatalf("ToSARIF failed: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("ToSARIF produced invalid JSON: %v", err)
+	}
+
+	if log.Version != "2.1.0" || log.Schema != sarifSchema {
+		t.Errorf("version/schema = %q/%q", log.Version, log.Schema)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("Expected 1 run, got %d", len(log.Runs))
+	}
+
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "AurumCode" {
+		t.Errorf("driver name = %q", run.Tool.Driver.Name)
+	}
+
+	// Rules are deduplicated in first-seen order
+	rules := run.Tool.Driver.Rules
+	if len(rules) != 2 || rules[0].ID != "security/sql-injection" || rules[1].ID != "style/naming" {
+		t.Fatalf("rules = %+v, want sql-injection then naming", rules)
+	}
+
+	if len(run.Results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(run.Results))
+	}
+
+	wantLevels := []string{"error", "error", "warning", "note"}
+	for i, want := range wantLevels {
+		if got := run.Results[i].Level; got != want {
+			t.Errorf("result %d level = %q, want %q", i, got, want)
+		}
+	}
+
+	first := run.Results[0]
+	if first.RuleID != "security/sql-injection" || first.RuleIndex == nil || *first.RuleIndex != 0 {
+		t.Errorf("first result rule = %q/%v", first.RuleID, first.RuleIndex)
+	}
+	if first.Message.Text != "Query built from user input" {
+		t.Errorf("message = %q", first.Message.Text)
+	}
+	loc := first.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "internal/db/query.go" || loc.Region == nil || loc.Region.StartLine != 42 {
+		t.Errorf("location = %+v", loc)
+	}
+	if first.Properties["suggestion"] != "Use a prepared statement" {
+		t.Errorf("suggestion = %q", first.Properties["suggestion"])
+	}
+
+	if *run.Results[2].RuleIndex != 1 {
+		t.Errorf("naming result ruleIndex = %d, want 1", *run.Results[2].RuleIndex)
+	}
+	if uri := run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "pkg/util/strings.go" {
+		t.Errorf("URI = %q, want forward slashes", uri)
+	}
+
+	last := run.Results[3]
+	if last.RuleID != "" || last.RuleIndex != nil {
+		t.Errorf("issue without rule should have no ruleId, got %q", last.RuleID)
+	}
+	if last.Locations[0].PhysicalLocation.Region != nil {
+		t.Error("issue without a line should have no region")
+	}
+}
+
+func TestReviewResultToSARIF_Empty(t *testing.T) {
+	data, err := ReviewResult{}.ToSARIF()
+	if err != nil {
+		t.Fatalf("ToSARIF failed: %v", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	runs := raw["runs"].([]interface{})
+	results, ok := runs[0].(map[string]interface{})["results"].([]interface{})
+	if !ok || len(results) != 0 {
+		t.Errorf("expected an empty results array, got %v", runs[0])
+	}
+}
+
+func TestReviewResultToSARIF_RoundTrip(t *testing.T) {
+	original := sampleReviewResult()
+
+	data, err := original.ToSARIF()
+	if err != nil {
+		t.Fatalf("ToSARIF failed: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	again, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("SARIF changed on round trip:\n%s\nvs\n%s", data, again)
+	}
+
+	// Every issue is recoverable from the document
+	for i, issue := range original.Issues {
+		result := log.Runs[0].Results[i]
+		if result.Message.Text != issue.Message || result.RuleID != issue.RuleID {
+			t.Errorf("result %d = %+v, want issue %+v", i, result, issue)
+		}
+		if issue.Line > 0 && result.Locations[0].PhysicalLocation.Region.StartLine != issue.Line {
+			t.Errorf("result %d line = %d, want %d", i, result.Locations[0].PhysicalLocation.Region.StartLine, issue.Line)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Severity classifies how serious a review issue is
+type Severity string
+
+// Canonical severities, from most to least serious
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// ErrUnknownSeverity indicates a severity string that matches no known level
+var ErrUnknownSeverity = errors.New("unknown severity")
+
+// severityAliases maps accepted spellings to canonical severities. The
+// critical/high/medium scale used by reports maps onto error/warning/info.
+var severityAliases = map[string]Severity{
+	"error":    SeverityError,
+	"critical": SeverityError,
+	"warning":  SeverityWarning,
+	"warn":     SeverityWarning,
+	"high":     SeverityWarning,
+	"info":     SeverityInfo,
+	"medium":   SeverityInfo,
+	"low":      SeverityInfo,
+	"note":     SeverityInfo,
+}
+
+// ParseSeverity converts s to a canonical severity, ignoring case and
+// surrounding whitespace
+func ParseSeverity(s string) (Severity, error) {
+	severity, ok := severityAliases[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownSeverity, s)
+	}
+	return severity, nil
+}
+
+// Rank orders severities: info is 1, warning 2, error 3. Unknown values,
+// including non-canonical spellings, rank 0.
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast reports whether s is as serious as min. Both are parsed first,
+// so "CRITICAL" is at least "warning".
+func (s Severity) AtLeast(min Severity) bool {
+	return s.normalized().Rank() >= min.normalized().Rank()
+}
+
+// normalized returns the canonical form of s, or s unchanged if unknown
+func (s Severity) normalized() Severity {
+	if parsed, err := ParseSeverity(string(s)); err == nil {
+		return parsed
+	}
+	return s
+}
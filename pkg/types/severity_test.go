@@ -0,0 +1,78 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Severity
+	}{
+		{"error", SeverityError},
+		{"ERROR", SeverityError},
+		{"critical", SeverityError},
+		{"CRITICAL", SeverityError},
+		{"warning", SeverityWarning},
+		{"Warning", SeverityWarning},
+		{"warn", SeverityWarning},
+		{"HIGH", SeverityWarning},
+		{"info", SeverityInfo},
+		{"INFO", SeverityInfo},
+		{"medium", SeverityInfo},
+		{"low", SeverityInfo},
+		{"note", SeverityInfo},
+		{"  error\n", SeverityError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSeverity(tt.input)
+			if err != nil {
+				t.Fatalf("ParseSeverity(%q) failed: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSeverity(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSeverity_Unknown(t *testing.T) {
+	for _, input := range []string{"", "fatal", "errors"} {
+		if _, err := ParseSeverity(input); !errors.Is(err, ErrUnknownSeverity) {
+			t.Errorf("ParseSeverity(%q): expected ErrUnknownSeverity, got %v", input, err)
+		}
+	}
+}
+
+func TestSeverityOrdering(t *testing.T) {
+	if !(SeverityError.Rank() > SeverityWarning.Rank() && SeverityWarning.Rank() > SeverityInfo.Rank()) {
+		t.Error("expected error > warning > info")
+	}
+	if Severity("bogus").Rank() != 0 {
+		t.Error("unknown severity should rank 0")
+	}
+
+	tests := []struct {
+		severity Severity
+		min      Severity
+		want     bool
+	}{
+		{SeverityError, SeverityWarning, true},
+		{SeverityWarning, SeverityWarning, true},
+		{SeverityInfo, SeverityWarning, false},
+		{"CRITICAL", SeverityError, true},
+		{"HIGH", "warning", true},
+		{"medium", SeverityWarning, false},
+		{SeverityInfo, SeverityInfo, true},
+		{"bogus", SeverityInfo, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.severity.AtLeast(tt.min); got != tt.want {
+			t.Errorf("%q.AtLeast(%q) = %v, want %v", tt.severity, tt.min, got, tt.want)
+		}
+	}
+}
@@ -21,6 +21,11 @@ type DiffFile struct {
 	Path  string     `json:"path" yaml:"path"`
 	Lang  string     `json:"lang" yaml:"lang"`
 	Hunks []DiffHunk `json:"hunks" yaml:"hunks"`
+
+	OldPath  string `json:"old_path,omitempty" yaml:"old_path,omitempty"`   // Previous path of a renamed file
+	IsRename bool   `json:"is_rename,omitempty" yaml:"is_rename,omitempty"` // File was moved, with or without edits
+	IsDelete bool   `json:"is_delete,omitempty" yaml:"is_delete,omitempty"` // File was removed; Path is its last path
+	IsBinary bool   `json:"is_binary,omitempty" yaml:"is_binary,omitempty"` // Binary change without hunks
 }
 
 // DiffHunk represents a single hunk within a file diff
@@ -30,6 +35,10 @@ type DiffHunk struct {
 	NewStart int      `json:"new_start" yaml:"new_start"`
 	NewLines int      `json:"new_lines" yaml:"new_lines"`
 	Lines    []string `json:"lines" yaml:"lines"`
+
+	// NoNewlineAtEOF is set when git marked a side of the hunk with
+	// "\ No newline at end of file"; the marker is not kept in Lines
+	NoNewlineAtEOF bool `json:"no_newline_at_eof,omitempty" yaml:"no_newline_at_eof,omitempty"`
 }
 
 // Diff represents the complete parsed diff
@@ -39,13 +48,25 @@ type Diff struct {
 
 // ReviewIssue represents a single finding in a code review
 type ReviewIssue struct {
-	ID         string `json:"id" yaml:"id"`
-	File       string `json:"file" yaml:"file"`
-	Line       int    `json:"line" yaml:"line"`
-	Severity   string `json:"severity" yaml:"severity"` // "error", "warning", "info"
-	RuleID     string `json:"rule_id" yaml:"rule_id"`
-	Message    string `json:"message" yaml:"message"`
-	Suggestion string `json:"suggestion,omitempty" yaml:"suggestion,omitempty"`
+	ID         string   `json:"id" yaml:"id"`
+	File       string   `json:"file" yaml:"file"`
+	Line       int      `json:"line" yaml:"line"`
+	Severity   Severity `json:"severity" yaml:"severity"` // "error", "warning", "info"
+	RuleID     string   `json:"rule_id" yaml:"rule_id"`
+	Message    string   `json:"message" yaml:"message"`
+	Suggestion string   `json:"suggestion,omitempty" yaml:"suggestion,omitempty"`
+
+	// Confidence is the model's certainty in the finding, from 0 to 1;
+	// nil when the model did not say
+	Confidence *float64 `json:"confidence,omitempty" yaml:"confidence,omitempty"`
+}
+
+// ConfidenceScore returns the issue's confidence, treating an omitted value as 1
+func (i ReviewIssue) ConfidenceScore() float64 {
+	if i.Confidence == nil {
+		return 1.0
+	}
+	return *i.Confidence
 }
 
 // ISOScores represents ISO/IEC 25010 quality characteristics
@@ -72,6 +93,20 @@ type ReviewResult struct {
 	Metadata         map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
+// ReviewResultSchema is the JSON shape LLM reviewers are asked to return.
+// It is itself valid JSON that decodes into ReviewResult.
+const ReviewResultSchema = `{
+  "issues": [
+    {"file": "path/to/file", "line": 1, "severity": "error|warning|info", "rule_id": "category/rule", "message": "...", "suggestion": "...", "confidence": 1.0}
+  ],
+  "iso_scores": {
+    "functionality": 0, "reliability": 0, "usability": 0, "efficiency": 0,
+    "maintainability": 0, "portability": 0, "security": 0, "compatibility": 0
+  },
+  "summary": "...",
+  "overall_score": 0
+}`
+
 // ReviewComment represents a comment to post on a PR
 type ReviewComment struct {
 	Path     string `json:"path" yaml:"path"`         // Empty for general PR comment
@@ -2,6 +2,8 @@ package types
 
 import (
 	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -108,3 +110,67 @@ func TestISOScoresZeroValues(t *testing.T) {
 		t.Errorf("Expected 0 for Security, got %d", scores.Security)
 	}
 }
+
+func TestReviewResultSchemaDecodes(t *testing.T) {
+	// Every key the prompt asks for must map onto a ReviewResult field
+	dec := json.NewDecoder(strings.NewReader(ReviewResultSchema))
+	dec.DisallowUnknownFields()
+
+	var result ReviewResult
+	if err := dec.Decode(&result); err != nil {
+		t.Fatalf("ReviewResultSchema does not match ReviewResult: %v", err)
+	}
+
+	if len(result.Issues) != 1 {
+		t.Fatalf("Expected 1 example issue, got %d", len(result.Issues))
+	}
+	issue := result.Issues[0]
+	if issue.File == "" || issue.Line != 1 || issue.RuleID == "" || issue.Message == "" || issue.Suggestion == "" {
+		t.Errorf("Example issue not fully decoded: %+v", issue)
+	}
+	if result.ISOScores == nil {
+		t.Error("Expected iso_scores to decode")
+	}
+	if result.Summary == "" {
+		t.Error("Expected summary to decode")
+	}
+}
+
+func TestReviewResultSchemaRoundTrip(t *testing.T) {
+	response := `{
+  "issues": [
+    {"file": "main.go", "line": 12, "severity": "error", "rule_id": "security/sql-injection", "message": "Query built from user input", "suggestion": "Use a prepared statement"}
+  ],
+  "iso_scores": {
+    "functionality": 8, "reliability": 7, "usability": 6, "efficiency": 9,
+    "maintainability": 5, "portability": 8, "security": 3, "compatibility": 7
+  },
+  "summary": "One critical issue",
+  "overall_score": 6.5
+}`
+
+	var result ReviewResult
+	if err := json.Unmarshal([]byte(response), &result); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if result.Issues[0].Severity != SeverityError {
+		t.Errorf("Severity = %q, want %q", result.Issues[0].Severity, SeverityError)
+	}
+	if result.ISOScores.Security != 3 || result.OverallScore != 6.5 {
+		t.Errorf("Scores not decoded: %+v, overall %v", result.ISOScores, result.OverallScore)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var again ReviewResult
+	if err := json.Unmarshal(data, &again); err != nil {
+		t.Fatalf("Unmarshal of marshaled result failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, again) {
+		t.Errorf("Round trip changed the result:\n%+v\nvs\n%+v", result, again)
+	}
+}